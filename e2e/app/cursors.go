@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/omni-network/omni/e2e/netman"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/umath"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// StreamOffsets are the portal message offsets of a single src->dest stream.
+type StreamOffsets struct {
+	Stream xchain.StreamID `json:"stream"`
+	Name   string          `json:"name"`
+	Out    uint64          `json:"out"` // Source portal OutXMsgOffset; total emitted messages.
+	In     uint64          `json:"in"`  // Destination portal InXMsgOffset; total submitted messages.
+}
+
+// Gap returns the number of emitted but not yet submitted messages of the stream.
+func (o StreamOffsets) Gap() uint64 {
+	return umath.SubtractOrZero(o.Out, o.In)
+}
+
+// CursorMatrix is a structured matrix of the portal message offsets of all src->dest streams.
+type CursorMatrix struct {
+	offsets map[xchain.StreamID]StreamOffsets
+}
+
+// NewCursorMatrix returns an empty cursor matrix.
+func NewCursorMatrix() CursorMatrix {
+	return CursorMatrix{offsets: make(map[xchain.StreamID]StreamOffsets)}
+}
+
+// Add adds (or replaces) the offsets of a stream.
+func (m CursorMatrix) Add(offsets StreamOffsets) {
+	m.offsets[offsets.Stream] = offsets
+}
+
+// Get returns the offsets of the stream or false if not present.
+func (m CursorMatrix) Get(stream xchain.StreamID) (StreamOffsets, bool) {
+	offsets, ok := m.offsets[stream]
+	return offsets, ok
+}
+
+// Offsets returns the offsets of all streams, sorted by source chain, destination chain and shard.
+func (m CursorMatrix) Offsets() []StreamOffsets {
+	resp := make([]StreamOffsets, 0, len(m.offsets))
+	for _, offsets := range m.offsets {
+		resp = append(resp, offsets)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		a, b := resp[i].Stream, resp[j].Stream
+		if a.SourceChainID != b.SourceChainID {
+			return a.SourceChainID < b.SourceChainID
+		} else if a.DestChainID != b.DestChainID {
+			return a.DestChainID < b.DestChainID
+		}
+
+		return a.ShardID < b.ShardID
+	})
+
+	return resp
+}
+
+// Gaps returns the offsets of all streams with emitted but not yet submitted messages, sorted like Offsets.
+func (m CursorMatrix) Gaps() []StreamOffsets {
+	var resp []StreamOffsets
+	for _, offsets := range m.Offsets() {
+		if offsets.Gap() > 0 {
+			resp = append(resp, offsets)
+		}
+	}
+
+	return resp
+}
+
+// TotalGap returns the total number of emitted but not yet submitted messages of all streams.
+func (m CursorMatrix) TotalGap() uint64 {
+	var resp uint64
+	for _, offsets := range m.offsets {
+		resp += offsets.Gap()
+	}
+
+	return resp
+}
+
+// MarshalJSON returns the JSON encoding of the sorted stream offsets.
+func (m CursorMatrix) MarshalJSON() ([]byte, error) {
+	bz, err := json.Marshal(m.Offsets())
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal offsets")
+	}
+
+	return bz, nil
+}
+
+// CursorSnapshot is a cursor matrix queried at a point in time.
+type CursorSnapshot struct {
+	Matrix CursorMatrix
+	Time   time.Time
+}
+
+// StreamRate is the message throughput of a single src->dest stream between two cursor snapshots.
+type StreamRate struct {
+	Stream    xchain.StreamID `json:"stream"`
+	Name      string          `json:"name"`
+	Known     bool            `json:"known"`       // False if the rate is unknown, e.g., no prior snapshot.
+	OutPerSec float64         `json:"out_per_sec"` // Emitted messages per second.
+	InPerSec  float64         `json:"in_per_sec"`  // Submitted messages per second.
+}
+
+// StreamRates returns the message throughput rates of all streams of the current snapshot, sorted like Offsets.
+// Rates are unknown for the first sample (zero prior snapshot), for streams not in the prior snapshot,
+// for non-increasing snapshot times, and if offsets decreased (e.g., portal redeploys).
+func StreamRates(prev CursorSnapshot, curr CursorSnapshot) []StreamRate {
+	elapsed := curr.Time.Sub(prev.Time).Seconds()
+
+	resp := make([]StreamRate, 0, len(curr.Matrix.offsets))
+	for _, offsets := range curr.Matrix.Offsets() {
+		rate := StreamRate{Stream: offsets.Stream, Name: offsets.Name}
+
+		prevOffsets, ok := prev.Matrix.Get(offsets.Stream)
+		if ok && !prev.Time.IsZero() && elapsed > 0 && offsets.Out >= prevOffsets.Out && offsets.In >= prevOffsets.In {
+			rate.Known = true
+			rate.OutPerSec = float64(offsets.Out-prevOffsets.Out) / elapsed
+			rate.InPerSec = float64(offsets.In-prevOffsets.In) / elapsed
+		}
+
+		resp = append(resp, rate)
+	}
+
+	return resp
+}
+
+// BuildCursorMatrix returns the cursor matrix of all streams of the network by querying the portals.
+// It checks for context cancellation between destination chains, returning the partial matrix
+// of the chains queried so far together with the context error. In-progress queries complete
+// irrespective of cancellation (bounded by flushTimeout), so no queried state is lost on shutdown.
+func BuildCursorMatrix(ctx context.Context, portals map[uint64]netman.Portal, network netconf.Network) (CursorMatrix, error) {
+	queryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+	opts := &bind.CallOpts{Context: queryCtx}
+
+	resp := NewCursorMatrix()
+	for _, dest := range network.EVMChains() {
+		if ctx.Err() != nil {
+			return resp, errors.Wrap(ctx.Err(), "build cursor matrix")
+		}
+
+		for _, stream := range network.StreamsFrom(dest.ID) {
+			srcOffset, err := portals[stream.SourceChainID].Contract.OutXMsgOffset(opts, stream.DestChainID, uint64(stream.ShardID))
+			if err != nil {
+				return CursorMatrix{}, errors.Wrap(err, "get outXMsgOffset")
+			}
+
+			destOffset, err := portals[stream.DestChainID].Contract.InXMsgOffset(opts, stream.SourceChainID, uint64(stream.ShardID))
+			if err != nil {
+				return CursorMatrix{}, errors.Wrap(err, "getting inXMsgOffset")
+			}
+
+			resp.Add(StreamOffsets{
+				Stream: stream,
+				Name:   network.StreamName(stream),
+				Out:    srcOffset,
+				In:     destOffset,
+			})
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,80 @@
+package app_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/e2e/app"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorMatrix(t *testing.T) {
+	t.Parallel()
+
+	stream := func(src, dest uint64) xchain.StreamID {
+		return xchain.StreamID{SourceChainID: src, DestChainID: dest, ShardID: xchain.ShardFinalized0}
+	}
+
+	matrix := app.NewCursorMatrix()
+	matrix.Add(app.StreamOffsets{Stream: stream(2, 1), Name: "b|a", Out: 7, In: 7})
+	matrix.Add(app.StreamOffsets{Stream: stream(1, 2), Name: "a|b", Out: 10, In: 4})
+	matrix.Add(app.StreamOffsets{Stream: stream(1, 3), Name: "a|c", Out: 3, In: 5}) // Unexpected, but no underflow
+
+	offsets, ok := matrix.Get(stream(1, 2))
+	require.True(t, ok)
+	require.Equal(t, uint64(6), offsets.Gap())
+	_, ok = matrix.Get(stream(3, 1))
+	require.False(t, ok)
+
+	var names []string
+	for _, offsets := range matrix.Offsets() {
+		names = append(names, offsets.Name)
+	}
+	require.Equal(t, []string{"a|b", "a|c", "b|a"}, names)
+
+	gaps := matrix.Gaps()
+	require.Len(t, gaps, 1)
+	require.Equal(t, "a|b", gaps[0].Name)
+	require.Equal(t, uint64(6), matrix.TotalGap())
+
+	bz, err := json.Marshal(matrix)
+	require.NoError(t, err)
+	var decoded []app.StreamOffsets
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, matrix.Offsets(), decoded)
+}
+
+func TestStreamRates(t *testing.T) {
+	t.Parallel()
+
+	stream := func(src, dest uint64) xchain.StreamID {
+		return xchain.StreamID{SourceChainID: src, DestChainID: dest, ShardID: xchain.ShardFinalized0}
+	}
+
+	t0 := time.Unix(1000, 0)
+	prev := app.NewCursorMatrix()
+	prev.Add(app.StreamOffsets{Stream: stream(1, 2), Name: "a|b", Out: 10, In: 4})
+	prev.Add(app.StreamOffsets{Stream: stream(1, 3), Name: "a|c", Out: 10, In: 10})
+
+	curr := app.NewCursorMatrix()
+	curr.Add(app.StreamOffsets{Stream: stream(1, 2), Name: "a|b", Out: 30, In: 14})
+	curr.Add(app.StreamOffsets{Stream: stream(1, 3), Name: "a|c", Out: 5, In: 5}) // Redeployed
+	curr.Add(app.StreamOffsets{Stream: stream(2, 1), Name: "b|a", Out: 1, In: 1}) // New stream
+
+	snapshot := app.CursorSnapshot{Matrix: curr, Time: t0.Add(10 * time.Second)}
+
+	// First sample rates are unknown.
+	for _, rate := range app.StreamRates(app.CursorSnapshot{}, snapshot) {
+		require.False(t, rate.Known, rate.Name)
+	}
+
+	rates := app.StreamRates(app.CursorSnapshot{Matrix: prev, Time: t0}, snapshot)
+	require.Equal(t, []app.StreamRate{
+		{Stream: stream(1, 2), Name: "a|b", Known: true, OutPerSec: 2, InPerSec: 1},
+		{Stream: stream(1, 3), Name: "a|c"},
+		{Stream: stream(2, 1), Name: "b|a"},
+	}, rates)
+}
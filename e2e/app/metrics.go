@@ -0,0 +1,13 @@
+package app
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var msgRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "e2e",
+	Subsystem: "monitor",
+	Name:      "msg_rate_per_second",
+	Help:      "Cross chain messages per second per stream and direction (out=emitted, in=submitted). Only populated if the rate is known. Alert on sudden drops.",
+}, []string{"stream", "direction"})
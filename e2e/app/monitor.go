@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"sync"
+	"time"
 
 	"github.com/omni-network/omni/e2e/netman"
 	cprovider "github.com/omni-network/omni/lib/cchain/provider"
@@ -28,7 +29,8 @@ func LogMetrics(ctx context.Context, def Definition) error {
 		return errors.Wrap(err, "monitoring cchain provider")
 	}
 
-	if err := MonitorCursors(ctx, def.Netman().Portals(), extNetwork); err != nil {
+	// This is the first (and only) sample, so message rates are unknown.
+	if _, err := MonitorCursors(ctx, def.Netman().Portals(), extNetwork, CursorSnapshot{}); err != nil {
 		return errors.Wrap(err, "monitoring cursors")
 	}
 
@@ -45,7 +47,10 @@ func StartMonitoringReceipts(ctx context.Context, def Definition) func() error {
 
 	network := NetworkFromDef(def)
 	cProvider := cprovider.NewABCIProvider(client, def.Testnet.Network, netconf.ChainVersionNamer(def.Testnet.Network))
-	xProvider := xprovider.New(network, def.Backends().RPCClients(), cProvider)
+	xProvider, err := xprovider.New(network, def.Backends().RPCClients(), cProvider)
+	if err != nil {
+		return func() error { return errors.Wrap(err, "create xprovider") }
+	}
 	cChainID := def.Testnet.Network.Static().OmniConsensusChainIDUint64()
 
 	type void any
@@ -123,30 +128,62 @@ func StartMonitoringReceipts(ctx context.Context, def Definition) func() error {
 	}
 }
 
-func MonitorCursors(ctx context.Context, portals map[uint64]netman.Portal, network netconf.Network) error {
-	for _, dest := range network.EVMChains() {
-		for _, stream := range network.StreamsFrom(dest.ID) {
-			srcOffset, err := portals[stream.SourceChainID].Contract.OutXMsgOffset(nil, stream.DestChainID, uint64(stream.ShardID))
-			if err != nil {
-				return errors.Wrap(err, "get outXMsgOffset")
-			}
-
-			destOffset, err := portals[stream.DestChainID].Contract.InXMsgOffset(nil, stream.SourceChainID, uint64(stream.ShardID))
-			if err != nil {
-				return errors.Wrap(err, "getting inXMsgOffset")
-			}
+// flushTimeout bounds in-progress metric queries that complete after the context was canceled,
+// so the final state is still logged on shutdown.
+const flushTimeout = 10 * time.Second
+
+// MonitorCursors logs the submitted cross chain message offsets of all streams.
+// It also logs and instruments the message throughput rates of all streams since the previous snapshot,
+// which are unknown if the previous snapshot is zero (first sample). It returns the current snapshot,
+// to be provided as previous snapshot to the next call.
+// It is cancellation-aware, logging a final summary of the streams queried so far on shutdown.
+func MonitorCursors(ctx context.Context, portals map[uint64]netman.Portal, network netconf.Network, prev CursorSnapshot,
+) (CursorSnapshot, error) {
+	matrix, err := BuildCursorMatrix(ctx, portals, network)
+	if err != nil && ctx.Err() == nil {
+		return CursorSnapshot{}, err
+	}
+	snapshot := CursorSnapshot{Matrix: matrix, Time: time.Now()}
+
+	var totalIn, totalOut uint64
+	for _, offsets := range matrix.Offsets() {
+		totalIn += offsets.In
+		totalOut += offsets.Out
+		log.Debug(ctx, "Submitted cross chain messages",
+			"stream", offsets.Name,
+			"total_in", offsets.In,
+			"total_out", offsets.Out,
+		)
+	}
 
-			log.Debug(ctx, "Submitted cross chain messages",
-				"stream", network.StreamName(stream),
-				"total_in", destOffset,
-				"total_out", srcOffset,
-			)
+	log.Info(ctx, "Cross chain message cursors summary",
+		"streams", len(matrix.Offsets()),
+		"total_in", totalIn,
+		"total_out", totalOut,
+		"total_gap", matrix.TotalGap(),
+		"complete", ctx.Err() == nil,
+	)
+
+	for _, rate := range StreamRates(prev, snapshot) {
+		if !rate.Known {
+			log.Debug(ctx, "Cross chain message rate unknown", "stream", rate.Name)
+			continue
 		}
+
+		msgRate.WithLabelValues(rate.Name, "out").Set(rate.OutPerSec)
+		msgRate.WithLabelValues(rate.Name, "in").Set(rate.InPerSec)
+		log.Debug(ctx, "Cross chain message rate",
+			"stream", rate.Name,
+			"out_per_sec", rate.OutPerSec,
+			"in_per_sec", rate.InPerSec,
+		)
 	}
 
-	return nil
+	return snapshot, nil
 }
 
+// MonitorCProvider logs the number of approved attestations of all chain versions.
+// It is cancellation-aware, logging a final summary of the chain versions queried so far on shutdown.
 func MonitorCProvider(ctx context.Context, node *e2e.Node, network netconf.Network) error {
 	client, err := node.Client()
 	if err != nil {
@@ -155,16 +192,38 @@ func MonitorCProvider(ctx context.Context, node *e2e.Node, network netconf.Netwo
 
 	cprov := cprovider.NewABCIProvider(client, network.ID, netconf.ChainVersionNamer(network.ID))
 
+	// Allow in-progress queries to complete after cancellation.
+	queryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+
+	var chainVers, total int
+	logSummary := func() {
+		log.Info(ctx, "Halo approved attestations summary",
+			"chain_versions", chainVers,
+			"total", total,
+			"complete", ctx.Err() == nil,
+		)
+	}
+
 	for _, chain := range network.Chains {
 		for _, chainVer := range chain.ChainVersions() {
-			atts, err := cprov.AttestationsFrom(ctx, chainVer, 1)
+			if ctx.Err() != nil {
+				logSummary()
+				return nil
+			}
+
+			atts, err := cprov.AttestationsFrom(queryCtx, chainVer, 1)
 			if err != nil {
 				return errors.Wrap(err, "getting approved attestations")
 			}
 
+			chainVers++
+			total += len(atts)
 			log.Debug(ctx, "Halo approved attestations", "chain", chain.Name, "conf", chainVer.ConfLevel, "count", len(atts))
 		}
 	}
 
+	logSummary()
+
 	return nil
 }
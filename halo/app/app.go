@@ -4,6 +4,7 @@ import (
 	"context"
 	"regexp"
 
+	magellan2 "github.com/omni-network/omni/halo/app/upgrades/magellan"
 	attestkeeper "github.com/omni-network/omni/halo/attest/keeper"
 	atypes "github.com/omni-network/omni/halo/attest/types"
 	"github.com/omni-network/omni/halo/comet"
@@ -146,6 +147,7 @@ func newApp(
 	app.EVMEngKeeper.SetVoteProvider(app.AttestKeeper)
 	app.AttestKeeper.SetValidatorProvider(app.ValSyncKeeper)
 	app.AttestKeeper.SetPortalRegistry(app.RegistryKeeper)
+	app.AttestKeeper.SetStateUpgrade(app.UpgradeKeeper, magellan2.UpgradeName)
 
 	baseAppOpts = append(baseAppOpts, func(bapp *baseapp.BaseApp) {
 		// Use evm engine to create block proposals.
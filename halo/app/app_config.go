@@ -49,6 +49,7 @@ const (
 	genesisVoteExtLimit   uint64 = 256
 	genesisTrimLag        uint64 = 1      // Allow deleting attestations in block after approval.
 	genesisCTrimLag       uint64 = 72_000 // Delete consensus attestations state after +-1 day (given a period of 1.2s).
+	genesisMaxAttAge      uint64 = 0      // Disabled, since enabling it is consensus breaking.
 )
 
 //nolint:gochecknoglobals // Cosmos-style
@@ -179,6 +180,7 @@ var (
 						VoteExtensionLimit: genesisVoteExtLimit,
 						TrimLag:            genesisTrimLag,
 						ConsensusTrimLag:   genesisCTrimLag,
+						MaxAttestationAge:  genesisMaxAttAge,
 					}),
 				},
 				{
@@ -59,8 +59,6 @@ func newVoterLoader(privKey crypto.PrivKey) (*voterLoader, error) {
 
 // LazyLoad blocks until the network config can be loaded from the on-chain registry, then it initializes and starts
 // the voter instance and binds it to the lazy wrapper.
-//
-//nolint:nestif // 2 levels is not that bad
 func (l *voterLoader) LazyLoad(
 	ctx context.Context,
 	netID netconf.ID,
@@ -71,6 +69,7 @@ func (l *voterLoader) LazyLoad(
 	voterStateFile string,
 	cmtAPI comet.API,
 	asyncAbort chan<- error,
+	opts ...voter.Option,
 ) error {
 	if len(endpoints) == 0 {
 		log.Warn(ctx, "Flag --xchain-evm-rpc-endpoints empty. The app will crash if it becomes a validator since it cannot perform xchain voting duties", nil)
@@ -92,6 +91,8 @@ func (l *voterLoader) LazyLoad(
 
 	log.Info(ctx, "🫡 Local halo node is a validator, starting voter")
 
+	phases := newPhaseTimer() // Voter startup phases are timed after becoming a validator.
+
 	if len(endpoints) == 0 {
 		// Note that this negatively affects chain liveness, but xchain liveness already negatively affected so rather
 		// highlight the issue to the operator by crashing. #allornothing
@@ -116,52 +117,24 @@ func (l *voterLoader) LazyLoad(
 	if err != nil {
 		return err
 	}
+	phases.Done(ctx, "load_network")
 
-	var xprov xchain.Provider
-	if netID == netconf.Simnet {
-		omni, ok := network.OmniConsensusChain()
-		if !ok {
-			return errors.New("omni chain not found in network")
-		}
-
-		xprov, err = xprovider.NewMock(omni.BlockPeriod*8/10, omni.ID, cprov)
-		if err != nil {
-			return err
-		}
-	} else {
-		ethClients := make(map[uint64]ethclient.Client)
-		for _, chain := range network.EVMChains() {
-			// Use EngineAPI as omni_evm RPC client.
-			if netconf.IsOmniExecution(netID, chain.ID) {
-				ethClients[chain.ID] = omniEVMCl
-				continue
-			}
-
-			rpc, err := endpoints.ByNameOrID(chain.Name, chain.ID)
-			if err != nil {
-				return err
-			}
-
-			ethCl, err := ethclient.Dial(chain.Name, rpc)
-			if err != nil {
-				return err
-			}
-
-			ethClients[chain.ID] = ethCl
-		}
-
-		xprov = xprovider.New(network, ethClients, cprov)
+	xprov, err := newXProvider(netID, network, omniEVMCl, endpoints, cprov)
+	if err != nil {
+		return err
 	}
+	phases.Done(ctx, "create_xprovider")
 
 	deps := voteDeps{
 		API:      cmtAPI,
 		Provider: cprov,
 	}
 
-	v, err := voter.LoadVoter(privKey, voterStateFile, xprov, deps, network, asyncAbort)
+	v, err := voter.LoadVoter(privKey, voterStateFile, xprov, deps, network, asyncAbort, opts...)
 	if err != nil {
 		return errors.Wrap(err, "create voter")
 	}
+	phases.Done(ctx, "create_attester")
 
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -283,3 +256,70 @@ func (l *voterLoader) WaitDone() {
 		return
 	}
 }
+
+// voterOpts returns the voter options as per the halo config.
+func voterOpts(cfg Config) []voter.Option {
+	var opts []voter.Option
+	if cfg.AttesterAuditFile != "" {
+		opts = append(opts, voter.WithAuditLog(cfg.AttesterAuditFile, cfg.AttesterAuditFsync))
+	}
+	if cfg.AttesterSubmitInterval > 0 || cfg.AttesterSubmitMaxVotes > 0 {
+		opts = append(opts, voter.WithSubmitRateLimit(cfg.AttesterSubmitInterval, cfg.AttesterSubmitMaxVotes))
+	}
+
+	return opts
+}
+
+// newXProvider returns a new xchain provider for the network, dialing all EVM chains.
+// All chains are dialed, returning the errors of all failed chains at once.
+func newXProvider(
+	netID netconf.ID,
+	network netconf.Network,
+	omniEVMCl ethclient.Client,
+	endpoints xchain.RPCEndpoints,
+	cprov cprovider.Provider,
+) (xchain.Provider, error) {
+	if netID == netconf.Simnet {
+		omni, ok := network.OmniConsensusChain()
+		if !ok {
+			return nil, errors.New("omni chain not found in network")
+		}
+
+		return xprovider.NewMock(omni.BlockPeriod*8/10, omni.ID, cprov)
+	}
+
+	var dialErrs errors.MultiError
+	ethClients := make(map[uint64]ethclient.Client)
+	for _, chain := range network.EVMChains() {
+		// Use EngineAPI as omni_evm RPC client.
+		if netconf.IsOmniExecution(netID, chain.ID) {
+			ethClients[chain.ID] = omniEVMCl
+			continue
+		}
+
+		rpc, err := endpoints.ByNameOrID(chain.Name, chain.ID)
+		if err != nil {
+			dialErrs.Add(chain.ID, chain.Name, err)
+			continue
+		}
+
+		ethCl, err := ethclient.Dial(chain.Name, rpc)
+		if err != nil {
+			dialErrs.Add(chain.ID, chain.Name, err)
+			continue
+		}
+
+		ethClients[chain.ID] = ethCl
+	}
+
+	if err := dialErrs.ErrOrNil(); err != nil {
+		return nil, errors.Wrap(err, "dial chains")
+	}
+
+	xprov, err := xprovider.New(network, ethClients, cprov)
+	if err != nil {
+		return nil, errors.Wrap(err, "create xprovider")
+	}
+
+	return xprov, nil
+}
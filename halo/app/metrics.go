@@ -47,6 +47,20 @@ var (
 		Name:      "size_bytes",
 		Help:      "Current size of the database directory in bytes.",
 	})
+
+	snapshotSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "halo",
+		Subsystem: "snapshot",
+		Name:      "size_bytes",
+		Help:      "Current size of the state-sync snapshot directory in bytes.",
+	})
+
+	startupPhaseDuration = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "halo",
+		Subsystem: "startup",
+		Name:      "phase_duration_seconds",
+		Help:      "Duration of each halo startup phase in seconds by phase.",
+	}, []string{"phase"})
 )
 
 // setConstantGauge sets the value of a gauge to 1 if b is true, 0 otherwise.
@@ -16,12 +16,14 @@ import (
 
 // monitorCometForever blocks until the context is canceled.
 // It periodically calls monitorCometOnce.
+// The snapshot size isn't monitored if snapshotDir is empty.
 func monitorCometForever(
 	ctx context.Context,
 	network netconf.ID,
 	rpcClient rpcclient.Client,
 	isSyncing func() bool,
 	dbDir string,
+	snapshotDir string,
 ) {
 	if network == netconf.Simnet {
 		return // Simnet doesn't need to monitor cometBFT, since no p2p.
@@ -52,6 +54,17 @@ func monitorCometForever(
 			} else {
 				dbSize.Set(float64(size))
 			}
+
+			// Monitor snapshot size.
+			if snapshotDir == "" {
+				continue
+			}
+			size, err = dirSize(snapshotDir)
+			if err != nil {
+				log.Warn(ctx, "Failed monitoring snapshot size (will retry)", err)
+			} else {
+				snapshotSize.Set(float64(size))
+			}
 		}
 	}
 }
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/omni-network/omni/lib/log"
+)
+
+// phaseTimer times sequential startup phases, logging and instrumenting the duration of each.
+type phaseTimer struct {
+	start time.Time
+	last  time.Time
+}
+
+func newPhaseTimer() *phaseTimer {
+	now := time.Now()
+	return &phaseTimer{start: now, last: now}
+}
+
+// Done marks the completion of the phase that started when the previous phase completed.
+func (t *phaseTimer) Done(ctx context.Context, phase string) {
+	now := time.Now()
+	duration := now.Sub(t.last)
+	t.last = now
+
+	observePhase(ctx, phase, duration)
+}
+
+// Total returns the duration since the timer was created.
+func (t *phaseTimer) Total() time.Duration {
+	return time.Since(t.start)
+}
+
+// observePhase logs and instruments the duration of the startup phase.
+func observePhase(ctx context.Context, phase string, duration time.Duration) {
+	startupPhaseDuration.WithLabelValues(phase).Set(duration.Seconds())
+	log.Info(ctx, "Startup phase completed", "phase", phase, "duration", duration)
+}
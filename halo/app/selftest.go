@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/halo/genutil/evm/predeploys"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/xchain"
+
+	k1 "github.com/cometbft/cometbft/crypto/secp256k1"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// selfTestTimeout is the timeout of each self test network round-trip.
+const selfTestTimeout = time.Second * 10
+
+// SelfTest verifies the halo config, the network loaded from the portal registry,
+// and the connectivity of the engine API and xchain RPC endpoints.
+// Unlike Config.Verify, it performs network round-trips. It logs a report of all checks and
+// returns an error if any failed. It is intended as a deployment smoke test before promoting a node to validator.
+func SelfTest(ctx context.Context, cfg Config) error {
+	if err := cfg.Verify(); err != nil {
+		return errors.Wrap(err, "verify halo config")
+	}
+
+	var failed int
+	check := func(name string, fn func(context.Context) error) {
+		ctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+		defer cancel()
+
+		if err := fn(ctx); err != nil {
+			log.Error(ctx, "❌ Self test failed", err, "check", name)
+			failed++
+
+			return
+		}
+
+		log.Info(ctx, "✅ Self test passed", "check", name)
+	}
+
+	check("engine", func(ctx context.Context) error {
+		// Public key only used by simnet mock engine.
+		engineCl, err := newEngineClient(ctx, cfg, cfg.Network, k1.GenPrivKey().PubKey())
+		if err != nil {
+			return err
+		}
+
+		if _, err := engineCl.BlockNumber(ctx); err != nil {
+			return errors.Wrap(err, "query block number")
+		} else if cfg.Network == netconf.Simnet {
+			return nil // Mock engine doesn't support chain ID.
+		}
+
+		return verifyChainID(ctx, engineCl, cfg.Network.Static().OmniExecutionChainID)
+	})
+
+	check("network", func(ctx context.Context) error {
+		// Public key only used by simnet mock engine.
+		engineCl, err := newEngineClient(ctx, cfg, cfg.Network, k1.GenPrivKey().PubKey())
+		if err != nil {
+			return err
+		}
+
+		portalReg, err := bindings.NewPortalRegistry(common.HexToAddress(predeploys.PortalRegistry), engineCl)
+		if err != nil {
+			return errors.Wrap(err, "create portal registry")
+		}
+
+		// Don't await expected chains, since verifyNetwork reports missing chains.
+		network, err := netconf.AwaitOnExecutionChain(ctx, cfg.Network, portalReg, nil)
+		if err != nil {
+			return errors.Wrap(err, "load network")
+		}
+
+		return verifyNetwork(network, cfg.RPCEndpoints)
+	})
+
+	names := cfg.RPCEndpoints.Keys()
+	sort.Strings(names)
+	for _, name := range names {
+		check("xchain:"+name, func(ctx context.Context) error {
+			expected, err := chainIDByNameOrID(name)
+			if err != nil {
+				return err
+			}
+
+			ethCl, err := ethclient.Dial(name, cfg.RPCEndpoints[name])
+			if err != nil {
+				return err
+			}
+			defer ethCl.Close()
+
+			return verifyChainID(ctx, ethCl, expected)
+		})
+	}
+
+	if failed > 0 {
+		return errors.New("self test failed", "failed", failed, "total", len(names)+2)
+	}
+
+	log.Info(ctx, "Self test succeeded", "total", len(names)+2)
+
+	return nil
+}
+
+// verifyNetwork returns an error if the configured RPC endpoints don't match the network chains,
+// or if the network portals don't match the static portal deployments.
+func verifyNetwork(network netconf.Network, endpoints xchain.RPCEndpoints) error {
+	for _, chain := range network.EVMChains() {
+		if deployment, ok := network.ID.Static().PortalDeployment(chain.ID); ok && deployment.Address != chain.PortalAddress {
+			return errors.New("portal address mismatch", "chain", chain.Name, "expected", deployment.Address, "actual", chain.PortalAddress)
+		}
+
+		if netconf.IsOmniExecution(network.ID, chain.ID) {
+			continue // The engine API is used as omni_evm RPC client.
+		}
+
+		if _, err := endpoints.ByNameOrID(chain.Name, chain.ID); err != nil {
+			return err
+		}
+	}
+
+	names := endpoints.Keys()
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := network.ChainByName(name); ok {
+			continue
+		} else if chainID, err := strconv.ParseUint(name, 10, 64); err == nil {
+			if _, ok := network.Chain(chainID); ok {
+				continue
+			}
+		}
+
+		return errors.New("rpc endpoint chain not in network", "chain", name)
+	}
+
+	return nil
+}
+
+// verifyChainID returns an error if the client's chain ID doesn't match the expected chain ID.
+func verifyChainID(ctx context.Context, cl ethclient.Client, expected uint64) error {
+	chainID, err := cl.ChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "query chain id")
+	} else if !chainID.IsUint64() || chainID.Uint64() != expected {
+		return errors.New("chain id mismatch", "expected", expected, "actual", chainID)
+	}
+
+	return nil
+}
+
+// chainIDByNameOrID returns the chain ID of a RPC endpoint key, which is either a chain name or ID.
+func chainIDByNameOrID(nameOrID string) (uint64, error) {
+	if chainID, err := strconv.ParseUint(nameOrID, 10, 64); err == nil {
+		return chainID, nil
+	}
+
+	meta, ok := evmchain.MetadataByName(nameOrID)
+	if !ok {
+		return 0, errors.New("unknown chain name", "name", nameOrID)
+	}
+
+	return meta.ChainID, nil
+}
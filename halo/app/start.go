@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"encoding/hex"
+	"maps"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/omni-network/omni/halo/comet"
@@ -18,13 +20,18 @@ import (
 	"github.com/omni-network/omni/lib/tracer"
 	etypes "github.com/omni-network/omni/octane/evmengine/types"
 
+	"github.com/cometbft/cometbft/blocksync"
 	cmtcfg "github.com/cometbft/cometbft/config"
+	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto"
+	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/node"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
 	rpclocal "github.com/cometbft/cometbft/rpc/client/local"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/statesync"
 	cmttypes "github.com/cometbft/cometbft/types"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -95,10 +102,23 @@ func Run(ctx context.Context, cfg Config) error {
 func Start(ctx context.Context, cfg Config) (<-chan error, func(context.Context) error, error) {
 	log.Info(ctx, "Starting halo consensus client", "moniker", cfg.Comet.Moniker)
 
+	phases := newPhaseTimer()
+
 	if err := cfg.Verify(); err != nil {
 		return nil, nil, errors.Wrap(err, "verify halo config")
 	}
 
+	if err := verifyConsensusTimeouts(ctx, cfg.Network, cfg.Comet.Consensus); err != nil {
+		return nil, nil, errors.Wrap(err, "verify consensus timeouts")
+	}
+
+	if !cfg.SnapshotsEnabled && cfg.SnapshotInterval > 0 {
+		log.Warn(ctx, "Snapshots disabled, ignoring snapshot interval; this node will not serve state sync", nil,
+			"snapshot_interval", cfg.SnapshotInterval)
+	}
+
+	phases.Done(ctx, "verify_config")
+
 	buildinfo.Instrument(ctx)
 
 	tracerIDs := tracer.Identifiers{Network: cfg.Network, Service: "halo", Instance: cfg.Comet.Moniker}
@@ -111,17 +131,20 @@ func Start(ctx context.Context, cfg Config) (<-chan error, func(context.Context)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "enable cosmos-sdk telemetry")
 	}
+	phases.Done(ctx, "init_telemetry")
 
 	privVal, err := loadPrivVal(cfg)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "load validator key")
 	}
 	logPrivVal(ctx, privVal)
+	phases.Done(ctx, "load_key")
 
 	db, err := dbm.NewDB("application", cfg.BackendType(), cfg.DataDir())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "create db")
 	}
+	phases.Done(ctx, "open_db")
 
 	baseAppOpts, err := makeBaseAppOpts(cfg)
 	if err != nil {
@@ -132,6 +155,7 @@ func Start(ctx context.Context, cfg Config) (<-chan error, func(context.Context)
 	if err != nil {
 		return nil, nil, err
 	}
+	phases.Done(ctx, "dial_engine")
 
 	voter, err := newVoterLoader(privVal.Key.PrivKey) // Construct a lazy voter loader
 	if err != nil {
@@ -164,11 +188,15 @@ func Start(ctx context.Context, cfg Config) (<-chan error, func(context.Context)
 
 	app.EVMEngKeeper.SetBuildDelay(cfg.EVMBuildDelay)
 	app.EVMEngKeeper.SetBuildOptimistic(cfg.EVMBuildOptimistic)
+	app.AttestKeeper.SetTableSizeWarning(cfg.AttestTableWarnThreshold, cfg.AttestTableCheckInterval)
+	app.AttestKeeper.SetConcurrentVerify(cfg.AttestConcurrentVerify)
+	phases.Done(ctx, "create_app")
 
-	cmtNode, err := newCometNode(ctx, &cfg.Comet, app, privVal)
+	cmtNode, err := newCometNode(ctx, &cfg.Comet, cfg.Instrumentation, app, privVal)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "create comet node")
 	}
+	phases.Done(ctx, "create_comet_node")
 
 	rpcClient := rpclocal.New(cmtNode)
 	cmtAPI := comet.NewAPI(rpcClient)
@@ -187,6 +215,7 @@ func Start(ctx context.Context, cfg Config) (<-chan error, func(context.Context)
 			cfg.VoterStateFile(),
 			cmtAPI,
 			asyncAbort,
+			voterOpts(cfg)...,
 		)
 		if err != nil {
 			asyncAbort <- err
@@ -197,15 +226,26 @@ func Start(ctx context.Context, cfg Config) (<-chan error, func(context.Context)
 	if err := startRPCServers(ctx, cfg, app, sdkLogger, metrics, asyncAbort, clientCtx); err != nil {
 		return nil, nil, err
 	}
+	phases.Done(ctx, "start_rpc_servers")
 
 	log.Info(ctx, "Starting CometBFT")
 
 	if err := cmtNode.Start(); err != nil {
 		return nil, nil, errors.Wrap(err, "start comet node")
 	}
+	phases.Done(ctx, "start_comet_node")
+	log.Info(ctx, "Halo consensus client started", "duration", phases.Total())
+
+	var snapshotDir string
+	if cfg.SnapshotsEnabled {
+		snapshotDir = cfg.SnapshotDir()
+	}
 
-	go monitorCometForever(ctx, cfg.Network, rpcClient, cmtNode.ConsensusReactor().WaitSync, cfg.DataDir())
+	go monitorCometForever(ctx, cfg.Network, rpcClient, cmtNode.ConsensusReactor().WaitSync, cfg.DataDir(), snapshotDir)
 	go monitorEVMForever(ctx, cfg, engineCl)
+	go app.AttestKeeper.RunTableSizeChecks(ctx, func() (context.Context, error) {
+		return app.CreateQueryContext(0, false) // Latest committed state
+	})
 
 	// Return asyncAbort and stop functions.
 	// Note that the original context used to start the app must be canceled first.
@@ -269,7 +309,7 @@ func startRPCServers(
 	return nil
 }
 
-func newCometNode(ctx context.Context, cfg *cmtcfg.Config, app *App, privVal cmttypes.PrivValidator,
+func newCometNode(ctx context.Context, cfg *cmtcfg.Config, instrCfg halocfg.InstrumentationConfig, app *App, privVal cmttypes.PrivValidator,
 ) (*node.Node, error) {
 	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
 	if err != nil {
@@ -295,7 +335,7 @@ func newCometNode(ctx context.Context, cfg *cmtcfg.Config, app *App, privVal cmt
 		proxy.NewLocalClientCreator(wrapper),
 		node.DefaultGenesisDocProviderFunc(cfg),
 		cmtcfg.DefaultDBProvider,
-		node.DefaultMetricsProvider(cfg.Instrumentation),
+		newMetricsProvider(cfg.Instrumentation, instrCfg),
 		cmtLog,
 	)
 	if err != nil {
@@ -305,36 +345,75 @@ func newCometNode(ctx context.Context, cfg *cmtcfg.Config, app *App, privVal cmt
 	return cmtNode, nil
 }
 
-func makeBaseAppOpts(cfg Config) ([]func(*baseapp.BaseApp), error) {
-	chainID, err := chainIDFromGenesis(cfg)
-	if err != nil {
-		return nil, err
+// newMetricsProvider returns a CometBFT metrics provider like node.DefaultMetricsProvider,
+// but with the namespace override and static labels of the halo instrumentation config.
+func newMetricsProvider(cmtCfg *cmtcfg.InstrumentationConfig, instrCfg halocfg.InstrumentationConfig) node.MetricsProvider {
+	if !cmtCfg.Prometheus {
+		return node.DefaultMetricsProvider(cmtCfg) // Nop metrics
 	}
 
-	snapshotStore, err := newSnapshotStore(cfg)
+	namespace := cmtCfg.Namespace
+	if instrCfg.Namespace != "" {
+		namespace = instrCfg.Namespace
+	}
+
+	keys := slices.Sorted(maps.Keys(instrCfg.Labels))
+
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics) {
+		labels := []string{"chain_id", chainID}
+		for _, key := range keys {
+			labels = append(labels, key, instrCfg.Labels[key])
+		}
+
+		return cs.PrometheusMetrics(namespace, labels...),
+			p2p.PrometheusMetrics(namespace, labels...),
+			mempl.PrometheusMetrics(namespace, labels...),
+			sm.PrometheusMetrics(namespace, labels...),
+			proxy.PrometheusMetrics(namespace, labels...),
+			blocksync.PrometheusMetrics(namespace, labels...),
+			statesync.PrometheusMetrics(namespace, labels...)
+	}
+}
+
+func makeBaseAppOpts(cfg Config) ([]func(*baseapp.BaseApp), error) {
+	chainID, err := chainIDFromGenesis(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	snapshotOptions := snapshottypes.NewSnapshotOptions(cfg.SnapshotInterval, cfg.SnapshotKeepRecent)
-
 	pruneOpts := pruningtypes.NewPruningOptionsFromString(cfg.PruningOption)
 	if cfg.PruningOption == pruningtypes.PruningOptionDefault {
 		// We interpret "default" to be PruningEverything, since historical state isn't very important.
 		pruneOpts = pruningtypes.NewPruningOptions(pruningtypes.PruningEverything)
 	}
 
-	return []func(*baseapp.BaseApp){
+	opts := []func(*baseapp.BaseApp){
 		// baseapp.SetOptimisticExecution(), // Octane doesn't support this.
 		baseapp.SetChainID(chainID),
 		baseapp.SetMinRetainBlocks(cfg.MinRetainBlocks),
 		baseapp.SetPruning(pruneOpts),
 		baseapp.SetInterBlockCache(store.NewCommitKVStoreCacheManager()),
-		baseapp.SetSnapshot(snapshotStore, snapshotOptions),
 		baseapp.SetMempool(mempool.NoOpMempool{}),
-	}, nil
+	}
+
+	if !cfg.SnapshotsEnabled {
+		// Without a snapshot manager, baseapp doesn't take, list or serve any snapshots.
+		return opts, nil
+	}
+
+	snapshotStore, err := newSnapshotStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotOptions := snapshottypes.NewSnapshotOptions(cfg.SnapshotInterval, cfg.SnapshotKeepRecent)
+
+	return append(opts, baseapp.SetSnapshot(snapshotStore, snapshotOptions)), nil
 }
 
+// newSnapshotStore returns a new snapshot store in the snapshot directory.
+// Note that snapshot chunks are already zlib compressed by the snapshot manager before being stored,
+// and peers verify chunk hashes of the stored bytes, so the store itself doesn't compress chunks.
 func newSnapshotStore(cfg Config) (*snapshots.Store, error) {
 	db, err := dbm.NewDB("metadata", cfg.BackendType(), cfg.SnapshotDir())
 	if err != nil {
@@ -359,6 +438,7 @@ func chainIDFromGenesis(cfg Config) (string, error) {
 }
 
 // newEngineClient returns a new engine API client.
+// The engine JWT secret is loaded from the halocfg.EngineJWTEnv environment variable if set, otherwise from the configured file.
 func newEngineClient(ctx context.Context, cfg Config, network netconf.ID, pubkey crypto.PubKey) (ethclient.EngineClient, error) {
 	if network == netconf.Simnet {
 		return ethclient.NewEngineMock(
@@ -368,9 +448,9 @@ func newEngineClient(ctx context.Context, cfg Config, network netconf.ID, pubkey
 		)
 	}
 
-	jwtBytes, err := ethclient.LoadJWTHexFile(cfg.EngineJWTFile)
+	jwtBytes, err := ethclient.LoadJWT(halocfg.EngineJWTEnv, cfg.EngineJWTFile)
 	if err != nil {
-		return nil, errors.Wrap(err, "load engine JWT file")
+		return nil, errors.Wrap(err, "load engine JWT")
 	}
 
 	engineCl, err := ethclient.NewAuthClient(ctx, cfg.EngineEndpoint, jwtBytes)
@@ -4,9 +4,13 @@ package app_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +23,7 @@ import (
 	"github.com/omni-network/omni/lib/cchain/grpc"
 	cprovider "github.com/omni-network/omni/lib/cchain/provider"
 	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/log"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tutil"
@@ -199,6 +204,42 @@ func testCProvider(t *testing.T, ctx context.Context, cprov cprovider.Provider)
 	}, time.Second*5, time.Millisecond*100)
 }
 
+func TestSelfTest(t *testing.T) {
+	ctx := log.WithNoopLogger(context.Background())
+	cfg := setupSimnet(t)
+
+	// Mock RPC server returning the chain ID of the URL path.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chainID, _ := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/"), 10, 64)
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"%#x"}`, chainID)
+	}))
+	defer srv.Close()
+
+	endpoint := func(chainID uint64) string {
+		return srv.URL + "/" + strconv.FormatUint(chainID, 10)
+	}
+
+	// Simnet network chains, excluding omni_evm which uses the engine API.
+	cfg.RPCEndpoints = map[string]string{"mock_l1": endpoint(evmchain.IDMockL1), "1654": endpoint(evmchain.IDMockL2)}
+	require.NoError(t, haloapp.SelfTest(ctx, cfg))
+
+	// Chain ID mismatch.
+	cfg.RPCEndpoints = map[string]string{"mock_l1": endpoint(evmchain.IDMockL2), "mock_l2": endpoint(evmchain.IDMockL2)}
+	require.ErrorContains(t, haloapp.SelfTest(ctx, cfg), "self test failed")
+
+	// Unknown chain name.
+	cfg.RPCEndpoints = map[string]string{"unknown": endpoint(1)}
+	require.ErrorContains(t, haloapp.SelfTest(ctx, cfg), "self test failed")
+
+	// Network chain without endpoint.
+	cfg.RPCEndpoints = map[string]string{"mock_l1": endpoint(evmchain.IDMockL1)}
+	require.ErrorContains(t, haloapp.SelfTest(ctx, cfg), "self test failed")
+
+	// Reachable endpoint of a chain not in the network.
+	cfg.RPCEndpoints = map[string]string{"mock_l1": endpoint(evmchain.IDMockL1), "mock_l2": endpoint(evmchain.IDMockL2), "1": endpoint(1)}
+	require.ErrorContains(t, haloapp.SelfTest(ctx, cfg), "self test failed")
+}
+
 func setupSimnet(t *testing.T) haloapp.Config {
 	t.Helper()
 	homeDir := t.TempDir()
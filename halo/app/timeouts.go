@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+)
+
+// maxTimeoutFactor is the multiple of the block period above which consensus timeouts are
+// considered clearly wrong, since the node would fall behind and desync from the network.
+const maxTimeoutFactor = 10
+
+// verifyConsensusTimeouts verifies the cometBFT consensus timeouts against the network's omni consensus chain block period.
+// It returns an error for clearly wrong values and logs a warning for suspicious values.
+func verifyConsensusTimeouts(ctx context.Context, network netconf.ID, cfg *cmtcfg.ConsensusConfig) error {
+	if cfg == nil {
+		return errors.New("nil consensus config")
+	}
+
+	period := network.Static().OmniConsensusChain().BlockPeriod
+	maxTimeout := period * maxTimeoutFactor
+
+	if !cfg.SkipTimeoutCommit {
+		if cfg.TimeoutCommit > maxTimeout {
+			return errors.New("consensus commit timeout too large for network block period",
+				"timeout_commit", cfg.TimeoutCommit, "block_period", period, "max", maxTimeout)
+		} else if cfg.TimeoutCommit > period {
+			log.Warn(ctx, "Consensus commit timeout exceeds network block period, this node will slow down block production", nil,
+				"timeout_commit", cfg.TimeoutCommit, "block_period", period)
+		}
+	}
+
+	for _, timeout := range []struct {
+		Name     string
+		Duration time.Duration
+	}{
+		{"timeout_propose", cfg.TimeoutPropose},
+		{"timeout_prevote", cfg.TimeoutPrevote},
+		{"timeout_precommit", cfg.TimeoutPrecommit},
+	} {
+		if timeout.Duration > maxTimeout {
+			return errors.New("consensus timeout too large for network block period",
+				timeout.Name, timeout.Duration, "block_period", period, "max", maxTimeout)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/netconf"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyConsensusTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*cmtcfg.ConsensusConfig)
+		errStr string
+	}{
+		{
+			name:   "default",
+			modify: func(*cmtcfg.ConsensusConfig) {},
+		},
+		{
+			name: "slow commit",
+			modify: func(cfg *cmtcfg.ConsensusConfig) {
+				cfg.TimeoutCommit = time.Second * 5 // Warning only
+			},
+		},
+		{
+			name: "commit too large",
+			modify: func(cfg *cmtcfg.ConsensusConfig) {
+				cfg.TimeoutCommit = time.Second * 99
+			},
+			errStr: "consensus commit timeout too large",
+		},
+		{
+			name: "commit too large but skipped",
+			modify: func(cfg *cmtcfg.ConsensusConfig) {
+				cfg.TimeoutCommit = time.Second * 99
+				cfg.SkipTimeoutCommit = true
+			},
+		},
+		{
+			name: "propose too large",
+			modify: func(cfg *cmtcfg.ConsensusConfig) {
+				cfg.TimeoutPropose = time.Minute
+			},
+			errStr: "consensus timeout too large",
+		},
+		{
+			name: "precommit too large",
+			modify: func(cfg *cmtcfg.ConsensusConfig) {
+				cfg.TimeoutPrecommit = time.Minute
+			},
+			errStr: "consensus timeout too large",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := cmtcfg.DefaultConsensusConfig()
+			test.modify(cfg)
+
+			err := verifyConsensusTimeouts(context.Background(), netconf.Mainnet, cfg)
+			if test.errStr != "" {
+				require.ErrorContains(t, err, test.errStr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
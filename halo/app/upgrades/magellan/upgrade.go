@@ -0,0 +1,25 @@
+// Package magellan defines the second omni consensus chain upgrade named after the explorer.
+// It enables storing attestation created and approved timestamps and double sign evidence in the attest module.
+// It doesn't include any store migrations.
+package magellan
+
+import (
+	"context"
+
+	storetypes "cosmossdk.io/store/types"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+const UpgradeName = "2_magellan"
+
+var StoreUpgrades storetypes.StoreUpgrades // Zero store upgrades
+
+func CreateUpgradeHandler(
+	mm *module.Manager,
+	configurator module.Configurator,
+) upgradetypes.UpgradeHandler {
+	return func(ctx context.Context, _ upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		return mm.RunMigrations(ctx, configurator, fromVM)
+	}
+}
@@ -436,9 +436,216 @@ func NewSignatureTable(db ormtable.Schema) (SignatureTable, error) {
 	return signatureTable{table.(ormtable.AutoIncrementTable)}, nil
 }
 
+type DoubleSignTable interface {
+	Insert(ctx context.Context, doubleSign *DoubleSign) error
+	InsertReturningId(ctx context.Context, doubleSign *DoubleSign) (uint64, error)
+	LastInsertedSequence(ctx context.Context) (uint64, error)
+	Update(ctx context.Context, doubleSign *DoubleSign) error
+	Save(ctx context.Context, doubleSign *DoubleSign) error
+	Delete(ctx context.Context, doubleSign *DoubleSign) error
+	Has(ctx context.Context, id uint64) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, id uint64) (*DoubleSign, error)
+	HasByValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRoot(ctx context.Context, validator_address []byte, chain_id uint64, conf_level uint32, attest_offset uint64, conflicting_attestation_root []byte) (found bool, err error)
+	// GetByValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRoot returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	GetByValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRoot(ctx context.Context, validator_address []byte, chain_id uint64, conf_level uint32, attest_offset uint64, conflicting_attestation_root []byte) (*DoubleSign, error)
+	List(ctx context.Context, prefixKey DoubleSignIndexKey, opts ...ormlist.Option) (DoubleSignIterator, error)
+	ListRange(ctx context.Context, from, to DoubleSignIndexKey, opts ...ormlist.Option) (DoubleSignIterator, error)
+	DeleteBy(ctx context.Context, prefixKey DoubleSignIndexKey) error
+	DeleteRange(ctx context.Context, from, to DoubleSignIndexKey) error
+
+	doNotImplement()
+}
+
+type DoubleSignIterator struct {
+	ormtable.Iterator
+}
+
+func (i DoubleSignIterator) Value() (*DoubleSign, error) {
+	var doubleSign DoubleSign
+	err := i.UnmarshalMessage(&doubleSign)
+	return &doubleSign, err
+}
+
+type DoubleSignIndexKey interface {
+	id() uint32
+	values() []interface{}
+	doubleSignIndexKey()
+}
+
+// primary key starting index..
+type DoubleSignPrimaryKey = DoubleSignIdIndexKey
+
+type DoubleSignIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x DoubleSignIdIndexKey) id() uint32            { return 0 }
+func (x DoubleSignIdIndexKey) values() []interface{} { return x.vs }
+func (x DoubleSignIdIndexKey) doubleSignIndexKey()   {}
+
+func (this DoubleSignIdIndexKey) WithId(id uint64) DoubleSignIdIndexKey {
+	this.vs = []interface{}{id}
+	return this
+}
+
+type DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey struct {
+	vs []interface{}
+}
+
+func (x DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) id() uint32 {
+	return 1
+}
+func (x DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) values() []interface{} {
+	return x.vs
+}
+func (x DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) doubleSignIndexKey() {
+}
+
+func (this DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) WithValidatorAddress(validator_address []byte) DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey {
+	this.vs = []interface{}{validator_address}
+	return this
+}
+
+func (this DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) WithValidatorAddressChainId(validator_address []byte, chain_id uint64) DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey {
+	this.vs = []interface{}{validator_address, chain_id}
+	return this
+}
+
+func (this DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) WithValidatorAddressChainIdConfLevel(validator_address []byte, chain_id uint64, conf_level uint32) DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey {
+	this.vs = []interface{}{validator_address, chain_id, conf_level}
+	return this
+}
+
+func (this DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) WithValidatorAddressChainIdConfLevelAttestOffset(validator_address []byte, chain_id uint64, conf_level uint32, attest_offset uint64) DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey {
+	this.vs = []interface{}{validator_address, chain_id, conf_level, attest_offset}
+	return this
+}
+
+func (this DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey) WithValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRoot(validator_address []byte, chain_id uint64, conf_level uint32, attest_offset uint64, conflicting_attestation_root []byte) DoubleSignValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRootIndexKey {
+	this.vs = []interface{}{validator_address, chain_id, conf_level, attest_offset, conflicting_attestation_root}
+	return this
+}
+
+type DoubleSignDetectedHeightIndexKey struct {
+	vs []interface{}
+}
+
+func (x DoubleSignDetectedHeightIndexKey) id() uint32            { return 2 }
+func (x DoubleSignDetectedHeightIndexKey) values() []interface{} { return x.vs }
+func (x DoubleSignDetectedHeightIndexKey) doubleSignIndexKey()   {}
+
+func (this DoubleSignDetectedHeightIndexKey) WithDetectedHeight(detected_height uint64) DoubleSignDetectedHeightIndexKey {
+	this.vs = []interface{}{detected_height}
+	return this
+}
+
+type doubleSignTable struct {
+	table ormtable.AutoIncrementTable
+}
+
+func (this doubleSignTable) Insert(ctx context.Context, doubleSign *DoubleSign) error {
+	return this.table.Insert(ctx, doubleSign)
+}
+
+func (this doubleSignTable) Update(ctx context.Context, doubleSign *DoubleSign) error {
+	return this.table.Update(ctx, doubleSign)
+}
+
+func (this doubleSignTable) Save(ctx context.Context, doubleSign *DoubleSign) error {
+	return this.table.Save(ctx, doubleSign)
+}
+
+func (this doubleSignTable) Delete(ctx context.Context, doubleSign *DoubleSign) error {
+	return this.table.Delete(ctx, doubleSign)
+}
+
+func (this doubleSignTable) InsertReturningId(ctx context.Context, doubleSign *DoubleSign) (uint64, error) {
+	return this.table.InsertReturningPKey(ctx, doubleSign)
+}
+
+func (this doubleSignTable) LastInsertedSequence(ctx context.Context) (uint64, error) {
+	return this.table.LastInsertedSequence(ctx)
+}
+
+func (this doubleSignTable) Has(ctx context.Context, id uint64) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, id)
+}
+
+func (this doubleSignTable) Get(ctx context.Context, id uint64) (*DoubleSign, error) {
+	var doubleSign DoubleSign
+	found, err := this.table.PrimaryKey().Get(ctx, &doubleSign, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &doubleSign, nil
+}
+
+func (this doubleSignTable) HasByValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRoot(ctx context.Context, validator_address []byte, chain_id uint64, conf_level uint32, attest_offset uint64, conflicting_attestation_root []byte) (found bool, err error) {
+	return this.table.GetIndexByID(1).(ormtable.UniqueIndex).Has(ctx,
+		validator_address,
+		chain_id,
+		conf_level,
+		attest_offset,
+		conflicting_attestation_root,
+	)
+}
+
+func (this doubleSignTable) GetByValidatorAddressChainIdConfLevelAttestOffsetConflictingAttestationRoot(ctx context.Context, validator_address []byte, chain_id uint64, conf_level uint32, attest_offset uint64, conflicting_attestation_root []byte) (*DoubleSign, error) {
+	var doubleSign DoubleSign
+	found, err := this.table.GetIndexByID(1).(ormtable.UniqueIndex).Get(ctx, &doubleSign,
+		validator_address,
+		chain_id,
+		conf_level,
+		attest_offset,
+		conflicting_attestation_root,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &doubleSign, nil
+}
+
+func (this doubleSignTable) List(ctx context.Context, prefixKey DoubleSignIndexKey, opts ...ormlist.Option) (DoubleSignIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return DoubleSignIterator{it}, err
+}
+
+func (this doubleSignTable) ListRange(ctx context.Context, from, to DoubleSignIndexKey, opts ...ormlist.Option) (DoubleSignIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return DoubleSignIterator{it}, err
+}
+
+func (this doubleSignTable) DeleteBy(ctx context.Context, prefixKey DoubleSignIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this doubleSignTable) DeleteRange(ctx context.Context, from, to DoubleSignIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this doubleSignTable) doNotImplement() {}
+
+var _ DoubleSignTable = doubleSignTable{}
+
+func NewDoubleSignTable(db ormtable.Schema) (DoubleSignTable, error) {
+	table := db.GetTable(&DoubleSign{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&DoubleSign{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return doubleSignTable{table.(ormtable.AutoIncrementTable)}, nil
+}
+
 type AttestationStore interface {
 	AttestationTable() AttestationTable
 	SignatureTable() SignatureTable
+	DoubleSignTable() DoubleSignTable
 
 	doNotImplement()
 }
@@ -446,6 +653,7 @@ type AttestationStore interface {
 type attestationStore struct {
 	attestation AttestationTable
 	signature   SignatureTable
+	doubleSign  DoubleSignTable
 }
 
 func (x attestationStore) AttestationTable() AttestationTable {
@@ -456,6 +664,10 @@ func (x attestationStore) SignatureTable() SignatureTable {
 	return x.signature
 }
 
+func (x attestationStore) DoubleSignTable() DoubleSignTable {
+	return x.doubleSign
+}
+
 func (attestationStore) doNotImplement() {}
 
 var _ AttestationStore = attestationStore{}
@@ -471,8 +683,14 @@ func NewAttestationStore(db ormtable.Schema) (AttestationStore, error) {
 		return nil, err
 	}
 
+	doubleSignTable, err := NewDoubleSignTable(db)
+	if err != nil {
+		return nil, err
+	}
+
 	return attestationStore{
 		attestationTable,
 		signatureTable,
+		doubleSignTable,
 	}, nil
 }
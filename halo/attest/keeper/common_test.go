@@ -108,10 +108,14 @@ func setupKeeper(t *testing.T, expectations ...expectation) (*keeper.Keeper, sdk
 		}
 	}
 
-	const voteWindowUp = 1
-	const voteWindowDown = 0
-	const voteLimit = 4
-	k, err := keeper.New(codec, storeSvc, m.skeeper, m.namer.ChainName, m.voter, voteWindowUp, voteWindowDown, voteLimit, trimLag, cTrimLag)
+	cfg := keeper.Config{
+		VoteWindowUp:   1,
+		VoteWindowDown: 0,
+		VoteExtLimit:   4,
+		TrimLag:        trimLag,
+		CTrimLag:       cTrimLag,
+	}
+	k, err := keeper.New(codec, storeSvc, m.skeeper, m.namer.ChainName, m.voter, cfg)
 	require.NoError(t, err, "new keeper")
 
 	k.SetValidatorProvider(m.valProvider)
@@ -0,0 +1,198 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/omni-network/omni/halo/attest/types"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"cosmossdk.io/orm/types/ormerrors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Double sign event type and attribute keys, see recordDoubleSign.
+const (
+	EventTypeDoubleSign         = "double_sign"
+	AttributeKeyValidator       = "validator"
+	AttributeKeyChainID         = "chain_id"
+	AttributeKeyConfLevel       = "conf_level"
+	AttributeKeyAttestOffset    = "attest_offset"
+	AttributeKeyExistingRoot    = "existing_attestation_root"
+	AttributeKeyConflictingRoot = "conflicting_attestation_root"
+)
+
+// Evidence is a record of a validator double sign (equivocation), i.e., two conflicting signatures
+// by the same validator for the same chain version and attest offset.
+type Evidence struct {
+	Validator      common.Address
+	ChainVersion   xchain.ChainVersion
+	AttestOffset   uint64
+	DetectedHeight uint64       // Consensus height at which the double sign was detected.
+	Existing       EvidenceVote // Previously included vote.
+	Conflicting    EvidenceVote // Conflicting vote detected later.
+}
+
+// EvidenceVote is one of the conflicting votes of double sign evidence.
+type EvidenceVote struct {
+	BlockHeight     uint64
+	BlockHash       common.Hash
+	AttestationRoot common.Hash
+	Signature       []byte
+}
+
+// DoubleSignEvidence returns the double sign evidence retained in state, oldest first.
+// Evidence is recorded when votes are added and deleted after the consensus chain trim lag, see deleteEvidenceBefore.
+// Evidence is only recorded after the state upgrade, see SetStateUpgrade.
+func (k *Keeper) DoubleSignEvidence(ctx context.Context) ([]Evidence, error) {
+	iter, err := k.dsTable.List(ctx, DoubleSignIdIndexKey{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list double signs")
+	}
+	defer iter.Close()
+
+	var resp []Evidence
+	for iter.Next() {
+		ds, err := iter.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "value double sign")
+		}
+
+		resp = append(resp, evidenceFromDB(ds))
+	}
+
+	return resp, nil
+}
+
+// recordDoubleSign records evidence of the double sign vote and emits a double sign event, see isDoubleSign.
+// Evidence is best-effort monitoring, so errors are logged rather than aborting vote processing.
+func (k *Keeper) recordDoubleSign(ctx context.Context, agg *types.AggVote, attRoot common.Hash, sig *types.SigTuple) {
+	evidence, err := k.doubleSignEvidence(ctx, agg, attRoot, sig)
+	if err != nil {
+		log.Warn(ctx, "Failed recording double sign evidence (will continue)", err,
+			"chain", k.namer(agg.AttestHeader.XChainVersion()),
+			"attest_offset", agg.AttestHeader.GetAttestOffset(),
+			log.Hex7("validator", sig.GetValidatorAddress()),
+		)
+
+		return
+	}
+
+	err = k.insertEvidence(ctx, evidence)
+	if errors.Is(err, ormerrors.UniqueKeyViolation) {
+		return // Already recorded.
+	} else if err != nil {
+		log.Warn(ctx, "Failed inserting double sign evidence (will continue)", err,
+			"chain", k.namer(evidence.ChainVersion),
+			"attest_offset", evidence.AttestOffset,
+			log.Hex7("validator", evidence.Validator.Bytes()),
+		)
+
+		return
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(EventTypeDoubleSign,
+		sdk.NewAttribute(AttributeKeyValidator, evidence.Validator.Hex()),
+		sdk.NewAttribute(AttributeKeyChainID, strconv.FormatUint(evidence.ChainVersion.ID, 10)),
+		sdk.NewAttribute(AttributeKeyConfLevel, evidence.ChainVersion.ConfLevel.String()),
+		sdk.NewAttribute(AttributeKeyAttestOffset, strconv.FormatUint(evidence.AttestOffset, 10)),
+		sdk.NewAttribute(AttributeKeyExistingRoot, evidence.Existing.AttestationRoot.Hex()),
+		sdk.NewAttribute(AttributeKeyConflictingRoot, evidence.Conflicting.AttestationRoot.Hex()),
+	))
+}
+
+// doubleSignEvidence returns the evidence of the double sign vote.
+func (k *Keeper) doubleSignEvidence(ctx context.Context, agg *types.AggVote, attRoot common.Hash, sig *types.SigTuple) (Evidence, error) {
+	header := agg.AttestHeader
+	existingSig, err := k.sigTable.GetByChainIdConfLevelAttestOffsetValidatorAddress(ctx, header.GetSourceChainId(), header.GetConfLevel(), header.GetAttestOffset(), sig.GetValidatorAddress())
+	if err != nil {
+		return Evidence{}, errors.Wrap(err, "get existing signature")
+	}
+
+	existing, err := k.attTable.Get(ctx, existingSig.GetAttId())
+	if err != nil {
+		return Evidence{}, errors.Wrap(err, "get existing attestation")
+	}
+
+	return Evidence{
+		Validator:      common.BytesToAddress(sig.GetValidatorAddress()),
+		ChainVersion:   header.XChainVersion(),
+		AttestOffset:   header.GetAttestOffset(),
+		DetectedHeight: uint64(sdk.UnwrapSDKContext(ctx).BlockHeight()),
+		Existing: EvidenceVote{
+			BlockHeight:     existing.GetBlockHeight(),
+			BlockHash:       common.BytesToHash(existing.GetBlockHash()),
+			AttestationRoot: common.BytesToHash(existing.GetAttestationRoot()),
+			Signature:       existingSig.GetSignature(),
+		},
+		Conflicting: EvidenceVote{
+			BlockHeight:     agg.BlockHeader.GetBlockHeight(),
+			BlockHash:       common.BytesToHash(agg.BlockHeader.GetBlockHash()),
+			AttestationRoot: attRoot,
+			Signature:       sig.GetSignature(),
+		},
+	}, nil
+}
+
+// insertEvidence stores the evidence if the state upgrade was applied, see SetStateUpgrade.
+func (k *Keeper) insertEvidence(ctx context.Context, evidence Evidence) error {
+	if ok, err := k.isStateUpgraded(ctx); err != nil || !ok {
+		return err
+	}
+
+	return k.dsTable.Insert(ctx, evidenceToDB(evidence))
+}
+
+// deleteEvidenceBefore deletes all double sign evidence detected before the given height (inclusive).
+func (k *Keeper) deleteEvidenceBefore(ctx context.Context, height uint64) error {
+	start := DoubleSignDetectedHeightIndexKey{}
+	end := DoubleSignDetectedHeightIndexKey{}.WithDetectedHeight(height)
+	if err := k.dsTable.DeleteRange(ctx, start, end); err != nil {
+		return errors.Wrap(err, "delete double signs")
+	}
+
+	return nil
+}
+
+func evidenceToDB(e Evidence) *DoubleSign {
+	return &DoubleSign{
+		ValidatorAddress:           e.Validator.Bytes(),
+		ChainId:                    e.ChainVersion.ID,
+		ConfLevel:                  uint32(e.ChainVersion.ConfLevel),
+		AttestOffset:               e.AttestOffset,
+		DetectedHeight:             e.DetectedHeight,
+		ExistingBlockHeight:        e.Existing.BlockHeight,
+		ExistingBlockHash:          e.Existing.BlockHash.Bytes(),
+		ExistingAttestationRoot:    e.Existing.AttestationRoot.Bytes(),
+		ExistingSignature:          e.Existing.Signature,
+		ConflictingBlockHeight:     e.Conflicting.BlockHeight,
+		ConflictingBlockHash:       e.Conflicting.BlockHash.Bytes(),
+		ConflictingAttestationRoot: e.Conflicting.AttestationRoot.Bytes(),
+		ConflictingSignature:       e.Conflicting.Signature,
+	}
+}
+
+func evidenceFromDB(ds *DoubleSign) Evidence {
+	return Evidence{
+		Validator:      common.BytesToAddress(ds.GetValidatorAddress()),
+		ChainVersion:   ds.XChainVersion(),
+		AttestOffset:   ds.GetAttestOffset(),
+		DetectedHeight: ds.GetDetectedHeight(),
+		Existing: EvidenceVote{
+			BlockHeight:     ds.GetExistingBlockHeight(),
+			BlockHash:       common.BytesToHash(ds.GetExistingBlockHash()),
+			AttestationRoot: common.BytesToHash(ds.GetExistingAttestationRoot()),
+			Signature:       ds.GetExistingSignature(),
+		},
+		Conflicting: EvidenceVote{
+			BlockHeight:     ds.GetConflictingBlockHeight(),
+			BlockHash:       common.BytesToHash(ds.GetConflictingBlockHash()),
+			AttestationRoot: common.BytesToHash(ds.GetConflictingAttestationRoot()),
+			Signature:       ds.GetConflictingSignature(),
+		},
+	}
+}
@@ -16,6 +16,13 @@ func (a *Attestation) XChainVersion() xchain.ChainVersion {
 	}
 }
 
+func (d *DoubleSign) XChainVersion() xchain.ChainVersion {
+	return xchain.ChainVersion{
+		ID:        d.GetChainId(),
+		ConfLevel: xchain.ConfLevel(d.GetConfLevel()),
+	}
+}
+
 func (a *Attestation) IsFuzzy() bool {
 	return xchain.ConfLevel(a.GetConfLevel()).IsFuzzy()
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"strconv"
+	"time"
 
 	"github.com/omni-network/omni/halo/attest/types"
 	rtypes "github.com/omni-network/omni/halo/registry/types"
@@ -43,23 +45,67 @@ var _ sdk.VerifyVoteExtensionHandler = (*Keeper)(nil).VerifyVoteExtension
 type Keeper struct {
 	attTable       AttestationTable
 	sigTable       SignatureTable
+	dsTable        DoubleSignTable
 	cdc            codec.BinaryCodec
 	storeService   store.KVStoreService
 	skeeper        baseapp.ValidatorStore
 	valProvider    vtypes.ValidatorProvider
 	portalRegistry rtypes.PortalRegistry
+	upgradeKeeper  types.UpgradeKeeper // Nil disables upgrade gated state, see SetStateUpgrade
 	namer          types.ChainVerNameFunc
 	voter          types.Voter
 
-	voteWindowUp   uint64 // Vote window upper bound delta
-	voteWindowDown uint64 // Vote window lower bound delta
-	voteExtLimit   uint64
-	trimLag        uint64 // Non-consensus chain trim lag
-	cTrimLag       uint64 // Consensus chain trim lag
+	stateUpgrade      string // Network upgrade enabling attestation timestamps and double sign evidence
+	voteWindowUp      uint64 // Vote window upper bound delta
+	voteWindowDown    uint64 // Vote window lower bound delta
+	voteExtLimit      uint64
+	trimLag           uint64 // Non-consensus chain trim lag
+	cTrimLag          uint64 // Consensus chain trim lag
+	maxAttestationAge uint64 // Maximum age of pending attestations in blocks, zero disables
+	concurrentVerify  bool   // Verify vote signatures concurrently, see SetConcurrentVerify
+
+	tableWarnThreshold uint64     // Table rows warning threshold, zero disables, see SetTableSizeWarning
+	tableCheckInterval uint64     // Table size check interval in blocks, zero disables
+	tableChecks        chan int64 // Triggered table size check heights, see RunTableSizeChecks
 
 	valAddrCache *valAddrCache
 }
 
+// Config defines the attestation keeper configuration.
+type Config struct {
+	// VoteWindowUp defines the number of attest offsets after (higher than)
+	// the latest approved attestation that votes are allowed for.
+	VoteWindowUp uint64
+	// VoteWindowDown defines the number of attest offsets before (lower than)
+	// the latest approved attestation that votes are allowed for.
+	VoteWindowDown uint64
+	// VoteExtLimit defines the maximum number of votes a validator may include in a single vote extension.
+	VoteExtLimit uint64
+	// TrimLag defines the number of blocks after which non-consensus-chain attestations are deleted.
+	TrimLag uint64
+	// CTrimLag defines the number of blocks after which consensus-chain attestations are deleted.
+	CTrimLag uint64
+	// MaxAttestationAge defines the number of blocks after which pending attestations
+	// of already approved offsets are rejected (deleted). Zero disables this.
+	MaxAttestationAge uint64
+}
+
+// Verify returns an error if the configuration is invalid.
+func (c Config) Verify() error {
+	if c.VoteWindowUp == 0 {
+		return errors.New("vote window up must be at least 1, else the next attestation can never be voted for")
+	} else if c.VoteExtLimit == 0 {
+		return errors.New("vote extension limit must be at least 1")
+	} else if c.VoteExtLimit < c.VoteWindowUp {
+		return errors.New("vote extension limit must be greater than or equal to vote window up, else validators cannot vote for the whole window",
+			"limit", c.VoteExtLimit, "window_up", c.VoteWindowUp)
+	} else if c.CTrimLag < c.TrimLag {
+		return errors.New("consensus trim lag must be greater than or equal to trim lag")
+	}
+
+	return nil
+}
+
 // New returns a new attestation keeper.
 func New(
 	cdc codec.BinaryCodec,
@@ -67,12 +113,12 @@ func New(
 	skeeper baseapp.ValidatorStore,
 	namer types.ChainVerNameFunc,
 	voter types.Voter,
-	voteWindowUp uint64,
-	voteWindowDown uint64,
-	voteExtLimit uint64,
-	trimLag uint64,
-	cTrimLag uint64,
+	cfg Config,
 ) (*Keeper, error) {
+	if err := cfg.Verify(); err != nil {
+		return nil, errors.Wrap(err, "verify config")
+	}
+
 	schema := &ormv1alpha1.ModuleSchemaDescriptor{SchemaFile: []*ormv1alpha1.ModuleSchemaDescriptor_FileEntry{
 		{Id: 1, ProtoFileName: File_halo_attest_keeper_attestation_proto.Path()},
 	}}
@@ -87,25 +133,23 @@ func New(
 		return nil, errors.Wrap(err, "create attestation store")
 	}
 
-	if cTrimLag < trimLag {
-		return nil, errors.New("consensus trim lag must be greater than or equal to trim lag")
-	}
-
 	k := &Keeper{
-		attTable:       attstore.AttestationTable(),
-		sigTable:       attstore.SignatureTable(),
-		cdc:            cdc,
-		storeService:   storeSvc,
-		skeeper:        skeeper,
-		namer:          namer,
-		voter:          voter,
-		voteWindowUp:   voteWindowUp,
-		voteWindowDown: voteWindowDown,
-		voteExtLimit:   voteExtLimit,
-		trimLag:        trimLag,
-		cTrimLag:       cTrimLag,
-		portalRegistry: stubPortalRegistry{},
-		valAddrCache:   new(valAddrCache),
+		attTable:          attstore.AttestationTable(),
+		sigTable:          attstore.SignatureTable(),
+		dsTable:           attstore.DoubleSignTable(),
+		cdc:               cdc,
+		storeService:      storeSvc,
+		skeeper:           skeeper,
+		namer:             namer,
+		voter:             voter,
+		voteWindowUp:      cfg.VoteWindowUp,
+		voteWindowDown:    cfg.VoteWindowDown,
+		voteExtLimit:      cfg.VoteExtLimit,
+		trimLag:           cfg.TrimLag,
+		cTrimLag:          cfg.CTrimLag,
+		maxAttestationAge: cfg.MaxAttestationAge,
+		portalRegistry:    stubPortalRegistry{},
+		valAddrCache:      new(valAddrCache),
 	}
 
 	return k, nil
@@ -121,6 +165,15 @@ func (k *Keeper) SetPortalRegistry(portalRegistry rtypes.PortalRegistry) {
 	k.portalRegistry = portalRegistry
 }
 
+// SetStateUpgrade sets the network upgrade after which attestation created and approved timestamps
+// (see AttestationLatency) and double sign evidence (see DoubleSignEvidence) are stored.
+// These are consensus state, so they are only stored once all validators apply the upgrade.
+// They aren't stored if not set.
+func (k *Keeper) SetStateUpgrade(upgradeKeeper types.UpgradeKeeper, upgradeName string) {
+	k.upgradeKeeper = upgradeKeeper
+	k.stateUpgrade = upgradeName
+}
+
 // RegisterProposalService registers the proposal service on the provided router.
 // This implements abci.ProcessProposal verification of new proposals.
 func (k *Keeper) RegisterProposalService(server grpc1.Server) {
@@ -176,6 +229,12 @@ func (k *Keeper) addOne(ctx context.Context, agg *types.AggVote, valSetID uint64
 	var attID uint64
 	existing, err := k.attTable.GetByAttestationRoot(ctx, attRoot[:])
 	if ormerrors.IsNotFound(err) {
+		var createdMs uint64
+		createdMs, err = k.timestampMs(ctx)
+		if err != nil {
+			return err
+		}
+
 		// Insert new attestation
 		attID, err = k.attTable.InsertReturningId(ctx, &Attestation{
 			ChainId:         agg.AttestHeader.SourceChainId,
@@ -189,6 +248,8 @@ func (k *Keeper) addOne(ctx context.Context, agg *types.AggVote, valSetID uint64
 			ValidatorSetId:  0, // Unknown at this point.
 			CreatedHeight:   uint64(sdk.UnwrapSDKContext(ctx).BlockHeight()),
 			FinalizedAttId:  0, // No finalized override yet.
+			CreatedTimeMs:   createdMs,
+			ApprovedTimeMs:  0, // Not approved yet.
 		})
 		if err != nil {
 			return errors.Wrap(err, "insert")
@@ -238,6 +299,7 @@ func (k *Keeper) addOne(ctx context.Context, agg *types.AggVote, valSetID uint64
 			} else if ok {
 				doubleSignCounter.WithLabelValues(common.BytesToAddress(sig.ValidatorAddress).Hex()).Inc()
 				msg = "🚨 Ignoring duplicate slashable vote"
+				k.recordDoubleSign(ctx, agg, attRoot, sig)
 			}
 
 			log.Warn(ctx, msg, nil,
@@ -265,6 +327,11 @@ func (k *Keeper) Approve(ctx context.Context, valset ValSet) error {
 	}
 	defer iter.Close()
 
+	approvedMs, err := k.timestampMs(ctx)
+	if err != nil {
+		return err
+	}
+
 	approvedByChain := make(map[xchain.ChainVersion]uint64) // Cache the latest approved attestation offset by chain version.
 	for iter.Next() {
 		att, err := iter.Value()
@@ -291,6 +358,10 @@ func (k *Keeper) Approve(ctx context.Context, valset ValSet) error {
 				continue
 			} else if ok && head+1 != att.GetAttestOffset() {
 				// This isn't the next attestation to approve, so we can't approve it yet.
+				if err := k.maybeRejectStale(ctx, att, head); err != nil {
+					return err
+				}
+
 				continue
 			}
 		}
@@ -329,12 +400,16 @@ func (k *Keeper) Approve(ctx context.Context, valset ValSet) error {
 		// Update status
 		att.Status = uint32(Status_Approved)
 		att.ValidatorSetId = valset.ID
+		att.ApprovedTimeMs = approvedMs
 		err = k.attTable.Update(ctx, att)
 		if err != nil {
 			return errors.Wrap(err, "save")
 		}
 
 		setMetrics(att)
+		if latency, ok := approvalLatency(att); ok {
+			approvalLatencySeconds.WithLabelValues(chainVerName).Observe(latency.Seconds())
+		}
 		approvedByChain[chainVer] = att.GetAttestOffset()
 
 		log.Debug(ctx, "📬 Approved attestation",
@@ -359,6 +434,115 @@ func (k *Keeper) Approve(ctx context.Context, valset ValSet) error {
 	return nil
 }
 
+// AttestationLatency returns the duration it took the attestation with the provided ID to reach quorum,
+// i.e., the consensus block time between creation and approval.
+// It returns an error if the attestation doesn't exist, isn't approved by quorum (e.g. pending or
+// overridden by a finalized attestation) or was created before latencies were recorded.
+func (k *Keeper) AttestationLatency(ctx context.Context, attID uint64) (time.Duration, error) {
+	att, err := k.attTable.Get(ctx, attID)
+	if err != nil {
+		return 0, errors.Wrap(err, "get attestation", "att_id", attID)
+	}
+
+	if att.GetStatus() != uint32(Status_Approved) {
+		return 0, errors.New("attestation not approved", "att_id", attID, "status", Status(att.GetStatus()))
+	}
+
+	latency, ok := approvalLatency(att)
+	if !ok {
+		// Zero timestamps are unknown, e.g. attestations created before the timestamps upgrade.
+		return 0, errors.New("attestation latency unknown", "att_id", attID)
+	}
+
+	return latency, nil
+}
+
+// approvalLatency returns the duration between the attestation's creation and approval times,
+// or false if either is unknown (zero), e.g. for attestations created before the timestamps upgrade.
+func approvalLatency(att *Attestation) (time.Duration, bool) {
+	if att.GetStatus() != uint32(Status_Approved) || att.GetCreatedTimeMs() == 0 || att.GetApprovedTimeMs() == 0 {
+		return 0, false
+	} else if att.GetApprovedTimeMs() < att.GetCreatedTimeMs() {
+		return 0, false // Consensus block time is monotonic, but be defensive.
+	}
+
+	return time.Duration(att.GetApprovedTimeMs()-att.GetCreatedTimeMs()) * time.Millisecond, true
+}
+
+// timestampMs returns the consensus block time in unix milliseconds to store in attestations,
+// or zero if the state upgrade wasn't applied yet, see SetStateUpgrade.
+func (k *Keeper) timestampMs(ctx context.Context) (uint64, error) {
+	if ok, err := k.isStateUpgraded(ctx); err != nil || !ok {
+		return 0, err
+	}
+
+	return blockTimeMs(ctx), nil
+}
+
+// isStateUpgraded returns true if the state upgrade was applied, see SetStateUpgrade.
+func (k *Keeper) isStateUpgraded(ctx context.Context) (bool, error) {
+	if k.upgradeKeeper == nil {
+		return false, nil
+	}
+
+	doneHeight, err := k.upgradeKeeper.GetDoneHeight(ctx, k.stateUpgrade)
+	if err != nil {
+		return false, errors.Wrap(err, "get upgrade done height")
+	}
+
+	return doneHeight > 0, nil
+}
+
+// blockTimeMs returns the consensus block time in unix milliseconds or zero if not available.
+func blockTimeMs(ctx context.Context) uint64 {
+	ms := sdk.UnwrapSDKContext(ctx).BlockTime().UnixMilli()
+	if ms <= 0 {
+		return 0 // Zero block time not available
+	}
+
+	return uint64(ms)
+}
+
+// maybeRejectStale deletes the provided pending attestation (and its signatures)
+// if its offset is at or below the approved head offset and it was created more than maxAttestationAge blocks ago.
+// This bounds table growth due to attestations that will never be approved, since their offset was
+// already approved with a different attestation. Future offsets are retained, since they may still be approved.
+// It must only be called for attestations that aren't the next to approve.
+func (k *Keeper) maybeRejectStale(ctx context.Context, att *Attestation, approvedHead uint64) error {
+	if k.maxAttestationAge == 0 {
+		return nil // Disabled
+	} else if att.GetAttestOffset() > approvedHead {
+		return nil // Future offsets may still be approved
+	}
+
+	head := uint64(sdk.UnwrapSDKContext(ctx).BlockHeight())
+	if att.GetCreatedHeight()+k.maxAttestationAge >= head {
+		return nil // Not stale yet
+	}
+
+	if err := k.instrumentVotes(ctx, att); err != nil {
+		return errors.Wrap(err, "instrument votes")
+	}
+
+	if err := k.sigTable.DeleteBy(ctx, SignatureAttIdValidatorAddressIndexKey{}.WithAttId(att.GetId())); err != nil {
+		return errors.Wrap(err, "delete sigs")
+	}
+
+	if err := k.attTable.Delete(ctx, att); err != nil {
+		return errors.Wrap(err, "delete att")
+	}
+
+	log.Debug(ctx, "Rejected stale pending attestation",
+		"chain", k.namer(att.XChainVersion()),
+		"attest_offset", att.GetAttestOffset(),
+		"approved_offset", approvedHead,
+		"created_height", att.GetCreatedHeight(),
+		"max_age", k.maxAttestationAge,
+	)
+
+	return nil
+}
+
 // ListAttestationsFrom returns the subsequent approved attestations from the provided offset (inclusive).
 func (k *Keeper) ListAttestationsFrom(ctx context.Context, chainID uint64, confLevel uint32, offset uint64, max uint64) ([]*types.Attestation, error) {
 	defer latency("attestations_from")()
@@ -409,6 +593,117 @@ func (k *Keeper) ListAttestationsFrom(ctx context.Context, chainID uint64, confL
 	return resp, nil
 }
 
+// maxReplayRange is the maximum number of attestations returned by a single ReplayApproved call.
+const maxReplayRange = 1000
+
+// ReplayApproved returns the approved attestations of the chain version from fromOffset to toOffset (both inclusive)
+// exactly as they were approved, including their signatures. This supports recovery of downstream consumers
+// (e.g. indexers and relayers) that fell behind or reset, without requiring a chain resync.
+// It doesn't change any state.
+//
+// The result is contiguous from fromOffset and truncated at the first offset not approved (yet),
+// e.g. if attestations were already deleted. Larger ranges than 1000 attestations should be chunked.
+func (k *Keeper) ReplayApproved(ctx context.Context, chainVer xchain.ChainVersion, fromOffset, toOffset uint64) ([]*types.Attestation, error) {
+	if fromOffset > toOffset {
+		return nil, errors.New("invalid replay range", "from", fromOffset, "to", toOffset)
+	} else if toOffset-fromOffset >= maxReplayRange {
+		return nil, errors.New("replay range too large", "from", fromOffset, "to", toOffset, "max", maxReplayRange)
+	}
+
+	count := toOffset - fromOffset + 1
+	atts, err := k.ListAttestationsFrom(ctx, chainVer.ID, uint32(chainVer.ConfLevel), fromOffset, count)
+	if err != nil {
+		return nil, errors.Wrap(err, "list approved")
+	}
+
+	// Results are contiguous from fromOffset, so truncate by count, since overridden
+	// attestations are replaced by finalized attestations of different offsets.
+	if uint64(len(atts)) > count {
+		atts = atts[:count]
+	}
+
+	return atts, nil
+}
+
+// LatestApproved returns the highest approved attestation of the chain across all confirmation levels,
+// i.e., the attestation of the highest source chain block, preferring finalized attestations on ties.
+// It returns false if no attestation of the chain is approved yet.
+// It performs a single reverse index lookup per confirmation level, so it doesn't scan approved attestations.
+func (k *Keeper) LatestApproved(ctx context.Context, chainID uint64) (*Attestation, bool, error) {
+	defer latency("latest_approved")()
+
+	var resp *Attestation
+	for _, conf := range append([]xchain.ConfLevel{xchain.ConfFinalized}, xchain.FuzzyConfLevels()...) {
+		att, ok, err := k.latestAttestation(ctx, xchain.ChainVersion{ID: chainID, ConfLevel: conf})
+		if err != nil {
+			return nil, false, err
+		} else if !ok {
+			continue
+		}
+
+		if resp == nil || att.GetBlockHeight() > resp.GetBlockHeight() {
+			resp = att
+		}
+	}
+
+	return resp, resp != nil, nil
+}
+
+// ChainsWithPending returns the distinct source chain IDs (ascending) that currently have at least one pending attestation.
+// It skip-scans the status index, seeking once per chain with pending attestations instead of scanning all of them.
+func (k *Keeper) ChainsWithPending(ctx context.Context) ([]uint64, error) {
+	defer latency("chains_with_pending")()
+
+	var (
+		resp  []uint64
+		names []string
+		next  uint64
+	)
+	for {
+		from := AttestationStatusChainIdConfLevelAttestOffsetIndexKey{}.WithStatusChainId(uint32(Status_Pending), next)
+		to := AttestationStatusChainIdConfLevelAttestOffsetIndexKey{}.WithStatusChainId(uint32(Status_Pending), math.MaxUint64)
+
+		att, ok, err := k.firstInRange(ctx, from, to)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+
+		resp = append(resp, att.GetChainId())
+		names = append(names, k.namer(att.XChainVersion()))
+
+		if att.GetChainId() == math.MaxUint64 {
+			break
+		}
+		next = att.GetChainId() + 1 // Skip to the next chain
+	}
+
+	log.Debug(ctx, "Chains with pending attestations", "chains", names)
+
+	return resp, nil
+}
+
+// firstInRange returns the first attestation in the provided index range or false if none exist.
+func (k *Keeper) firstInRange(ctx context.Context, from, to AttestationIndexKey) (*Attestation, bool, error) {
+	iter, err := k.attTable.ListRange(ctx, from, to)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "list range")
+	}
+	defer iter.Close()
+
+	if !iter.Next() {
+		return nil, false, nil
+	}
+
+	att, err := iter.Value()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "value")
+	}
+
+	return att, true, nil
+}
+
 // maybeOverrideFinalized returns the approved finalized attestation and true for the provided fuzzy attestation if it exists.
 func (k *Keeper) maybeOverrideFinalized(ctx context.Context, att *Attestation) (bool, error) {
 	if att.GetStatus() != uint32(Status_Pending) {
@@ -626,6 +921,10 @@ func (k *Keeper) BeginBlock(ctx context.Context) error {
 	before := umath.SubtractOrZero(head, k.trimLag)
 	cBefore := umath.SubtractOrZero(head, k.cTrimLag)
 
+	if err := k.deleteEvidenceBefore(ctx, cBefore); err != nil {
+		return err
+	}
+
 	return k.deleteBefore(ctx, before, consensusID, cBefore)
 }
 
@@ -640,7 +939,13 @@ func (k *Keeper) EndBlock(ctx context.Context) error {
 		return errors.Wrap(err, "fetch validators")
 	}
 
-	return k.Approve(ctx, valset)
+	if err := k.Approve(ctx, valset); err != nil {
+		return err
+	}
+
+	k.maybeTriggerTableSizeCheck(ctx)
+
+	return nil
 }
 
 // ExtendVote extends a vote with application-injected data (vote extensions).
@@ -764,13 +1069,13 @@ func (k *Keeper) VerifyVoteExtension(ctx sdk.Context, req *abci.RequestVerifyVot
 		return respReject, nil
 	}
 
+	if err := verifyAll(votes.Votes, k.concurrentVerify, k.verifyVote(ctx)); err != nil {
+		log.Warn(ctx, "Rejecting invalid vote", err)
+		return respReject, nil
+	}
+
 	duplicate := make(map[xchain.AttestHeader]bool)
 	for _, vote := range votes.Votes {
-		if err := vote.Verify(); err != nil {
-			log.Warn(ctx, "Rejecting invalid vote", err)
-			return respReject, nil
-		}
-
 		if duplicate[vote.AttestHeader.ToXChain()] {
 			doubleSignCounter.WithLabelValues(ethAddr.Hex()).Inc()
 			log.Warn(ctx, "Rejecting duplicate slashable vote", err)
@@ -872,12 +1177,13 @@ func (k *Keeper) verifyAggVotes(
 	aggs []*types.AggVote,
 	windowCompareFunc windowCompareFunc, // Aliased for testing
 ) error {
+	if err := verifyAll(aggs, k.concurrentVerify, k.verifyAggVote(ctx)); err != nil {
+		return errors.Wrap(err, "verify aggregate vote")
+	}
+
 	duplicate := make(map[common.Hash]bool)         // Detects duplicate aggregate votes.
 	countsPerVal := make(map[common.Address]uint64) // Enforce vote extension limit.
 	for _, agg := range aggs {
-		if err := agg.Verify(); err != nil {
-			return errors.Wrap(err, "verify aggregate vote")
-		}
 		errAttrs := []any{"chain", k.namer(agg.AttestHeader.XChainVersion()), "attest_offset", agg.AttestHeader.AttestOffset}
 
 		if err := verifyHeaderChains(ctx, cChainID, k.portalRegistry, agg.AttestHeader, agg.BlockHeader); err != nil {
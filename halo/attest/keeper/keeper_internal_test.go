@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/omni-network/omni/halo/attest/types"
+	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/k1util"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tutil"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 
+	promutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,6 +36,35 @@ func (k *Keeper) SetVoteWindowDownForT(voteWindowDown uint64) {
 	k.voteWindowDown = voteWindowDown
 }
 
+// SetMaxAttestationAgeForT sets the max attestation age for testing purposes.
+func (k *Keeper) SetMaxAttestationAgeForT(maxAttestationAge uint64) {
+	k.maxAttestationAge = maxAttestationAge
+}
+
+// TriggerTableSizeCheckForT returns whether a table size check was triggered at the context height for testing purposes.
+func (k *Keeper) TriggerTableSizeCheckForT(ctx context.Context) bool {
+	k.maybeTriggerTableSizeCheck(ctx)
+
+	select {
+	case <-k.tableChecks:
+		return true
+	default:
+		return false
+	}
+}
+
+// CheckTableSizesForT checks the table sizes and returns the instrumented rows per table for testing purposes.
+func (k *Keeper) CheckTableSizesForT(ctx context.Context) (map[string]float64, error) {
+	if err := k.checkTableSizes(ctx, ctx); err != nil {
+		return nil, err
+	}
+
+	return map[string]float64{
+		"attestation": promutil.ToFloat64(tableRows.WithLabelValues("attestation")),
+		"signature":   promutil.ToFloat64(tableRows.WithLabelValues("signature")),
+	}, nil
+}
+
 func TestWindowCompose(t *testing.T) {
 	t.Parallel()
 	const windowUp = 64
@@ -194,7 +225,135 @@ func TestVerifyAggVotes(t *testing.T) {
 	}
 }
 
-func sign(t *testing.T, vote *types.AggVote, vals []*ecdsa.PrivateKey) []*types.SigTuple {
+func TestVerifyInvalidSignature(t *testing.T) {
+	t.Parallel()
+	const (
+		cChainID  = 999
+		srcChain  = 1
+		valsCount = 3
+	)
+
+	var vals []*ecdsa.PrivateKey
+	valset := ValSet{Vals: make(map[common.Address]int64)}
+	for range valsCount {
+		val := genPrivKey(t)
+		vals = append(vals, val)
+		valset.Vals[addr(val)] = 1
+	}
+
+	agg := &types.AggVote{
+		AttestHeader: &types.AttestHeader{
+			ConsensusChainId: cChainID,
+			SourceChainId:    srcChain,
+			ConfLevel:        uint32(xchain.ConfFinalized),
+			AttestOffset:     1,
+		},
+		BlockHeader: &types.BlockHeader{
+			ChainId:     srcChain,
+			BlockHeight: 1,
+			BlockHash:   tutil.RandomHash().Bytes(),
+		},
+		MsgRoot:    tutil.RandomHash().Bytes(),
+		Signatures: toSign(vals...),
+	}
+	agg.Signatures = sign(t, agg, vals)
+
+	// Corrupt the middle signature by signing with a different key.
+	other := genPrivKey(t)
+	otherSigs := toSign(other)
+	otherAgg := *agg
+	otherAgg.Signatures = otherSigs
+	agg.Signatures[1].Signature = sign(t, &otherAgg, []*ecdsa.PrivateKey{other})[0].Signature
+
+	keeper := Keeper{
+		portalRegistry: testPortalRegistry{srcChain: []xchain.ConfLevel{xchain.ConfFinalized}},
+		namer:          netconf.SimnetNetwork().ChainVersionName,
+		voteExtLimit:   1,
+	}
+	counter := signatureVerifyFailures.WithLabelValues(keeper.namer(agg.AttestHeader.XChainVersion()))
+	before := promutil.ToFloat64(counter)
+
+	windowCompareFunc := func(context.Context, xchain.ChainVersion, uint64) (int, error) { return 0, nil }
+	err := keeper.verifyAggVotes(context.Background(), cChainID, valset, []*types.AggVote{agg}, windowCompareFunc)
+	require.ErrorIs(t, err, types.ErrInvalidSignature)
+
+	// Only the offending signature is reported.
+	invalid, err := agg.InvalidSigners()
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{addr(vals[1])}, invalid)
+	require.InDelta(t, before+1, promutil.ToFloat64(counter), 0)
+}
+
+func TestVerifyAll(t *testing.T) {
+	t.Parallel()
+
+	elems := make([]int, 100)
+	for i := range elems {
+		elems[i] = i
+	}
+
+	// verify fails for all multiples of 7 (except 0), with the index in the error.
+	verify := func(i int) error {
+		if i > 0 && i%7 == 0 {
+			return errors.New("invalid", "index", i)
+		}
+
+		return nil
+	}
+
+	for _, concurrent := range []bool{false, true} {
+		require.NoError(t, verifyAll(elems[:7], concurrent, verify))
+		require.NoError(t, verifyAll([]int{}, concurrent, verify))
+
+		// The lowest failing index is always returned.
+		for range 10 {
+			err := verifyAll(elems, concurrent, verify)
+			require.Error(t, err)
+			require.Equal(t, errors.New("invalid", "index", 7).Error(), err.Error())
+		}
+	}
+}
+
+func BenchmarkVerifyAll(b *testing.B) {
+	const n = 64
+
+	var vals []*ecdsa.PrivateKey
+	for range 16 {
+		vals = append(vals, genPrivKey(b))
+	}
+
+	aggs := make([]*types.AggVote, 0, n)
+	for i := range n {
+		agg := &types.AggVote{
+			AttestHeader: &types.AttestHeader{
+				ConsensusChainId: 999,
+				SourceChainId:    1,
+				ConfLevel:        uint32(xchain.ConfFinalized),
+				AttestOffset:     uint64(i + 1),
+			},
+			BlockHeader: &types.BlockHeader{
+				ChainId:     1,
+				BlockHeight: uint64(i + 1),
+				BlockHash:   tutil.RandomHash().Bytes(),
+			},
+			MsgRoot:    tutil.RandomHash().Bytes(),
+			Signatures: toSign(vals...),
+		}
+		agg.Signatures = sign(b, agg, vals)
+		aggs = append(aggs, agg)
+	}
+
+	for _, concurrent := range []bool{false, true} {
+		b.Run(fmt.Sprintf("concurrent_%t", concurrent), func(b *testing.B) {
+			for range b.N {
+				err := verifyAll(aggs, concurrent, (*types.AggVote).Verify)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func sign(t testing.TB, vote *types.AggVote, vals []*ecdsa.PrivateKey) []*types.SigTuple {
 	t.Helper()
 	attRoot, err := vote.AttestationRoot()
 	require.NoError(t, err)
@@ -238,7 +397,7 @@ func toSign(vals ...*ecdsa.PrivateKey) []*types.SigTuple {
 	return resp
 }
 
-func genPrivKey(t *testing.T) *ecdsa.PrivateKey {
+func genPrivKey(t testing.TB) *ecdsa.PrivateKey {
 	t.Helper()
 
 	privKey, err := crypto.GenerateKey()
@@ -1,7 +1,9 @@
 package keeper_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/omni-network/omni/halo/attest/keeper"
 	"github.com/omni-network/omni/halo/attest/types"
@@ -190,8 +192,33 @@ func TestKeeper_Add(t *testing.T) {
 					msg := defaultMsg().Msg()
 					err := k.Add(ctx, msg)
 					require.NoError(t, err)
+
+					// Evidence is only recorded after the state upgrade.
+					k.SetStateUpgrade(&stubUpgradeKeeper{doneHeight: 1}, "test_upgrade")
 				},
 			},
+			postrequisites: []postrequisite{func(t *testing.T, k *keeper.Keeper, ctx sdk.Context) {
+				t.Helper()
+				// Double signs of both validators are recorded as evidence.
+				evidence, err := k.DoubleSignEvidence(ctx)
+				require.NoError(t, err)
+				require.Len(t, evidence, 2)
+				for _, e := range evidence {
+					require.Equal(t, defaultChainVer, e.ChainVersion)
+					require.Equal(t, defaultOffset, e.AttestOffset)
+					require.Equal(t, e.Existing.BlockHash, e.Conflicting.BlockHash)
+					require.NotEqual(t, e.Existing.AttestationRoot, e.Conflicting.AttestationRoot)
+				}
+
+				// A double sign event is emitted per validator.
+				var events int
+				for _, event := range ctx.EventManager().Events() {
+					if event.Type == keeper.EventTypeDoubleSign {
+						events++
+					}
+				}
+				require.Equal(t, 2, events)
+			}},
 			want: want{
 				atts: []*keeper.Attestation{
 					expectPendingAtt(1, defaultOffset, 1), // Default agg vote resulting in pending attestation.
@@ -1116,3 +1143,411 @@ func consensusSig(sig *keeper.Signature) *keeper.Signature {
 	sig.ChainId = consensusID
 	return sig
 }
+
+func TestConfigVerify(t *testing.T) {
+	t.Parallel()
+
+	valid := keeper.Config{
+		VoteWindowUp:   64,
+		VoteWindowDown: 2,
+		VoteExtLimit:   256,
+		TrimLag:        1,
+		CTrimLag:       72_000,
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*keeper.Config)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(*keeper.Config) {}},
+		{name: "zero_vote_window_down", mutate: func(c *keeper.Config) { c.VoteWindowDown = 0 }},
+		{name: "max_attestation_age", mutate: func(c *keeper.Config) { c.MaxAttestationAge = 1000 }},
+		{name: "zero_vote_window_up", mutate: func(c *keeper.Config) { c.VoteWindowUp = 0 }, wantErr: true},
+		{name: "zero_vote_ext_limit", mutate: func(c *keeper.Config) { c.VoteExtLimit = 0 }, wantErr: true},
+		{name: "vote_ext_limit_below_window", mutate: func(c *keeper.Config) { c.VoteExtLimit = 63 }, wantErr: true},
+		{name: "ctrim_lag_below_trim_lag", mutate: func(c *keeper.Config) { c.CTrimLag = 0 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := valid
+			tt.mutate(&cfg)
+
+			err := cfg.Verify()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChainsWithPending(t *testing.T) {
+	t.Parallel()
+
+	anyNamer := func(_ sdk.Context, m mocks) {
+		m.namer.EXPECT().ChainName(gomock.Any()).Return("test_chain").AnyTimes()
+	}
+	k, ctx := setupKeeper(t, anyNamer)
+
+	chains, err := k.ChainsWithPending(ctx)
+	require.NoError(t, err)
+	require.Empty(t, chains)
+
+	insert := func(chainID uint64, confLevel xchain.ConfLevel, offset uint64, status keeper.Status) {
+		t.Helper()
+		err := k.AttestTableForT().Insert(ctx, &keeper.Attestation{
+			ChainId:         chainID,
+			ConfLevel:       uint32(confLevel),
+			AttestOffset:    offset,
+			AttestationRoot: common.BigToHash(umath.NewBigInt(chainID*1000 + offset*10 + uint64(confLevel))).Bytes(),
+			Status:          uint32(status),
+		})
+		require.NoError(t, err)
+	}
+
+	for offset := uint64(1); offset <= 3; offset++ {
+		insert(1, xchain.ConfFinalized, offset, keeper.Status_Pending)
+		insert(1, xchain.ConfLatest, offset, keeper.Status_Pending)
+	}
+	insert(2, xchain.ConfFinalized, 1, keeper.Status_Approved) // No pending
+	insert(5, xchain.ConfLatest, 1, keeper.Status_Pending)
+	insert(7, xchain.ConfFinalized, 1, keeper.Status_Approved)
+	insert(7, xchain.ConfFinalized, 2, keeper.Status_Pending)
+
+	chains, err = k.ChainsWithPending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 5, 7}, chains)
+}
+
+func TestLatestApproved(t *testing.T) {
+	t.Parallel()
+
+	anyNamer := func(_ sdk.Context, m mocks) {
+		m.namer.EXPECT().ChainName(gomock.Any()).Return("test_chain").AnyTimes()
+	}
+	k, ctx := setupKeeper(t, anyNamer)
+
+	_, ok, err := k.LatestApproved(ctx, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	insert := func(chainID uint64, confLevel xchain.ConfLevel, offset uint64, height uint64, status keeper.Status) {
+		t.Helper()
+		err := k.AttestTableForT().Insert(ctx, &keeper.Attestation{
+			ChainId:         chainID,
+			ConfLevel:       uint32(confLevel),
+			AttestOffset:    offset,
+			BlockHeight:     height,
+			AttestationRoot: common.BigToHash(umath.NewBigInt(chainID*1000 + offset*10 + uint64(confLevel))).Bytes(),
+			Status:          uint32(status),
+		})
+		require.NoError(t, err)
+	}
+
+	requireLatest := func(chainID uint64, confLevel xchain.ConfLevel, offset uint64) {
+		t.Helper()
+		att, ok, err := k.LatestApproved(ctx, chainID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, uint32(confLevel), att.GetConfLevel())
+		require.Equal(t, offset, att.GetAttestOffset())
+	}
+
+	// Pending attestations are ignored.
+	insert(1, xchain.ConfFinalized, 1, 10, keeper.Status_Pending)
+	_, ok, err = k.LatestApproved(ctx, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Highest block across conf levels.
+	insert(1, xchain.ConfFinalized, 2, 20, keeper.Status_Approved)
+	insert(1, xchain.ConfFinalized, 3, 30, keeper.Status_Approved)
+	insert(1, xchain.ConfLatest, 1, 35, keeper.Status_Approved)
+	requireLatest(1, xchain.ConfLatest, 1)
+
+	// Finalized preferred on ties.
+	insert(1, xchain.ConfFinalized, 4, 35, keeper.Status_Approved)
+	requireLatest(1, xchain.ConfFinalized, 4)
+
+	// Other chains are independent.
+	insert(2, xchain.ConfFinalized, 1, 5, keeper.Status_Approved)
+	requireLatest(2, xchain.ConfFinalized, 1)
+	requireLatest(1, xchain.ConfFinalized, 4)
+}
+
+func TestReplayApproved(t *testing.T) {
+	t.Parallel()
+
+	anyNamer := func(_ sdk.Context, m mocks) {
+		m.namer.EXPECT().ChainName(gomock.Any()).Return("test_chain").AnyTimes()
+	}
+	k, ctx := setupKeeper(t, anyNamer)
+
+	insert := func(chainID uint64, confLevel xchain.ConfLevel, offset uint64, status keeper.Status) {
+		t.Helper()
+		err := k.AttestTableForT().Insert(ctx, &keeper.Attestation{
+			ChainId:         chainID,
+			ConfLevel:       uint32(confLevel),
+			AttestOffset:    offset,
+			BlockHeight:     offset * 10,
+			AttestationRoot: common.BigToHash(umath.NewBigInt(chainID*1000 + offset*10 + uint64(confLevel))).Bytes(),
+			Status:          uint32(status),
+		})
+		require.NoError(t, err)
+	}
+
+	for offset := uint64(1); offset <= 5; offset++ {
+		insert(1, xchain.ConfFinalized, offset, keeper.Status_Approved)
+	}
+	insert(1, xchain.ConfFinalized, 6, keeper.Status_Pending)
+	insert(1, xchain.ConfLatest, 1, keeper.Status_Approved)
+
+	chainVer := xchain.ChainVersion{ID: 1, ConfLevel: xchain.ConfFinalized}
+	requireReplay := func(from, to uint64, expected ...uint64) {
+		t.Helper()
+		atts, err := k.ReplayApproved(ctx, chainVer, from, to)
+		require.NoError(t, err)
+
+		var offsets []uint64
+		for _, att := range atts {
+			require.Equal(t, chainVer, att.AttestHeader.XChainVersion())
+			require.Equal(t, att.AttestHeader.GetAttestOffset()*10, att.BlockHeader.GetBlockHeight())
+			offsets = append(offsets, att.AttestHeader.GetAttestOffset())
+		}
+		require.Equal(t, expected, offsets)
+	}
+
+	requireReplay(2, 4, 2, 3, 4)
+	requireReplay(1, 1, 1)
+	requireReplay(4, 10, 4, 5) // Truncated at pending attestation
+	requireReplay(7, 8)        // Nothing approved
+
+	_, err := k.ReplayApproved(ctx, chainVer, 4, 2)
+	require.ErrorContains(t, err, "invalid replay range")
+	_, err = k.ReplayApproved(ctx, chainVer, 1, 1000)
+	require.ErrorContains(t, err, "replay range too large")
+
+	// State isn't changed.
+	latest, ok, err := k.LatestApproved(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), latest.GetAttestOffset())
+	requireReplay(1, 5, 1, 2, 3, 4, 5)
+}
+
+func TestAttestationLatency(t *testing.T) {
+	t.Parallel()
+
+	valset1_2 := newValSet(8, val1, val2)
+
+	expectations := func(_ sdk.Context, m mocks) {
+		m.namer.EXPECT().ChainName(gomock.Any()).AnyTimes().Return("")
+		m.voter.EXPECT().TrimBehind(gomock.Any()).AnyTimes().Return(0)
+		m.valProvider.EXPECT().ActiveSetByHeight(gomock.Any(), gomock.Any()).AnyTimes().Return(valset1_2, nil)
+	}
+	k, ctx := setupKeeper(t, expectations)
+
+	created := time.Unix(1_700_000_000, 0)
+	approved := created.Add(time.Second * 3)
+
+	// Unknown attestation
+	_, err := k.AttestationLatency(ctx, 1)
+	require.Error(t, err)
+
+	// Att 1: created and approved before the timestamps upgrade.
+	upgrade := new(stubUpgradeKeeper)
+	k.SetStateUpgrade(upgrade, "test_upgrade")
+
+	vote := defaultAggVote().Vote()
+	err = k.Add(ctx.WithBlockTime(created), defaultMsg().Default().WithVotes(vote).Msg())
+	require.NoError(t, err)
+
+	// Pending attestation
+	_, err = k.AttestationLatency(ctx, 1)
+	require.ErrorContains(t, err, "attestation not approved")
+
+	err = k.Approve(ctx.WithBlockTime(approved), toValSet(valset1_2))
+	require.NoError(t, err)
+
+	// Timestamps aren't stored before the upgrade, so latency is unknown.
+	_, err = k.AttestationLatency(ctx, 1)
+	require.ErrorContains(t, err, "attestation latency unknown")
+	atts, _ := dumpTables(t, ctx, k)
+	require.Zero(t, atts[0].GetCreatedTimeMs())
+	require.Zero(t, atts[0].GetApprovedTimeMs())
+
+	// Att 2: created and approved after the timestamps upgrade.
+	upgrade.doneHeight = ctx.BlockHeight()
+
+	vote = defaultAggVote().WithAttestOfset(defaultOffset + 1).Vote()
+	err = k.Add(ctx.WithBlockTime(created), defaultMsg().Default().WithVotes(vote).Msg())
+	require.NoError(t, err)
+
+	err = k.Approve(ctx.WithBlockTime(approved), toValSet(valset1_2))
+	require.NoError(t, err)
+
+	latency, err := k.AttestationLatency(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, time.Second*3, latency)
+}
+
+// stubUpgradeKeeper is a stub upgrade keeper with a single done height for all upgrades.
+type stubUpgradeKeeper struct {
+	doneHeight int64
+}
+
+func (k *stubUpgradeKeeper) GetDoneHeight(context.Context, string) (int64, error) {
+	return k.doneHeight, nil
+}
+
+func TestRejectStale(t *testing.T) {
+	t.Parallel()
+
+	valset1_2 := newValSet(8, val1, val2)
+
+	expectations := func(_ sdk.Context, m mocks) {
+		m.namer.EXPECT().ChainName(gomock.Any()).AnyTimes().Return("")
+		m.voter.EXPECT().TrimBehind(gomock.Any()).AnyTimes().Return(0)
+		m.valProvider.EXPECT().ActiveSetByHeight(gomock.Any(), gomock.Any()).AnyTimes().Return(valset1_2, nil)
+	}
+	k, ctx := setupKeeper(t, expectations)
+
+	const maxAge = 2
+	k.SetMaxAttestationAgeForT(maxAge)
+
+	// Att 1: default agg vote signed by val 1 and 2, approved below.
+	require.NoError(t, k.Add(ctx, defaultMsg().Msg()))
+
+	// Att 2: same offset but different root signed by val 3 only, it will never be approved.
+	staleVote := defaultAggVote().
+		WithMsgRoot(common.BytesToHash([]byte("different root"))).
+		WithSignatures(sigsTuples(val3)...).
+		Vote()
+	require.NoError(t, k.Add(ctx, defaultMsg().WithVotes(staleVote).Msg()))
+
+	// Att 3: future offset, skipping the next offset, so it cannot be approved yet.
+	futureVote := defaultAggVote().WithAttestOfset(defaultOffset + 2).Vote()
+	require.NoError(t, k.Add(ctx, defaultMsg().Default().WithVotes(futureVote).Msg()))
+
+	// Approve att 1, nothing is stale yet.
+	require.NoError(t, k.Approve(ctx, toValSet(valset1_2)))
+	atts, _ := dumpTables(t, ctx, k)
+	require.Len(t, atts, 3)
+
+	// Approve after max age, att 2 is rejected, but future att 3 is kept.
+	stale := ctx.WithBlockHeight(ctx.BlockHeight() + maxAge + 1)
+	require.NoError(t, k.Approve(stale, toValSet(valset1_2)))
+
+	atts, sigs := dumpTables(t, ctx, k)
+	require.Len(t, atts, 2)
+	require.Equal(t, uint64(1), atts[0].GetId())
+	require.Equal(t, uint32(keeper.Status_Approved), atts[0].GetStatus())
+	require.Equal(t, uint64(3), atts[1].GetId())
+	require.Equal(t, uint32(keeper.Status_Pending), atts[1].GetStatus())
+	require.Equal(t, defaultOffset+2, atts[1].GetAttestOffset())
+	for _, sig := range sigs {
+		require.NotEqual(t, uint64(2), sig.GetAttId())
+	}
+}
+
+func TestTableSizeWarning(t *testing.T) {
+	t.Parallel()
+
+	expectations := func(_ sdk.Context, m mocks) {
+		m.namer.EXPECT().ChainName(gomock.Any()).AnyTimes().Return("")
+		m.valProvider.EXPECT().ActiveSetByHeight(gomock.Any(), gomock.Any()).AnyTimes().Return(newValSet(8, val1, val2), nil)
+	}
+	k, ctx := setupKeeper(t, expectations)
+
+	vote := defaultAggVote().Vote()
+	err := k.Add(ctx, defaultMsg().Default().WithVotes(vote).Msg())
+	require.NoError(t, err)
+
+	// Disabled by default.
+	require.False(t, k.TriggerTableSizeCheckForT(ctx.WithBlockHeight(10)))
+
+	k.SetTableSizeWarning(1, 10)
+
+	// Only triggered every interval blocks.
+	require.False(t, k.TriggerTableSizeCheckForT(ctx.WithBlockHeight(11)))
+	require.True(t, k.TriggerTableSizeCheckForT(ctx.WithBlockHeight(20)))
+
+	atts, sigs := dumpTables(t, ctx, k)
+	rows, err := k.CheckTableSizesForT(ctx)
+	require.NoError(t, err)
+	require.InDelta(t, len(atts), rows["attestation"], 0)
+	require.InDelta(t, len(sigs), rows["signature"], 0)
+}
+
+func TestDoubleSignEvidenceError(t *testing.T) {
+	t.Parallel()
+
+	k, ctx := setupKeeper(t)
+	k.SetStateUpgrade(&stubUpgradeKeeper{doneHeight: 1}, "test_upgrade")
+
+	// Insert default agg vote first, then delete its attestation, so evidence lookups of double signs fail.
+	require.NoError(t, k.Add(ctx, defaultMsg().Msg()))
+	att, err := k.AttestTableForT().Get(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, k.AttestTableForT().Delete(ctx, att))
+
+	// Double sign votes are still processed, evidence failures are only logged.
+	msg := defaultMsg().WithVotes(
+		defaultAggVote().
+			WithMsgRoot(common.BytesToHash([]byte("different root"))).
+			Vote(),
+	).Msg()
+	require.NoError(t, k.Add(ctx, msg))
+
+	evidence, err := k.DoubleSignEvidence(ctx)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+	for _, event := range ctx.EventManager().Events() {
+		require.NotEqual(t, keeper.EventTypeDoubleSign, event.Type)
+	}
+}
+
+func TestDoubleSignEvidencePruning(t *testing.T) {
+	t.Parallel()
+
+	k, ctx := setupKeeper(t, mockDefaultExpectations, noFuzzyDeps(), noFuzzyDeps())
+
+	// Insert default agg vote first, so the conflicting vote is a double sign.
+	require.NoError(t, k.Add(ctx, defaultMsg().Msg()))
+	msg := defaultMsg().WithVotes(
+		defaultAggVote().
+			WithMsgRoot(common.BytesToHash([]byte("different root"))).
+			Vote(),
+	).Msg()
+	require.NoError(t, k.Add(ctx, msg))
+
+	// Evidence isn't recorded before the state upgrade.
+	evidence, err := k.DoubleSignEvidence(ctx)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+
+	k.SetStateUpgrade(&stubUpgradeKeeper{doneHeight: ctx.BlockHeight()}, "test_upgrade")
+	require.NoError(t, k.Add(ctx, msg))
+
+	// Adding the same double sign again doesn't duplicate evidence.
+	require.NoError(t, k.Add(ctx, msg))
+
+	evidence, err = k.DoubleSignEvidence(ctx)
+	require.NoError(t, err)
+	require.Len(t, evidence, 2)
+
+	// Evidence is retained until the consensus chain trim lag.
+	require.NoError(t, k.BeginBlock(ctx.WithBlockHeight(ctx.BlockHeight()+cTrimLag-1)))
+	evidence, err = k.DoubleSignEvidence(ctx)
+	require.NoError(t, err)
+	require.Len(t, evidence, 2)
+
+	require.NoError(t, k.BeginBlock(ctx.WithBlockHeight(ctx.BlockHeight()+cTrimLag)))
+	evidence, err = k.DoubleSignEvidence(ctx)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+}
@@ -15,6 +15,13 @@ var (
 		Help:      "The height of latest approved attestation per source chain",
 	}, []string{"chain_version"})
 
+	tableRows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "halo",
+		Subsystem: "attest",
+		Name:      "table_rows",
+		Help:      "The number of rows per attest table. Only populated if the table size check is enabled. Alert if growing.",
+	}, []string{"table"})
+
 	approvedOffset = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "halo",
 		Subsystem: "attest",
@@ -38,6 +45,14 @@ var (
 		Buckets:   []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
 	}, []string{"chain_version"})
 
+	approvalLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "halo",
+		Subsystem: "attest",
+		Name:      "approval_latency_seconds",
+		Help:      "Consensus time (in seconds) from attestation creation until quorum approval per source chain. Alert if growing.",
+		Buckets:   []float64{1, 2, 5, 10, 20, 30, 60, 120, 300, 600},
+	}, []string{"chain_version"})
+
 	dbLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "halo",
 		Subsystem: "attest",
@@ -53,6 +68,13 @@ var (
 		Help:      "Total number of double sign votes detected per validator",
 	}, []string{"validator"})
 
+	signatureVerifyFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "halo",
+		Subsystem: "attest",
+		Name:      "signature_verify_failures_total",
+		Help:      "Total number of vote signatures that failed verification per source chain. Alert if growing.",
+	}, []string{"chain_version"})
+
 	approvedVotesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "halo",
 		Subsystem: "attest",
@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetTableSizeWarning configures a periodic background check of the attestation and signature table sizes.
+// Every interval blocks, the table rows of the latest committed state are counted and instrumented, and a warning
+// is logged if a table exceeds the threshold. This detects runaway growth, e.g. due to misconfigured trimming.
+// A zero threshold or interval disables the check. The check only runs while RunTableSizeChecks is running.
+func (k *Keeper) SetTableSizeWarning(threshold uint64, interval uint64) {
+	k.tableWarnThreshold = threshold
+	k.tableCheckInterval = interval
+	k.tableChecks = make(chan int64, 1)
+}
+
+// maybeTriggerTableSizeCheck triggers a background table size check every check interval blocks, see RunTableSizeChecks.
+// It never blocks or fails, so it doesn't affect block production. Triggers are dropped while a check is pending.
+func (k *Keeper) maybeTriggerTableSizeCheck(ctx context.Context) {
+	if k.tableWarnThreshold == 0 || k.tableCheckInterval == 0 {
+		return
+	}
+
+	height := sdk.UnwrapSDKContext(ctx).BlockHeight()
+	if height <= 0 || uint64(height)%k.tableCheckInterval != 0 {
+		return
+	}
+
+	select {
+	case k.tableChecks <- height:
+	default:
+	}
+}
+
+// RunTableSizeChecks blocks, counting the table rows on each triggered check until the context is canceled.
+// The query context function must return a read-only context of the latest committed state,
+// e.g. BaseApp.CreateQueryContext, since checks run concurrently with block processing.
+// Check errors are only logged, since the check is purely informational.
+func (k *Keeper) RunTableSizeChecks(ctx context.Context, queryCtx func() (context.Context, error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case height := <-k.tableChecks:
+			qCtx, err := queryCtx()
+			if err != nil {
+				log.Warn(ctx, "Failed creating attest table size check context (will retry)", err, "height", height)
+				continue
+			}
+
+			if err := k.checkTableSizes(ctx, qCtx); err != nil {
+				log.Warn(ctx, "Failed checking attest table sizes (will retry)", err, "height", height)
+			}
+		}
+	}
+}
+
+// checkTableSizes counts the table rows of the query context state, logging a warning if any exceeds the threshold.
+func (k *Keeper) checkTableSizes(ctx context.Context, qCtx context.Context) error {
+	attIter, err := k.attTable.List(qCtx, AttestationIdIndexKey{})
+	if err != nil {
+		return errors.Wrap(err, "list attestations")
+	}
+	attRows := countRows(attIter)
+
+	sigIter, err := k.sigTable.List(qCtx, SignatureIdIndexKey{})
+	if err != nil {
+		return errors.Wrap(err, "list signatures")
+	}
+	sigRows := countRows(sigIter)
+
+	tables := []struct {
+		Name string
+		Rows uint64
+	}{
+		{Name: "attestation", Rows: attRows},
+		{Name: "signature", Rows: sigRows},
+	}
+
+	for _, t := range tables {
+		tableRows.WithLabelValues(t.Name).Set(float64(t.Rows))
+
+		if t.Rows > k.tableWarnThreshold {
+			log.Warn(ctx, "Attest table size exceeds warning threshold, check trimming config", nil,
+				"table", t.Name,
+				"rows", t.Rows,
+				"threshold", k.tableWarnThreshold,
+			)
+		}
+	}
+
+	return nil
+}
+
+// countRows returns the number of rows of the iterator, closing it.
+func countRows(iter interface {
+	Next() bool
+	Close()
+},
+) uint64 {
+	defer iter.Close()
+
+	var resp uint64
+	for iter.Next() {
+		resp++
+	}
+
+	return resp
+}
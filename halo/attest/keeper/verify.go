@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/omni-network/omni/halo/attest/types"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SetConcurrentVerify enables concurrent verification of vote signatures bounded by GOMAXPROCS.
+// Accept/reject results are identical to sequential verification, so it isn't consensus critical.
+func (k *Keeper) SetConcurrentVerify(enabled bool) {
+	k.concurrentVerify = enabled
+}
+
+// verifyAll calls verify for each element, returning the first error by index.
+// If concurrent is true, elements are verified concurrently by at most GOMAXPROCS workers.
+// Since the error of the lowest failing index is always returned, results are deterministic
+// and identical to the sequential path, irrespective of goroutine scheduling.
+func verifyAll[T any](elems []T, concurrent bool, verify func(T) error) error {
+	if !concurrent || len(elems) <= 1 {
+		for _, elem := range elems {
+			if err := verify(elem); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	indexes := make(chan int, len(elems))
+	for i := range elems {
+		indexes <- i
+	}
+	close(indexes)
+
+	errs := make([]error, len(elems))
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(elems)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = verify(elems[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyVote returns a vote verify function that reports invalid signatures, see reportInvalidSig.
+func (k *Keeper) verifyVote(ctx context.Context) func(*types.Vote) error {
+	return func(vote *types.Vote) error {
+		err := vote.Verify()
+		if errors.Is(err, types.ErrInvalidSignature) {
+			k.reportInvalidSig(ctx, vote.AttestHeader.XChainVersion(), common.BytesToAddress(vote.Signature.ValidatorAddress), err)
+		}
+
+		return err
+	}
+}
+
+// verifyAggVote returns an aggregate vote verify function that reports all invalid signatures, see reportInvalidSig.
+// Only the offending validators are reported, not the other valid signatures of the aggregate.
+func (k *Keeper) verifyAggVote(ctx context.Context) func(*types.AggVote) error {
+	return func(agg *types.AggVote) error {
+		err := agg.Verify()
+		if !errors.Is(err, types.ErrInvalidSignature) {
+			return err
+		}
+
+		addrs, rootErr := agg.InvalidSigners()
+		if rootErr != nil {
+			return err // Not possible since the root was already calculated during verification.
+		}
+
+		for _, addr := range addrs {
+			k.reportInvalidSig(ctx, agg.AttestHeader.XChainVersion(), addr, err)
+		}
+
+		return err
+	}
+}
+
+// reportInvalidSig instruments and logs a vote signature that failed verification,
+// surfacing misbehaving or buggy validators and potential attacks.
+func (k *Keeper) reportInvalidSig(ctx context.Context, chainVer xchain.ChainVersion, validator common.Address, err error) {
+	signatureVerifyFailures.WithLabelValues(k.namer(chainVer)).Inc()
+	log.Warn(ctx, "Invalid vote signature", err,
+		"chain", k.namer(chainVer),
+		"validator", validator,
+	)
+}
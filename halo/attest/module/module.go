@@ -145,11 +145,14 @@ func ProvideModule(in ModuleInputs) (ModuleOutputs, error) {
 		in.SKeeper,
 		in.Namer,
 		in.Voter,
-		in.Config.GetVoteWindowUp(),
-		in.Config.GetVoteWindowDown(),
-		in.Config.GetVoteExtensionLimit(),
-		in.Config.GetTrimLag(),
-		in.Config.GetConsensusTrimLag(),
+		keeper.Config{
+			VoteWindowUp:      in.Config.GetVoteWindowUp(),
+			VoteWindowDown:    in.Config.GetVoteWindowDown(),
+			VoteExtLimit:      in.Config.GetVoteExtensionLimit(),
+			TrimLag:           in.Config.GetTrimLag(),
+			CTrimLag:          in.Config.GetConsensusTrimLag(),
+			MaxAttestationAge: in.Config.GetMaxAttestationAge(),
+		},
 	)
 	if err != nil {
 		return ModuleOutputs{}, err
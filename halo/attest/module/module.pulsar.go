@@ -21,6 +21,7 @@ var (
 	fd_Module_vote_extension_limit protoreflect.FieldDescriptor
 	fd_Module_trim_lag             protoreflect.FieldDescriptor
 	fd_Module_consensus_trim_lag   protoreflect.FieldDescriptor
+	fd_Module_max_attestation_age  protoreflect.FieldDescriptor
 )
 
 func init() {
@@ -32,6 +33,7 @@ func init() {
 	fd_Module_vote_extension_limit = md_Module.Fields().ByName("vote_extension_limit")
 	fd_Module_trim_lag = md_Module.Fields().ByName("trim_lag")
 	fd_Module_consensus_trim_lag = md_Module.Fields().ByName("consensus_trim_lag")
+	fd_Module_max_attestation_age = md_Module.Fields().ByName("max_attestation_age")
 }
 
 var _ protoreflect.Message = (*fastReflection_Module)(nil)
@@ -135,6 +137,12 @@ func (x *fastReflection_Module) Range(f func(protoreflect.FieldDescriptor, proto
 			return
 		}
 	}
+	if x.MaxAttestationAge != uint64(0) {
+		value := protoreflect.ValueOfUint64(x.MaxAttestationAge)
+		if !f(fd_Module_max_attestation_age, value) {
+			return
+		}
+	}
 }
 
 // Has reports whether a field is populated.
@@ -162,6 +170,8 @@ func (x *fastReflection_Module) Has(fd protoreflect.FieldDescriptor) bool {
 		return x.TrimLag != uint64(0)
 	case "halo.attest.module.Module.consensus_trim_lag":
 		return x.ConsensusTrimLag != uint64(0)
+	case "halo.attest.module.Module.max_attestation_age":
+		return x.MaxAttestationAge != uint64(0)
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: halo.attest.module.Module"))
@@ -190,6 +200,8 @@ func (x *fastReflection_Module) Clear(fd protoreflect.FieldDescriptor) {
 		x.TrimLag = uint64(0)
 	case "halo.attest.module.Module.consensus_trim_lag":
 		x.ConsensusTrimLag = uint64(0)
+	case "halo.attest.module.Module.max_attestation_age":
+		x.MaxAttestationAge = uint64(0)
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: halo.attest.module.Module"))
@@ -224,6 +236,9 @@ func (x *fastReflection_Module) Get(descriptor protoreflect.FieldDescriptor) pro
 	case "halo.attest.module.Module.consensus_trim_lag":
 		value := x.ConsensusTrimLag
 		return protoreflect.ValueOfUint64(value)
+	case "halo.attest.module.Module.max_attestation_age":
+		value := x.MaxAttestationAge
+		return protoreflect.ValueOfUint64(value)
 	default:
 		if descriptor.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: halo.attest.module.Module"))
@@ -256,6 +271,8 @@ func (x *fastReflection_Module) Set(fd protoreflect.FieldDescriptor, value proto
 		x.TrimLag = value.Uint()
 	case "halo.attest.module.Module.consensus_trim_lag":
 		x.ConsensusTrimLag = value.Uint()
+	case "halo.attest.module.Module.max_attestation_age":
+		x.MaxAttestationAge = value.Uint()
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: halo.attest.module.Module"))
@@ -288,6 +305,8 @@ func (x *fastReflection_Module) Mutable(fd protoreflect.FieldDescriptor) protore
 		panic(fmt.Errorf("field trim_lag of message halo.attest.module.Module is not mutable"))
 	case "halo.attest.module.Module.consensus_trim_lag":
 		panic(fmt.Errorf("field consensus_trim_lag of message halo.attest.module.Module is not mutable"))
+	case "halo.attest.module.Module.max_attestation_age":
+		panic(fmt.Errorf("field max_attestation_age of message halo.attest.module.Module is not mutable"))
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: halo.attest.module.Module"))
@@ -313,6 +332,8 @@ func (x *fastReflection_Module) NewField(fd protoreflect.FieldDescriptor) protor
 		return protoreflect.ValueOfUint64(uint64(0))
 	case "halo.attest.module.Module.consensus_trim_lag":
 		return protoreflect.ValueOfUint64(uint64(0))
+	case "halo.attest.module.Module.max_attestation_age":
+		return protoreflect.ValueOfUint64(uint64(0))
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: halo.attest.module.Module"))
@@ -401,6 +422,9 @@ func (x *fastReflection_Module) ProtoMethods() *protoiface.Methods {
 		if x.ConsensusTrimLag != 0 {
 			n += 1 + runtime.Sov(uint64(x.ConsensusTrimLag))
 		}
+		if x.MaxAttestationAge != 0 {
+			n += 1 + runtime.Sov(uint64(x.MaxAttestationAge))
+		}
 		if x.unknownFields != nil {
 			n += len(x.unknownFields)
 		}
@@ -430,6 +454,11 @@ func (x *fastReflection_Module) ProtoMethods() *protoiface.Methods {
 			i -= len(x.unknownFields)
 			copy(dAtA[i:], x.unknownFields)
 		}
+		if x.MaxAttestationAge != 0 {
+			i = runtime.EncodeVarint(dAtA, i, uint64(x.MaxAttestationAge))
+			i--
+			dAtA[i] = 0x38
+		}
 		if x.ConsensusTrimLag != 0 {
 			i = runtime.EncodeVarint(dAtA, i, uint64(x.ConsensusTrimLag))
 			i--
@@ -638,6 +667,25 @@ func (x *fastReflection_Module) ProtoMethods() *protoiface.Methods {
 						break
 					}
 				}
+			case 7:
+				if wireType != 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field MaxAttestationAge", wireType)
+				}
+				x.MaxAttestationAge = 0
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					x.MaxAttestationAge |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
 			default:
 				iNdEx = preIndex
 				skippy, err := runtime.Skip(dAtA[iNdEx:])
@@ -706,6 +754,9 @@ type Module struct {
 	TrimLag uint64 `protobuf:"varint,5,opt,name=trim_lag,json=trimLag,proto3" json:"trim_lag,omitempty"`
 	// consensus_trim_lag defines the number of blocks after which consensus-chain attestations are deleted from the module state.
 	ConsensusTrimLag uint64 `protobuf:"varint,6,opt,name=consensus_trim_lag,json=consensusTrimLag,proto3" json:"consensus_trim_lag,omitempty"`
+	// max_attestation_age defines the number of blocks after which pending attestations of already approved offsets
+	// are rejected and deleted from the module state. Zero disables this.
+	MaxAttestationAge uint64 `protobuf:"varint,7,opt,name=max_attestation_age,json=maxAttestationAge,proto3" json:"max_attestation_age,omitempty"`
 }
 
 func (x *Module) Reset() {
@@ -770,6 +821,13 @@ func (x *Module) GetConsensusTrimLag() uint64 {
 	return 0
 }
 
+func (x *Module) GetMaxAttestationAge() uint64 {
+	if x != nil {
+		return x.MaxAttestationAge
+	}
+	return 0
+}
+
 var File_halo_attest_module_module_proto protoreflect.FileDescriptor
 
 var file_halo_attest_module_module_proto_rawDesc = []byte{
@@ -778,7 +836,7 @@ var file_halo_attest_module_module_proto_rawDesc = []byte{
 	0x6f, 0x12, 0x12, 0x68, 0x61, 0x6c, 0x6f, 0x2e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x6d,
 	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x1a, 0x20, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x61, 0x70,
 	0x70, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa3, 0x02, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x75,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd3, 0x02, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x75,
 	0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79,
 	0x12, 0x24, 0x0a, 0x0e, 0x76, 0x6f, 0x74, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f,
@@ -793,7 +851,10 @@ var file_halo_attest_module_module_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x72, 0x69, 0x6d, 0x4c, 0x61, 0x67, 0x12, 0x2c, 0x0a,
 	0x12, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x5f,
 	0x6c, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x65,
-	0x6e, 0x73, 0x75, 0x73, 0x54, 0x72, 0x69, 0x6d, 0x4c, 0x61, 0x67, 0x3a, 0x30, 0xba, 0xc0, 0x96,
+	0x6e, 0x73, 0x75, 0x73, 0x54, 0x72, 0x69, 0x6d, 0x4c, 0x61, 0x67, 0x12, 0x2e, 0x0a, 0x13, 0x6d,
+	0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61,
+	0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x67, 0x65, 0x3a, 0x30, 0xba, 0xc0, 0x96,
 	0xda, 0x01, 0x2a, 0x0a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
 	0x6f, 0x6d, 0x6e, 0x69, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x6f, 0x6d, 0x6e,
 	0x69, 0x2f, 0x68, 0x61, 0x6c, 0x6f, 0x2f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x42, 0xb4, 0x01,
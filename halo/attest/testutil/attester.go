@@ -0,0 +1,88 @@
+package testutil
+
+import (
+	"sync"
+
+	"github.com/omni-network/omni/halo/attest/types"
+	"github.com/omni-network/omni/halo/attest/voter"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignedPayload is an attestation payload an Attester was asked to sign.
+type SignedPayload struct {
+	AttestHeader    xchain.AttestHeader
+	BlockHeader     xchain.BlockHeader
+	AttestationRoot common.Hash
+}
+
+// Attester is a deterministic test attester that records every payload it was asked to sign,
+// and signs with deterministic fake signatures instead of real crypto.
+// This allows tests to assert exactly which blocks were attested and in what order.
+// Note that fake signatures don't verify, see types.Vote.Verify. It is safe for concurrent use.
+type Attester struct {
+	address common.Address
+
+	mu     sync.Mutex
+	signed []SignedPayload
+}
+
+// NewAttester returns a new test attester signing as the provided address.
+func NewAttester(address common.Address) *Attester {
+	return &Attester{address: address}
+}
+
+// Address returns the attester's address.
+func (a *Attester) Address() common.Address {
+	return a.address
+}
+
+// Vote returns a vote for the block with a deterministic fake signature, recording the signed payload.
+func (a *Attester) Vote(attHeader xchain.AttestHeader, block xchain.Block) (*types.Vote, error) {
+	return voter.CreateVoteWithSigner(a.Sign, a.address, attHeader, block)
+}
+
+// Sign returns a deterministic fake signature over the attestation root, recording the signed payload.
+// It implements voter.Signer, so the attester can be plugged into a voter, see voter.WithSigner.
+func (a *Attester) Sign(attHeader xchain.AttestHeader, header xchain.BlockHeader, attRoot [32]byte) ([65]byte, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.signed = append(a.signed, SignedPayload{
+		AttestHeader:    attHeader,
+		BlockHeader:     header,
+		AttestationRoot: attRoot,
+	})
+
+	return FakeSignature(a.address, attRoot), nil
+}
+
+// Signed returns a copy of all payloads signed so far, in order.
+func (a *Attester) Signed() []SignedPayload {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]SignedPayload(nil), a.signed...)
+}
+
+// SignedHeights returns the block heights of all payloads signed so far, in order.
+func (a *Attester) SignedHeights() []uint64 {
+	var resp []uint64
+	for _, payload := range a.Signed() {
+		resp = append(resp, payload.BlockHeader.BlockHeight)
+	}
+
+	return resp
+}
+
+// FakeSignature returns the deterministic fake signature of the address over the attestation root.
+func FakeSignature(address common.Address, attRoot [32]byte) [65]byte {
+	var sig [65]byte
+	r := crypto.Keccak256(address.Bytes(), attRoot[:])
+	copy(sig[:32], r)
+	copy(sig[32:64], crypto.Keccak256(r))
+
+	return sig
+}
@@ -0,0 +1,46 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/omni-network/omni/halo/attest/testutil"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttester(t *testing.T) {
+	t.Parallel()
+
+	address := common.Address{0x01}
+	attester := testutil.NewAttester(address)
+	chainVer := xchain.ChainVersion{ID: 100, ConfLevel: xchain.ConfFinalized}
+
+	vote := func(offset, height uint64) []byte {
+		t.Helper()
+		v, err := attester.Vote(
+			xchain.AttestHeader{ConsensusChainID: 1, ChainVersion: chainVer, AttestOffset: offset},
+			xchain.Block{BlockHeader: xchain.BlockHeader{ChainID: chainVer.ID, BlockHeight: height, BlockHash: common.Hash{byte(height)}}},
+		)
+		require.NoError(t, err)
+		require.Equal(t, address.Bytes(), v.Signature.ValidatorAddress)
+
+		return v.Signature.Signature
+	}
+
+	sig1 := vote(1, 10)
+	sig2 := vote(2, 12)
+	require.NotEqual(t, sig1, sig2)
+	require.Equal(t, sig1, vote(1, 10)) // Deterministic
+
+	require.Equal(t, []uint64{10, 12, 10}, attester.SignedHeights())
+
+	signed := attester.Signed()
+	require.Len(t, signed, 3)
+	require.Equal(t, uint64(2), signed[1].AttestHeader.AttestOffset)
+	require.Equal(t, signed[0].AttestationRoot, signed[2].AttestationRoot)
+
+	fake := testutil.FakeSignature(address, signed[0].AttestationRoot)
+	require.Equal(t, fake[:], sig1)
+}
@@ -58,3 +58,9 @@ type ChainVerNameFunc func(xchain.ChainVersion) string
 type AttestKeeper interface {
 	ListAttestationsFrom(ctx context.Context, chainID uint64, confLevel uint32, offset uint64, max uint64) ([]*Attestation, error)
 }
+
+// UpgradeKeeper abstracts the upgrade keeper, used to gate state changes behind network upgrades.
+type UpgradeKeeper interface {
+	// GetDoneHeight returns the height at which the named upgrade was applied, or zero if not applied.
+	GetDoneHeight(ctx context.Context, name string) (int64, error)
+}
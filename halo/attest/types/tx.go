@@ -8,6 +8,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// ErrInvalidSignature is returned when a vote signature fails verification, e.g. signed by a different key or
+// over different data.
+var ErrInvalidSignature = errors.New("invalid attestation signature")
+
 const (
 	StatusUnknown  uint32 = 0
 	StatusPending  uint32 = 1
@@ -57,9 +61,10 @@ func (v *Vote) Verify() error {
 		xchain.Signature65(v.Signature.Signature),
 	)
 	if err != nil {
-		return err
+		// Corrupted signatures are also invalid.
+		return errors.Wrap(ErrInvalidSignature, err.Error(), "validator", common.Address(v.Signature.ValidatorAddress))
 	} else if !ok {
-		return errors.New("invalid attestation signature")
+		return errors.Wrap(ErrInvalidSignature, "verify", "validator", common.Address(v.Signature.ValidatorAddress))
 	}
 
 	return nil
@@ -176,9 +181,10 @@ func (a *AggVote) Verify() error {
 			xchain.Signature65(sig.Signature),
 		)
 		if err != nil {
-			return err
+			// Corrupted signatures are also invalid.
+			return errors.Wrap(ErrInvalidSignature, err.Error(), "validator", addr)
 		} else if !ok {
-			return errors.New("invalid attestation signature")
+			return errors.Wrap(ErrInvalidSignature, "verify", "validator", addr)
 		}
 
 		if duplicateVals[addr] {
@@ -190,6 +196,28 @@ func (a *AggVote) Verify() error {
 	return nil
 }
 
+// InvalidSigners returns the validator addresses of all signatures of the aggregate vote that fail verification.
+func (a *AggVote) InvalidSigners() ([]common.Address, error) {
+	attRoot, err := a.AttestationRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []common.Address
+	for _, sig := range a.Signatures {
+		if sig.Verify() != nil {
+			continue // Malformed, not an invalid signature.
+		}
+
+		addr := common.BytesToAddress(sig.ValidatorAddress)
+		if ok, err := k1util.Verify(addr, attRoot, xchain.Signature65(sig.Signature)); err != nil || !ok {
+			resp = append(resp, addr)
+		}
+	}
+
+	return resp, nil
+}
+
 func (a *AggVote) AttestationRoot() (common.Hash, error) {
 	return xchain.AttestationRoot(a.AttestHeader.ToXChain(), a.BlockHeader.ToXChain(), common.Hash(a.MsgRoot))
 }
@@ -0,0 +1,145 @@
+package voter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/halo/attest/types"
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AuditRecord is a single entry of the attester audit log, recording a signed attestation.
+type AuditRecord struct {
+	Chain           string      `json:"chain"`
+	ChainID         uint64      `json:"chain_id"`
+	ConfLevel       uint32      `json:"conf_level"`
+	AttestOffset    uint64      `json:"attest_offset"`
+	BlockHeight     uint64      `json:"block_height"`
+	BlockHash       common.Hash `json:"block_hash"`
+	AttestationRoot common.Hash `json:"attestation_root"`
+	SignedAt        time.Time   `json:"signed_at"`
+}
+
+// auditLog is an append-only JSONL file of all attestations signed by the voter.
+// It is separate from the voter state file and purely for human/audit consumption.
+type auditLog struct {
+	fsync bool
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// openAuditLog opens (or creates) the audit log at the provided path for appending.
+func openAuditLog(path string, fsync bool) (*auditLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "open audit log")
+	}
+
+	return &auditLog{fsync: fsync, file: file}, nil
+}
+
+// Append appends a record for the signed vote, syncing the file to disk if enabled.
+func (l *auditLog) Append(chain string, vote *types.Vote, signedAt time.Time) error {
+	attRoot, err := vote.AttestationRoot()
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.Marshal(AuditRecord{
+		Chain:           chain,
+		ChainID:         vote.AttestHeader.GetSourceChainId(),
+		ConfLevel:       vote.AttestHeader.GetConfLevel(),
+		AttestOffset:    vote.AttestHeader.GetAttestOffset(),
+		BlockHeight:     vote.BlockHeader.GetBlockHeight(),
+		BlockHash:       common.BytesToHash(vote.BlockHeader.GetBlockHash()),
+		AttestationRoot: attRoot,
+		SignedAt:        signedAt.UTC(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal audit record")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return errors.New("audit log closed")
+	}
+
+	if _, err := l.file.Write(append(bz, '\n')); err != nil {
+		return errors.Wrap(err, "write audit record")
+	}
+
+	if l.fsync {
+		if err := l.file.Sync(); err != nil {
+			return errors.Wrap(err, "sync audit log")
+		}
+	}
+
+	return nil
+}
+
+// Close syncs and closes the audit log file. Subsequent appends fail. It is idempotent.
+func (l *auditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	file := l.file
+	l.file = nil
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "sync audit log")
+	} else if err := file.Close(); err != nil {
+		return errors.Wrap(err, "close audit log")
+	}
+
+	return nil
+}
+
+// ReadAuditLog returns the last n records of the audit log at the provided path (oldest first).
+// A trailing partially written record (e.g. due to a crash) is ignored.
+func ReadAuditLog(path string, n int) ([]AuditRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open audit log")
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "scan audit log")
+	}
+
+	resp := make([]AuditRecord, 0, len(lines))
+	for i, line := range lines {
+		var record AuditRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			if i == len(lines)-1 {
+				break // Ignore partially written last record.
+			}
+
+			return nil, errors.Wrap(err, "unmarshal audit record")
+		}
+		resp = append(resp, record)
+	}
+
+	return resp, nil
+}
@@ -85,4 +85,18 @@ var (
 		Name:      "reorg_total",
 		Help:      "Total number of reorgs detected per source chain version.",
 	}, []string{"chain_version"})
+
+	submittedVotes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "halo",
+		Subsystem: "voter",
+		Name:      "submitted_votes_total",
+		Help:      "Total number of votes submitted via vote extensions per source chain version, resubmissions are only counted once. Use rate() for the submission rate.",
+	}, []string{"chain_version"})
+
+	submitLimitedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "halo",
+		Subsystem: "voter",
+		Name:      "submit_limited_total",
+		Help:      "Total number of vote submissions delayed or truncated by the submit rate limit.",
+	})
 )
@@ -0,0 +1,78 @@
+package voter
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+)
+
+// writeFileAtomic durably replaces the file at the path with the data, so a crash never leaves a torn file.
+// It writes the data to a temporary file in the same directory, fsyncs it, atomically renames it
+// to the path, and finally fsyncs the directory to persist the rename.
+// Failing to fsync the directory is only logged, since the file was already replaced.
+// The optional wrap function wraps the temporary file writer, allowing tests to simulate partial writes.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, wrap func(io.Writer) io.Writer) error {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	f, err := os.CreateTemp(dir, base+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "create temp file")
+	}
+	tmpPath := f.Name()
+
+	// Remove the temporary file on any failure, leaving the previous file intact.
+	success := false
+	defer func() {
+		if !success {
+			_ = f.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	var w io.Writer = f
+	if wrap != nil {
+		w = wrap(f)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "write temp file")
+	} else if err := f.Chmod(perm); err != nil {
+		return errors.Wrap(err, "chmod temp file")
+	} else if err := f.Sync(); err != nil {
+		return errors.Wrap(err, "sync temp file")
+	} else if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close temp file")
+	} else if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrap(err, "rename temp file")
+	}
+	success = true
+
+	if err := syncDir(dir); err != nil {
+		// The file was replaced, so the state is consistent unless the host crashes before the directory is flushed.
+		log.Warn(context.Background(), "Failed syncing directory after replacing file (will continue)", err, "path", path)
+	}
+
+	return nil
+}
+
+// syncDir fsyncs the directory, persisting renames of its entries.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "open dir")
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return errors.Wrap(err, "sync dir")
+	}
+
+	return nil
+}
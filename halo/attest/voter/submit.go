@@ -0,0 +1,71 @@
+package voter
+
+import (
+	"slices"
+	"time"
+
+	"github.com/omni-network/omni/halo/attest/types"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// submitLimiter rate-limits the submission of available votes, see WithSubmitRateLimit.
+// It is not thread safe, it relies on the voter lock.
+type submitLimiter struct {
+	interval time.Duration // Zero disables the interval limit.
+	maxVotes int           // Zero disables the batch size limit.
+	now      func() time.Time
+
+	last time.Time // Last non-empty submission
+}
+
+// Limit returns a copy of the votes to submit now.
+func (l *submitLimiter) Limit(available []*types.Vote) []*types.Vote {
+	if len(available) == 0 {
+		return nil
+	}
+
+	now := l.now()
+	if l.interval > 0 && !l.last.IsZero() && now.Sub(l.last) < l.interval {
+		submitLimitedTotal.Inc()
+		return nil
+	}
+	l.last = now
+
+	if l.maxVotes <= 0 || len(available) <= l.maxVotes {
+		return slices.Clone(available)
+	}
+
+	submitLimitedTotal.Inc()
+
+	return roundRobin(available, l.maxVotes)
+}
+
+// roundRobin returns up to limit votes, taking the oldest votes of each chain version in turn.
+// This ensures a single chain catching up doesn't starve the others.
+func roundRobin(votes []*types.Vote, limit int) []*types.Vote {
+	var (
+		order  []xchain.ChainVersion
+		queues = make(map[xchain.ChainVersion][]*types.Vote)
+	)
+	for _, vote := range votes {
+		chainVer := vote.AttestHeader.XChainVersion()
+		if _, ok := queues[chainVer]; !ok {
+			order = append(order, chainVer)
+		}
+		queues[chainVer] = append(queues[chainVer], vote)
+	}
+
+	resp := make([]*types.Vote, 0, limit)
+	for len(resp) < limit {
+		for _, chainVer := range order {
+			queue := queues[chainVer]
+			if len(queue) == 0 || len(resp) >= limit {
+				continue
+			}
+			resp = append(resp, queue[0])
+			queues[chainVer] = queue[1:]
+		}
+	}
+
+	return resp
+}
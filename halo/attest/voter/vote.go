@@ -7,33 +7,47 @@ import (
 	"github.com/omni-network/omni/lib/xchain"
 
 	"github.com/cometbft/cometbft/crypto"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// Signer signs the attestation root of the attestation and block headers.
+// The headers allow signers to track what they sign, e.g. remote signers or test attesters.
+type Signer func(attHeader xchain.AttestHeader, header xchain.BlockHeader, attRoot [32]byte) ([65]byte, error)
+
+// PrivKeySigner returns a signer that signs with the private key.
+func PrivKeySigner(privKey crypto.PrivKey) Signer {
+	return func(_ xchain.AttestHeader, _ xchain.BlockHeader, attRoot [32]byte) ([65]byte, error) {
+		return k1util.Sign(privKey, attRoot)
+	}
+}
+
 // CreateVote creates a vote for the given block.
 func CreateVote(privKey crypto.PrivKey, attHeader xchain.AttestHeader, block xchain.Block) (*types.Vote, error) {
-	var msgRoot [32]byte
-	if len(block.Msgs) > 0 {
-		tree, err := xchain.NewMsgTree(block.Msgs)
-		if err != nil {
-			return nil, err
-		}
+	address, err := k1util.PubKeyToAddress(privKey.PubKey())
+	if err != nil {
+		return nil, err
+	}
 
-		msgRoot = tree.MsgRoot()
-	} // else use zero value msgRoot
+	return CreateVoteWithSigner(PrivKeySigner(privKey), address, attHeader, block)
+}
 
-	attRoot, err := xchain.AttestationRoot(attHeader, block.BlockHeader, msgRoot)
+// CreateVoteWithSigner creates a vote for the given block signed by the provided signer and address.
+// This allows creating votes without a private key, e.g. in tests, see testutil.Attester.
+func CreateVoteWithSigner(sign Signer, address common.Address, attHeader xchain.AttestHeader, block xchain.Block) (*types.Vote, error) {
+	msgRoot, err := xchain.BlockMsgRoot(block)
 	if err != nil {
 		return nil, err
 	}
 
-	sig, err := k1util.Sign(privKey, attRoot)
+	attRoot, err := xchain.AttestationRoot(attHeader, block.BlockHeader, msgRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "sign attestation")
+		return nil, err
 	}
 
-	address, err := k1util.PubKeyToAddress(privKey.PubKey())
+	sig, err := sign(attHeader, block.BlockHeader, attRoot)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "sign attestation")
 	}
 
 	return &types.Vote{
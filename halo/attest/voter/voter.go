@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -21,7 +21,6 @@ import (
 
 	"github.com/cometbft/cometbft/crypto"
 	k1 "github.com/cometbft/cometbft/crypto/secp256k1"
-	"github.com/cometbft/cometbft/libs/tempfile"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -45,7 +44,8 @@ var _ types.Voter = (*Voter)(nil)
 type Voter struct {
 	path        string
 	cChainID    uint64
-	privKey     crypto.PrivKey
+	sign        Signer // Signs votes as address, defaults to the private key, see WithSigner
+	verifySigs  bool   // Verify vote signatures, false for custom signers
 	network     netconf.Network
 	address     common.Address
 	provider    xchain.Provider
@@ -53,16 +53,63 @@ type Voter struct {
 	backoffFunc func(context.Context) func()
 	wg          sync.WaitGroup
 	asyncAbort  chan<- error
+	audit       *auditLog // Nil if disabled
+	submit      submitLimiter
 
 	mu          sync.Mutex
 	latest      map[xchain.ChainVersion]*types.Vote // Latest vote per chain
 	available   []*types.Vote
 	proposed    []*types.Vote
 	committed   []*types.Vote
+	submitted   map[xchain.AttestHeader]bool   // Available or proposed votes already counted as submitted.
 	minsByChain map[xchain.ChainVersion]uint64 // map[chainID]offset
 	isVal       bool
 	valSetID    uint64
-	errAborted  error // Abort when state persistence fails.
+	errAborted  error                     // Abort when state persistence fails.
+	wrapWriter  func(io.Writer) io.Writer // Wraps the state file writer, nil except in tests.
+}
+
+// Option configures the voter.
+type Option func(*options)
+
+type options struct {
+	sign           Signer
+	signerAddress  common.Address
+	auditFile      string
+	auditFsync     bool
+	submitInterval time.Duration
+	submitMaxVotes int
+}
+
+// WithSigner returns an option that signs votes with the provided signer as the address
+// instead of with the private key, e.g. a remote signer or a test attester.
+// Signatures of custom signers aren't verified against the address, since test signers produce fake signatures,
+// all other vote fields are still verified.
+func WithSigner(sign Signer, address common.Address) Option {
+	return func(o *options) {
+		o.sign = sign
+		o.signerAddress = address
+	}
+}
+
+// WithAuditLog returns an option that appends a record of every signed attestation to the JSONL audit file
+// at the provided path, optionally syncing it to disk after each record. See ReadAuditLog.
+func WithAuditLog(path string, fsync bool) Option {
+	return func(o *options) {
+		o.auditFile = path
+		o.auditFsync = fsync
+	}
+}
+
+// WithSubmitRateLimit returns an option that rate-limits the submission of available votes (via vote extensions).
+// Votes are only returned by GetAvailable at most once per interval, coalescing all votes created in the meantime
+// into a single batch of at most maxVotes (evenly spread across chain versions).
+// This protects consensus from bursts of votes during catch-up. Zero values disable the respective limit.
+func WithSubmitRateLimit(interval time.Duration, maxVotes int) Option {
+	return func(o *options) {
+		o.submitInterval = interval
+		o.submitMaxVotes = maxVotes
+	}
 }
 
 // GenEmptyStateFile generates an empty attester state file at the given path.
@@ -72,6 +119,7 @@ func GenEmptyStateFile(path string) error {
 }
 
 // LoadVoter returns a new attester with state loaded from disk.
+// The private key may be nil if a custom signer is provided, see WithSigner.
 func LoadVoter(
 	privKey crypto.PrivKey,
 	path string,
@@ -79,23 +127,37 @@ func LoadVoter(
 	deps types.VoterDeps,
 	network netconf.Network,
 	asyncAbort chan<- error,
+	opts ...Option,
 ) (*Voter, error) {
-	if len(privKey.PubKey().Bytes()) != k1.PubKeySize {
-		return nil, errors.New("invalid private key")
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
 
-	s, err := loadState(path)
-	if err != nil {
-		return nil, err
+	sign, addr, verifySigs := o.sign, o.signerAddress, false
+	if sign == nil {
+		if privKey == nil || len(privKey.PubKey().Bytes()) != k1.PubKeySize {
+			return nil, errors.New("invalid private key")
+		}
+
+		var err error
+		addr, err = k1util.PubKeyToAddress(privKey.PubKey())
+		if err != nil {
+			return nil, err
+		}
+
+		sign = PrivKeySigner(privKey)
+		verifySigs = true
 	}
 
-	addr, err := k1util.PubKeyToAddress(privKey.PubKey())
+	s, err := loadState(path)
 	if err != nil {
 		return nil, err
 	}
 
 	v := &Voter{
-		privKey:    privKey,
+		sign:       sign,
+		verifySigs: verifySigs,
 		cChainID:   network.ID.Static().OmniConsensusChainIDUint64(),
 		address:    addr,
 		path:       path,
@@ -103,6 +165,11 @@ func LoadVoter(
 		provider:   provider,
 		deps:       deps,
 		asyncAbort: asyncAbort,
+		submit: submitLimiter{
+			interval: o.submitInterval,
+			maxVotes: o.submitMaxVotes,
+			now:      time.Now,
+		},
 		backoffFunc: func(ctx context.Context) func() {
 			return expbackoff.New(ctx, expbackoff.WithPeriodicConfig(prodBackoff))
 		},
@@ -110,6 +177,7 @@ func LoadVoter(
 		available: s.Available,
 		proposed:  s.Proposed,
 		committed: s.Committed,
+		submitted: make(map[xchain.AttestHeader]bool),
 		latest:    latestFromJSON(s.Latest),
 	}
 
@@ -118,6 +186,13 @@ func LoadVoter(
 		return nil, err
 	}
 
+	if o.auditFile != "" {
+		v.audit, err = openAuditLog(o.auditFile, o.auditFsync)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return v, nil
 }
 
@@ -130,9 +205,16 @@ func (v *Voter) Start(ctx context.Context) {
 	}
 }
 
-// WaitDone waits for all runners to exit. Note the original Start context must be canceled to exit.
+// WaitDone waits for all runners to exit and then closes the audit log.
+// Note the original Start context must be canceled to exit.
 func (v *Voter) WaitDone() {
 	v.wg.Wait()
+
+	if v.audit != nil {
+		if err := v.audit.Close(); err != nil {
+			log.Warn(context.Background(), "Failed closing attester audit log", err)
+		}
+	}
 }
 
 // minWindow returns the minimum vote window (attestation offset).
@@ -312,10 +394,10 @@ func (v *Voter) Vote(attHeader xchain.AttestHeader, block xchain.Block, allowSki
 		return v.errAborted
 	}
 
-	vote, err := CreateVote(v.privKey, attHeader, block)
+	vote, err := CreateVoteWithSigner(v.sign, v.address, attHeader, block)
 	if err != nil {
 		return err
-	} else if err := vote.Verify(); err != nil {
+	} else if err := vote.Verify(); err != nil && (v.verifySigs || !errors.Is(err, types.ErrInvalidSignature)) {
 		return errors.Wrap(err, "verify vote")
 	}
 
@@ -343,7 +425,18 @@ func (v *Voter) Vote(attHeader xchain.AttestHeader, block xchain.Block, allowSki
 		createMsgOffset.WithLabelValues(v.network.StreamName(stream)).Set(float64(msgOffset))
 	}
 
-	return v.saveUnsafe()
+	if err := v.saveUnsafe(); err != nil {
+		return err
+	}
+
+	if v.audit != nil {
+		// Audit log failures don't affect voting, since it is purely for human consumption.
+		if err := v.audit.Append(name, vote, time.Now()); err != nil {
+			log.Warn(context.Background(), "Failed appending attester audit log", err)
+		}
+	}
+
+	return nil
 }
 
 // UpdateValidatorSet caches whether this voter is a validator in the provided set.
@@ -407,6 +500,7 @@ func (v *Voter) TrimBehind(minsByChain map[xchain.ChainVersion]uint64) int {
 
 	v.available = remainingAvailable
 	v.proposed = remainingProposed
+	v.pruneSubmittedUnsafe()
 
 	v.minsByChain = minsByChain
 
@@ -424,7 +518,7 @@ func (v *Voter) AvailableCount() int {
 	return len(v.available)
 }
 
-// GetAvailable returns a copy of all the available votes.
+// GetAvailable returns a copy of all the available votes, subject to the submit rate limit if configured.
 func (v *Voter) GetAvailable() []*types.Vote {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -432,7 +526,18 @@ func (v *Voter) GetAvailable() []*types.Vote {
 		return nil
 	}
 
-	return slices.Clone(v.available)
+	resp := v.submit.Limit(v.available)
+	for _, vote := range resp {
+		// Votes are resubmitted until committed, but only counted once.
+		header := vote.AttestHeader.ToXChain()
+		if v.submitted[header] {
+			continue
+		}
+		v.submitted[header] = true
+		submittedVotes.WithLabelValues(v.network.ChainVersionName(vote.AttestHeader.XChainVersion())).Inc()
+	}
+
+	return resp
 }
 
 // SetProposed sets the votes as proposed.
@@ -498,6 +603,7 @@ func (v *Voter) SetCommitted(headers []*types.AttestHeader) error {
 	v.available = newAvailable
 	v.proposed = nil
 	v.committed = pruneLatestPerChain(newCommitted)
+	v.pruneSubmittedUnsafe()
 
 	// Update committed height metrics.
 	for _, vote := range v.committed {
@@ -521,6 +627,21 @@ func (v *Voter) availableAndProposedUnsafe() []*types.Vote {
 	return resp
 }
 
+// pruneSubmittedUnsafe stops tracking submitted votes that are no longer available or proposed, e.g. committed or trimmed.
+// It is unsafe since it assumes the lock is held.
+func (v *Voter) pruneSubmittedUnsafe() {
+	pending := make(map[xchain.AttestHeader]bool)
+	for _, vote := range v.availableAndProposedUnsafe() {
+		pending[vote.AttestHeader.ToXChain()] = true
+	}
+
+	for header := range v.submitted {
+		if !pending[header] {
+			delete(v.submitted, header)
+		}
+	}
+}
+
 func (v *Voter) latestByChain(chainVer xchain.ChainVersion) (*types.Vote, bool) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -556,7 +677,7 @@ func (v *Voter) saveUnsafe() error {
 		return errors.Wrap(err, "marshal state path")
 	}
 
-	if err := tempfile.WriteFileAtomic(v.path, bz, 0o600); err != nil {
+	if err := writeFileAtomic(v.path, bz, 0o600, v.wrapWriter); err != nil {
 		// Abort the voter if the state cannot be persisted.
 		// Voter in-memory and disk state are now inconsistent.
 		// Force binary restart to recover.
@@ -2,7 +2,9 @@ package voter
 
 import (
 	"context"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/omni-network/omni/halo/attest/types"
 	"github.com/omni-network/omni/lib/netconf"
@@ -16,10 +18,10 @@ import (
 // LoadVoterForT is a helper function to load a voter for testing.
 // It sets the backoff period to 1ms.
 func LoadVoterForT(t *testing.T, privKey crypto.PrivKey, path string, provider xchain.Provider,
-	deps types.VoterDeps, network netconf.Network, backoff func(),
+	deps types.VoterDeps, network netconf.Network, backoff func(), opts ...Option,
 ) *Voter {
 	t.Helper()
-	v, err := LoadVoter(privKey, path, provider, deps, network, make(chan error, 1))
+	v, err := LoadVoter(privKey, path, provider, deps, network, make(chan error, 1), opts...)
 	require.NoError(t, err)
 
 	v.backoffFunc = func(ctx context.Context) func() { return backoff }
@@ -31,3 +33,24 @@ func LoadVoterForT(t *testing.T, privKey crypto.PrivKey, path string, provider x
 func (v *Voter) LatestByChain(chainVer xchain.ChainVersion) (*types.Vote, bool) {
 	return v.latestByChain(chainVer)
 }
+
+// SetNowForT sets the submit rate limit clock for testing purposes only.
+func (v *Voter) SetNowForT(now func() time.Time) {
+	v.submit.now = now
+}
+
+// SubmittedCountForT returns the number of tracked submitted votes for testing purposes only.
+func (v *Voter) SubmittedCountForT() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return len(v.submitted)
+}
+
+// SetStateWriterForT wraps the state file writer of the voter for testing purposes only.
+func (v *Voter) SetStateWriterForT(wrap func(io.Writer) io.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.wrapWriter = wrap
+}
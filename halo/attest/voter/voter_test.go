@@ -4,15 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/omni-network/omni/halo/attest/testutil"
 	"github.com/omni-network/omni/halo/attest/types"
 	"github.com/omni-network/omni/halo/attest/voter"
 	vtypes "github.com/omni-network/omni/halo/valsync/types"
+	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/k1util"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/xchain"
@@ -25,6 +28,195 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	require.NoError(t, voter.GenEmptyStateFile(path))
+	auditPath := filepath.Join(dir, "audit.jsonl")
+
+	const (
+		chain1 = 1
+		conf   = xchain.ConfFinalized
+	)
+
+	pk := k1.GenPrivKey()
+	network := testNetwork(chain1)
+	v := voter.LoadVoterForT(t, pk, path, make(stubProvider), &mockDeps{}, network, new(testBackOff).BackOff,
+		voter.WithAuditLog(auditPath, true))
+
+	chainVer := xchain.ChainVersion{ID: chain1, ConfLevel: conf}
+	for offset := uint64(1); offset <= 3; offset++ {
+		att := xchain.AttestHeader{ConsensusChainID: 1, ChainVersion: chainVer, AttestOffset: offset}
+		block := xchain.Block{BlockHeader: xchain.BlockHeader{ChainID: chain1, BlockHeight: offset * 10, BlockHash: common.Hash{byte(offset)}}}
+		require.NoError(t, v.Vote(att, block, false))
+	}
+
+	records, err := voter.ReadAuditLog(auditPath, 2)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for i, record := range records {
+		offset := uint64(i + 2)
+		require.Equal(t, network.ChainVersionName(chainVer), record.Chain)
+		require.Equal(t, uint64(chain1), record.ChainID)
+		require.Equal(t, uint32(conf), record.ConfLevel)
+		require.Equal(t, offset, record.AttestOffset)
+		require.Equal(t, offset*10, record.BlockHeight)
+		require.Equal(t, common.Hash{byte(offset)}, record.BlockHash)
+		require.NotZero(t, record.SignedAt)
+	}
+
+	latest, ok := v.LatestByChain(chainVer)
+	require.True(t, ok)
+	attRoot, err := latest.AttestationRoot()
+	require.NoError(t, err)
+	require.Equal(t, attRoot, records[1].AttestationRoot)
+
+	// A partially written last record is ignored.
+	f, err := os.OpenFile(auditPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"chain":"partial`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	records, err = voter.ReadAuditLog(auditPath, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, uint64(1), records[0].AttestOffset)
+
+	// Stopping the voter closes the audit log, subsequent votes aren't audited but still succeed.
+	v.WaitDone()
+	att := xchain.AttestHeader{ConsensusChainID: 1, ChainVersion: chainVer, AttestOffset: 4}
+	block := xchain.Block{BlockHeader: xchain.BlockHeader{ChainID: chain1, BlockHeight: 40, BlockHash: common.Hash{4}}}
+	require.NoError(t, v.Vote(att, block, false))
+
+	records, err = voter.ReadAuditLog(auditPath, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+}
+
+func TestSubmitRateLimit(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, voter.GenEmptyStateFile(path))
+
+	const (
+		chain1 = 1
+		chain2 = 2
+		conf   = xchain.ConfFinalized
+	)
+
+	pk := k1.GenPrivKey()
+	network := testNetwork(chain1, chain2)
+	v := voter.LoadVoterForT(t, pk, path, make(stubProvider), &mockDeps{}, network, new(testBackOff).BackOff,
+		voter.WithSubmitRateLimit(time.Minute, 3))
+
+	now := time.Now()
+	v.SetNowForT(func() time.Time { return now })
+
+	// Chain 1 is catching up with many votes, chain 2 only has one.
+	vote := func(chainID uint64, offset uint64) {
+		chainVer := xchain.ChainVersion{ID: chainID, ConfLevel: conf}
+		att := xchain.AttestHeader{ConsensusChainID: 1, ChainVersion: chainVer, AttestOffset: offset}
+		block := xchain.Block{BlockHeader: xchain.BlockHeader{ChainID: chainID, BlockHeight: offset}}
+		require.NoError(t, v.Vote(att, block, true))
+	}
+	for offset := uint64(1); offset <= 5; offset++ {
+		vote(chain1, offset)
+	}
+	vote(chain2, 1)
+
+	offsets := func(votes []*types.Vote) []string {
+		var resp []string
+		for _, vote := range votes {
+			resp = append(resp, fmt.Sprintf("%d/%d", vote.AttestHeader.SourceChainId, vote.AttestHeader.AttestOffset))
+		}
+
+		return resp
+	}
+
+	// First batch is truncated, round-robin across chains.
+	require.Equal(t, []string{"1/1", "2/1", "1/2"}, offsets(v.GetAvailable()))
+	require.Equal(t, 3, v.SubmittedCountForT())
+
+	// Nothing is submitted within the interval.
+	now = now.Add(time.Minute - time.Second)
+	require.Empty(t, v.GetAvailable())
+	require.Equal(t, 6, v.AvailableCount())
+
+	// Next batch after the interval, resubmitted votes are only counted once.
+	now = now.Add(time.Second)
+	require.Equal(t, []string{"1/1", "2/1", "1/2"}, offsets(v.GetAvailable()))
+	require.Equal(t, 3, v.SubmittedCountForT())
+
+	// Committed votes are no longer tracked.
+	committed := []*types.AttestHeader{{ConsensusChainId: 1, SourceChainId: chain2, ConfLevel: uint32(conf), AttestOffset: 1}}
+	require.NoError(t, v.SetCommitted(committed))
+	require.Equal(t, 2, v.SubmittedCountForT())
+}
+
+func TestStateAtomicWrite(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	require.NoError(t, voter.GenEmptyStateFile(path))
+
+	const chain1 = 1
+	pk := k1.GenPrivKey()
+	network := testNetwork(chain1)
+	v := voter.LoadVoterForT(t, pk, path, make(stubProvider), &mockDeps{}, network, new(testBackOff).BackOff)
+
+	chainVer := xchain.ChainVersion{ID: chain1, ConfLevel: xchain.ConfFinalized}
+	vote := func(v *voter.Voter, offset uint64) error {
+		att := xchain.AttestHeader{ConsensusChainID: 1, ChainVersion: chainVer, AttestOffset: offset}
+		block := xchain.Block{BlockHeader: xchain.BlockHeader{ChainID: chain1, BlockHeight: offset, BlockHash: common.Hash{byte(offset)}}}
+
+		return v.Vote(att, block, false)
+	}
+	require.NoError(t, vote(v, 1))
+
+	// Simulate a crash mid-write: only half the state is written before failing.
+	v.SetStateWriterForT(func(w io.Writer) io.Writer {
+		return partialWriter{w: w}
+	})
+	require.ErrorContains(t, vote(v, 2), "partial write")
+	v.SetStateWriterForT(nil)
+
+	// The previous valid state is recoverable and no temporary files remain.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "state.json", entries[0].Name())
+
+	v = voter.LoadVoterForT(t, pk, path, make(stubProvider), &mockDeps{}, network, new(testBackOff).BackOff)
+	latest, ok := v.LatestByChain(chainVer)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), latest.AttestHeader.AttestOffset)
+
+	// Saving works again after the failure.
+	require.NoError(t, vote(v, 2))
+	v = voter.LoadVoterForT(t, pk, path, make(stubProvider), &mockDeps{}, network, new(testBackOff).BackOff)
+	latest, ok = v.LatestByChain(chainVer)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), latest.AttestHeader.AttestOffset)
+}
+
+// partialWriter writes only the first half of the data and then fails.
+type partialWriter struct {
+	w io.Writer
+}
+
+func (p partialWriter) Write(data []byte) (int, error) {
+	n, err := p.w.Write(data[:len(data)/2])
+	if err != nil {
+		return n, err
+	}
+
+	return n, errors.New("partial write")
+}
+
 func TestAbort(t *testing.T) {
 	t.Parallel()
 
@@ -181,6 +373,54 @@ func TestRunner(t *testing.T) {
 	v.WaitDone()
 }
 
+func TestSigner(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, voter.GenEmptyStateFile(path))
+
+	const chain1 = 1
+
+	pk := k1.GenPrivKey()
+	addr, err := k1util.PubKeyToAddress(pk.PubKey())
+	require.NoError(t, err)
+	attester := testutil.NewAttester(addr)
+
+	network := testNetwork(chain1)
+	prov := make(stubProvider)
+	v := voter.LoadVoterForT(t, nil, path, prov, &mockDeps{}, network, new(testBackOff).BackOff,
+		voter.WithSigner(attester.Sign, attester.Address()))
+	require.Equal(t, addr, v.LocalAddress())
+
+	v.Start(ctx)
+	setIsVal(t, v, pk, true)
+
+	sub := <-prov
+	const count = 5
+	for height := uint64(0); height < count; height++ {
+		require.NoError(t, sub.callback(ctx, xchain.Block{
+			BlockHeader: xchain.BlockHeader{ChainID: chain1, BlockHeight: height},
+			Msgs:        []xchain.Msg{{}}, // Non-empty XBlock should always be attested to
+		}))
+	}
+
+	// All blocks are signed by the attester, in order.
+	require.Equal(t, []uint64{0, 1, 2, 3, 4}, attester.SignedHeights())
+
+	latest, ok := v.LatestByChain(xchain.ChainVersion{ID: chain1, ConfLevel: xchain.ConfFinalized})
+	require.True(t, ok)
+	attRoot, err := latest.AttestationRoot()
+	require.NoError(t, err)
+	require.Equal(t, addr.Bytes(), latest.Signature.ValidatorAddress)
+	sig := testutil.FakeSignature(addr, attRoot)
+	require.Equal(t, sig[:], latest.Signature.Signature)
+
+	cancel()
+	v.WaitDone()
+}
+
 func TestVoteWindow(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -25,6 +25,7 @@ func New() *cobra.Command {
 		newRunCmd("run", app.Run),
 		newInitCmd(),
 		newRollbackCmd(),
+		newSelfTestCmd(),
 		buildinfo.NewVersionCmd(),
 		newConsKeyCmd(),
 		newStatusCmd(),
@@ -113,6 +114,47 @@ different block N cannot be re-built/re-proposed since that would result in vali
 	return cmd
 }
 
+func newSelfTestCmd() *cobra.Command {
+	logCfg := log.DefaultConfig()
+	haloCfg := halocfg.DefaultConfig()
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Verifies engine API and xchain RPC connectivity and exits",
+		Long: `
+Selftest verifies the halo config and performs network round-trips to the
+engine API and all configured xchain EVM RPC endpoints, ensuring that they
+respond and that their chain IDs match. It also loads the network from the
+portal registry, ensuring that the configured chains and portals match it.
+It exits with a non-zero code if any check fails. It is intended as a deployment smoke test before promoting a node to validator.
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, err := log.Init(cmd.Context(), logCfg)
+			if err != nil {
+				return err
+			}
+			if err := libcmd.LogFlags(ctx, cmd.Flags()); err != nil {
+				return err
+			}
+
+			cmtCfg, err := parseCometConfig(ctx, haloCfg.HomeDir)
+			if err != nil {
+				return err
+			}
+
+			return app.SelfTest(ctx, app.Config{
+				Config: haloCfg,
+				Comet:  cmtCfg,
+			})
+		},
+	}
+
+	bindRunFlags(cmd, &haloCfg)
+	log.BindFlags(cmd.Flags(), &logCfg)
+
+	return cmd
+}
+
 func newConsKeyCmd() *cobra.Command {
 	home := halocfg.DefaultConfig().HomeDir
 
@@ -76,6 +76,7 @@ func TestCLIReference(t *testing.T) {
 		{"run"},
 		{"init"},
 		{"rollback"},
+		{"selftest"},
 	}
 
 	for _, test := range tests {
@@ -147,6 +148,10 @@ func TestTomlConfig(t *testing.T) {
 		expect.RPCEndpoints[strings.ToLower(randomString())] = randomString()
 		delete(expect.RPCEndpoints, k)
 	}
+	for k, v := range expect.Instrumentation.Labels {
+		delete(expect.Instrumentation.Labels, k)
+		expect.Instrumentation.Labels[strings.ToLower(k)] = v
+	}
 
 	// Ensure the <home>/config directory exists.
 	require.NoError(t, os.Mkdir(filepath.Join(dir, "config"), 0o755))
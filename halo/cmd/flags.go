@@ -25,8 +25,11 @@ func bindRunFlags(cmd *cobra.Command, cfg *halocfg.Config) {
 	netconf.BindFlag(flags, &cfg.Network)
 	bindRPCFlags(flags, "api", &cfg.SDKAPI)
 	bindRPCFlags(flags, "grpc", &cfg.SDKGRPC)
+	flags.StringVar(&cfg.Instrumentation.Namespace, "instrumentation-namespace", cfg.Instrumentation.Namespace, "Overrides the CometBFT prometheus metrics namespace")
+	flags.StringToStringVar(&cfg.Instrumentation.Labels, "instrumentation-labels", cfg.Instrumentation.Labels, "Static labels added to all CometBFT prometheus metrics. e.g. \"network=mainnet,node=validator01\"")
 	flags.StringVar(&cfg.EngineEndpoint, "engine-endpoint", cfg.EngineEndpoint, "An EVM execution client Engine API http endpoint")
-	flags.StringVar(&cfg.EngineJWTFile, "engine-jwt-file", cfg.EngineJWTFile, "The path to the Engine API JWT file")
+	flags.StringVar(&cfg.EngineJWTFile, "engine-jwt-file", cfg.EngineJWTFile, "The path to the Engine API JWT file. The "+halocfg.EngineJWTEnv+" env var takes precedence if set")
+	flags.BoolVar(&cfg.SnapshotsEnabled, "snapshots-enabled", cfg.SnapshotsEnabled, "Enables the state sync snapshot store (disable on nodes that never serve state sync)")
 	flags.Uint64Var(&cfg.SnapshotInterval, "snapshot-interval", cfg.SnapshotInterval, "State sync snapshot interval")
 	flags.Uint32Var(&cfg.SnapshotKeepRecent, "snapshot-keep-recent", cfg.SnapshotKeepRecent, "State sync snapshot to keep")
 	flags.Uint64Var(&cfg.MinRetainBlocks, "min-retain-blocks", cfg.MinRetainBlocks, "Minimum block height offset during ABCI commit to prune CometBFT blocks")
@@ -34,6 +37,13 @@ func bindRunFlags(cmd *cobra.Command, cfg *halocfg.Config) {
 	flags.StringVar(&cfg.PruningOption, "pruning", cfg.PruningOption, "Pruning strategy (default|nothing|everything)")
 	flags.DurationVar(&cfg.EVMBuildDelay, "evm-build-delay", cfg.EVMBuildDelay, "Minimum delay between triggering and fetching a EVM payload build")
 	flags.BoolVar(&cfg.EVMBuildOptimistic, "evm-build-optimistic", cfg.EVMBuildOptimistic, "Enables optimistic building of EVM payloads on previous block finalize")
+	flags.Uint64Var(&cfg.AttestTableWarnThreshold, "attest-table-warn-threshold", cfg.AttestTableWarnThreshold, "Number of attestation or signature table rows above which a warning is logged (zero disables)")
+	flags.Uint64Var(&cfg.AttestTableCheckInterval, "attest-table-check-interval", cfg.AttestTableCheckInterval, "Interval in blocks of the attestation table size check (zero disables)")
+	flags.BoolVar(&cfg.AttestConcurrentVerify, "attest-concurrent-verify", cfg.AttestConcurrentVerify, "Verify attestation vote signatures concurrently (results are identical to sequential verification)")
+	flags.StringVar(&cfg.AttesterAuditFile, "attester-audit-file", cfg.AttesterAuditFile, "Path of the append-only JSONL audit log of all signed attestations (empty disables)")
+	flags.BoolVar(&cfg.AttesterAuditFsync, "attester-audit-fsync", cfg.AttesterAuditFsync, "Sync the attester audit log to disk after each record")
+	flags.DurationVar(&cfg.AttesterSubmitInterval, "attester-submit-interval", cfg.AttesterSubmitInterval, "Minimum interval between attestation submissions, coalescing votes in between (zero disables)")
+	flags.IntVar(&cfg.AttesterSubmitMaxVotes, "attester-submit-max-votes", cfg.AttesterSubmitMaxVotes, "Maximum number of attestations per submission (zero disables)")
 	flags.IntSliceVar(&cfg.UnsafeSkipUpgrades, sdkserver.FlagUnsafeSkipUpgrades, cfg.UnsafeSkipUpgrades, "Skip a set of upgrade heights to continue the old binary")
 }
 
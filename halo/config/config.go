@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/omni-network/omni/lib/buildinfo"
 	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
 	"github.com/omni-network/omni/lib/log"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tracer"
@@ -35,6 +38,7 @@ const (
 	DefaultHomeDir            = "./halo" // Defaults to "halo" in current directory
 	defaultSnapshotInterval   = 100      // Can't be too large, must overlap with geth snapshotcache.
 	defaultSnapshotKeepRecent = 2
+	defaultSnapshotsEnabled   = true
 	defaultMinRetainBlocks    = 1 // Prune all blocks by default, Cosmsos will still respect other needs like snapshots
 
 	defaultPruningOption      = pruningtypes.PruningOptionDefault // Note that Halo interprets this to be PruningEverything
@@ -42,50 +46,99 @@ const (
 	defaultEVMBuildDelay      = time.Millisecond * 600 // 100ms longer than geth's --miner.recommit=500ms.
 	defaultEVMBuildOptimistic = true
 
+	defaultAttestTableWarnThreshold = 1_000_000 // Attestations are trimmed after +-1 day, so this indicates runaway growth.
+	defaultAttestTableCheckInterval = 1_000     // Counting rows iterates the tables, so don't do it every block.
+	defaultAttestConcurrentVerify   = false
+
 	defaultAPIEnable   = true                 // Halo runs in docker, so enabled via port mapping
 	defaultAPIAddress  = "tcp://0.0.0.0:1317" // Halo runs inside docker
 	defaultGRPCEnable  = true                 // Halo runs in docker, so enabled via port mapping
 	defaultGRPCAddress = "0.0.0.0:9090"       // Halo runs inside docker
 )
 
+// EngineJWTEnv is the environment variable that, if set, provides the hex encoded engine JWT secret,
+// taking precedence over the engine JWT file.
+const EngineJWTEnv = "HALO_ENGINE_JWT"
+
+// metricNameRegex matches valid prometheus metric namespaces and label names.
+var metricNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 // DefaultConfig returns the default halo config.
 func DefaultConfig() Config {
 	return Config{
-		HomeDir:            DefaultHomeDir,
-		Network:            "", // No default
-		EngineEndpoint:     "", // No default
-		EngineJWTFile:      "", // No default
-		SnapshotInterval:   defaultSnapshotInterval,
-		SnapshotKeepRecent: defaultSnapshotKeepRecent,
-		BackendType:        string(defaultDBBackend),
-		MinRetainBlocks:    defaultMinRetainBlocks,
-		PruningOption:      defaultPruningOption,
-		EVMBuildDelay:      defaultEVMBuildDelay,
-		EVMBuildOptimistic: defaultEVMBuildOptimistic,
-		Tracer:             tracer.DefaultConfig(),
-		SDKAPI:             RPCConfig{Enable: defaultAPIEnable, Address: defaultAPIAddress},
-		SDKGRPC:            RPCConfig{Enable: defaultGRPCEnable, Address: defaultGRPCAddress},
+		HomeDir:                  DefaultHomeDir,
+		Network:                  "", // No default
+		EngineEndpoint:           "", // No default
+		EngineJWTFile:            "", // No default
+		SnapshotsEnabled:         defaultSnapshotsEnabled,
+		SnapshotInterval:         defaultSnapshotInterval,
+		SnapshotKeepRecent:       defaultSnapshotKeepRecent,
+		BackendType:              string(defaultDBBackend),
+		MinRetainBlocks:          defaultMinRetainBlocks,
+		PruningOption:            defaultPruningOption,
+		EVMBuildDelay:            defaultEVMBuildDelay,
+		EVMBuildOptimistic:       defaultEVMBuildOptimistic,
+		AttestTableWarnThreshold: defaultAttestTableWarnThreshold,
+		AttestTableCheckInterval: defaultAttestTableCheckInterval,
+		AttestConcurrentVerify:   defaultAttestConcurrentVerify,
+		Tracer:                   tracer.DefaultConfig(),
+		SDKAPI:                   RPCConfig{Enable: defaultAPIEnable, Address: defaultAPIAddress},
+		SDKGRPC:                  RPCConfig{Enable: defaultGRPCEnable, Address: defaultGRPCAddress},
 	}
 }
 
 // Config defines all halo specific config.
 type Config struct {
-	HomeDir            string
-	Network            netconf.ID
-	EngineJWTFile      string
-	EngineEndpoint     string
-	RPCEndpoints       xchain.RPCEndpoints
-	SnapshotInterval   uint64 // See cosmossdk.io/store/snapshots/types/options.go
-	SnapshotKeepRecent uint32 // See cosmossdk.io/store/snapshots/types/options.go
-	BackendType        string // See cosmos-db/db.go
-	MinRetainBlocks    uint64
-	PruningOption      string // See cosmossdk.io/store/pruning/types/options.go
-	EVMBuildDelay      time.Duration
-	EVMBuildOptimistic bool
-	Tracer             tracer.Config
-	UnsafeSkipUpgrades []int
-	SDKAPI             RPCConfig `mapstructure:"api"`
-	SDKGRPC            RPCConfig `mapstructure:"grpc"`
+	HomeDir                  string
+	Network                  netconf.ID
+	EngineJWTFile            string
+	EngineEndpoint           string
+	RPCEndpoints             xchain.RPCEndpoints
+	SnapshotsEnabled         bool   // Disables the snapshot store entirely if false, so the node can't serve state sync.
+	SnapshotInterval         uint64 // See cosmossdk.io/store/snapshots/types/options.go
+	SnapshotKeepRecent       uint32 // See cosmossdk.io/store/snapshots/types/options.go
+	BackendType              string // See cosmos-db/db.go
+	MinRetainBlocks          uint64
+	PruningOption            string // See cosmossdk.io/store/pruning/types/options.go
+	EVMBuildDelay            time.Duration
+	EVMBuildOptimistic       bool
+	AttestTableWarnThreshold uint64 // Zero disables the attest table size check.
+	AttestTableCheckInterval uint64 // In blocks, zero disables the attest table size check.
+	AttestConcurrentVerify   bool   // Verifies attestation vote signatures concurrently.
+	AttesterAuditFile        string // Empty disables the attester audit log.
+	AttesterAuditFsync       bool
+	AttesterSubmitInterval   time.Duration // Zero disables the attester submit interval limit.
+	AttesterSubmitMaxVotes   int           // Zero disables the attester submit batch size limit.
+	Tracer                   tracer.Config
+	UnsafeSkipUpgrades       []int
+	SDKAPI                   RPCConfig             `mapstructure:"api"`
+	SDKGRPC                  RPCConfig             `mapstructure:"grpc"`
+	Instrumentation          InstrumentationConfig `mapstructure:"instrumentation"`
+}
+
+// InstrumentationConfig defines overrides of the CometBFT prometheus metrics.
+type InstrumentationConfig struct {
+	Namespace string            // Overrides the CometBFT metrics namespace if not empty.
+	Labels    map[string]string // Static labels added to all CometBFT metrics.
+}
+
+// Verify returns an error if the namespace or labels would result in invalid prometheus metrics.
+func (c InstrumentationConfig) Verify() error {
+	if c.Namespace != "" && !metricNameRegex.MatchString(c.Namespace) {
+		return errors.New("invalid instrumentation namespace", "namespace", c.Namespace)
+	}
+
+	for key, value := range c.Labels {
+		if !metricNameRegex.MatchString(key) || strings.HasPrefix(key, "__") {
+			return errors.New("invalid instrumentation label key", "key", key)
+		} else if key == "chain_id" {
+			return errors.New("reserved instrumentation label key", "key", key)
+		} else if value == "" || !utf8.ValidString(value) {
+			return errors.New("invalid instrumentation label value", "key", key, "value", value)
+		}
+	}
+
+	return nil
 }
 
 // RPCConfig is an abridged version of CosmosSDK srvconfig.API/GRPCConfig.
@@ -143,12 +196,16 @@ func (c Config) ExecutionGenesisFile() string {
 func (c Config) Verify() error {
 	if c.EngineEndpoint == "" {
 		return errors.New("flag --engine-endpoint is empty")
-	} else if c.EngineJWTFile == "" {
-		return errors.New("flag --engine-jwt-file is empty")
+	} else if c.EngineJWTFile == "" && !ethclient.JWTEnvSet(EngineJWTEnv) {
+		return errors.New("flag --engine-jwt-file is empty and env var " + EngineJWTEnv + " not set")
 	} else if c.Network == "" {
 		return errors.New("flag --network is empty")
 	} else if err := c.Network.Verify(); err != nil {
 		return err
+	} else if err := c.Instrumentation.Verify(); err != nil {
+		return err
+	} else if c.AttesterSubmitInterval < 0 || c.AttesterSubmitMaxVotes < 0 {
+		return errors.New("negative attester submit rate limit")
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 
 	halocfg "github.com/omni-network/omni/halo/config"
 	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tutil"
 
 	"github.com/stretchr/testify/require"
@@ -33,3 +34,51 @@ func TestDefaultConfigReference(t *testing.T) {
 
 	tutil.RequireGoldenBytes(t, b, tutil.WithFilename("default_halo.toml"))
 }
+
+func TestInstrumentationConfigVerify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     halocfg.InstrumentationConfig
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", cfg: halocfg.InstrumentationConfig{Namespace: "omni_cmt", Labels: map[string]string{"network": "omega", "node_id": "val-01"}}},
+		{name: "invalid namespace", cfg: halocfg.InstrumentationConfig{Namespace: "omni-cmt"}, wantErr: true},
+		{name: "invalid label key", cfg: halocfg.InstrumentationConfig{Labels: map[string]string{"1network": "omega"}}, wantErr: true},
+		{name: "reserved label key prefix", cfg: halocfg.InstrumentationConfig{Labels: map[string]string{"__name": "omega"}}, wantErr: true},
+		{name: "duplicate chain_id label", cfg: halocfg.InstrumentationConfig{Labels: map[string]string{"chain_id": "1"}}, wantErr: true},
+		{name: "empty label value", cfg: halocfg.InstrumentationConfig{Labels: map[string]string{"network": ""}}, wantErr: true},
+		{name: "invalid utf8 label value", cfg: halocfg.InstrumentationConfig{Labels: map[string]string{"network": "\xff"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.cfg.Verify()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Environment variables are global.
+func TestVerifyEngineJWT(t *testing.T) {
+	cfg := halocfg.DefaultConfig()
+	cfg.EngineEndpoint = "http://127.0.0.1:8551"
+	cfg.Network = netconf.Simnet
+
+	t.Setenv(halocfg.EngineJWTEnv, "0x0102")
+	require.NoError(t, cfg.Verify())
+
+	// Whitespace-only secrets are ignored by ethclient.LoadJWT, so they aren't considered set.
+	t.Setenv(halocfg.EngineJWTEnv, " \n")
+	require.ErrorContains(t, cfg.Verify(), "flag --engine-jwt-file is empty")
+
+	cfg.EngineJWTFile = "jwt.hex"
+	require.NoError(t, cfg.Verify())
+}
@@ -17,6 +17,15 @@ import (
 // source chain block in strictly sequential order.
 type ProviderCallback func(ctx context.Context, approved xchain.Attestation) error
 
+// AttestCursor durably persists the attest offset of the last approved attestation delivered by a stream,
+// see Provider.StreamApprovedFromCursor.
+type AttestCursor interface {
+	// Load returns the last delivered attest offset or false if none.
+	Load(ctx context.Context) (uint64, bool, error)
+	// Save persists the provided offset as the last delivered attest offset.
+	Save(ctx context.Context, attestOffset uint64) error
+}
+
 // Provider abstracts connecting to the omni consensus chain and streaming approved
 // attestations for each source chain block from a specific height.
 //
@@ -38,6 +47,13 @@ type Provider interface {
 	StreamAttestations(ctx context.Context, chainVer xchain.ChainVersion, attestOffset uint64,
 		workerName string, callback ProviderCallback) error
 
+	// StreamApprovedFromCursor streams approved attestations like StreamAttestations, but resumes from the
+	// provided cursor: it streams from the offset after the cursor's last delivered offset (or from the initial
+	// offset if none), and saves each attestation's offset to the cursor after the callback succeeded.
+	// This enables durable resumption without re-delivery. It returns on the first callback or cursor error.
+	StreamApprovedFromCursor(ctx context.Context, chainVer xchain.ChainVersion, cursor AttestCursor,
+		workerName string, callback ProviderCallback) error
+
 	// AttestationsFrom returns the subsequent approved attestations for the provided source chain
 	// and attestOffset (inclusive). It will return max 100 attestations per call.
 	AttestationsFrom(ctx context.Context, chainVer xchain.ChainVersion, attestOffset uint64) ([]xchain.Attestation, error)
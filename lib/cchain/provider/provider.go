@@ -32,6 +32,9 @@ import (
 
 var _ cchain.Provider = Provider{}
 
+// initialAttestOffset is the first attest offset of all chains.
+const initialAttestOffset uint64 = 1
+
 type fetchFunc func(ctx context.Context, chainVer xchain.ChainVersion, fromOffset uint64) ([]xchain.Attestation, error)
 type allAttsFunc func(ctx context.Context, chainVer xchain.ChainVersion, fromOffset uint64) ([]xchain.Attestation, error)
 type latestFunc func(ctx context.Context, chainVer xchain.ChainVersion) (xchain.Attestation, bool, error)
@@ -159,6 +162,34 @@ func (p Provider) StreamAttestations(
 	return p.stream(ctx, chainVer, attestOffset, workerName, callback, false)
 }
 
+func (p Provider) StreamApprovedFromCursor(
+	ctx context.Context,
+	chainVer xchain.ChainVersion,
+	cursor cchain.AttestCursor,
+	workerName string,
+	callback cchain.ProviderCallback,
+) error {
+	attestOffset := initialAttestOffset
+	if last, ok, err := cursor.Load(ctx); err != nil {
+		return errors.Wrap(err, "load cursor", "worker", workerName)
+	} else if ok {
+		attestOffset = last + 1
+	}
+
+	return p.stream(ctx, chainVer, attestOffset, workerName, func(ctx context.Context, approved xchain.Attestation) error {
+		if err := callback(ctx, approved); err != nil {
+			return err
+		}
+
+		// Note that the attestation is re-delivered on resumption if this fails.
+		if err := cursor.Save(ctx, approved.AttestOffset); err != nil {
+			return errors.Wrap(err, "save cursor")
+		}
+
+		return nil
+	}, false)
+}
+
 func (p Provider) StreamAsync(
 	ctx context.Context,
 	chainVer xchain.ChainVersion,
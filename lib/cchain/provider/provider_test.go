@@ -158,6 +158,73 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestStreamApprovedFromCursor(t *testing.T) {
+	t.Parallel()
+
+	const failOffset = 4
+
+	chainVer := xchain.ChainVersion{ID: 999, ConfLevel: xchain.ConfFinalized}
+
+	// Fetch returns a single attestation per offset.
+	fetch := func(_ context.Context, chainVer xchain.ChainVersion, fromOffset uint64) ([]xchain.Attestation, error) {
+		return []xchain.Attestation{{
+			AttestHeader: xchain.AttestHeader{ChainVersion: chainVer, AttestOffset: fromOffset},
+			BlockHeader:  xchain.BlockHeader{ChainID: chainVer.ID},
+		}}, nil
+	}
+
+	p := provider.NewProviderForT(t, fetch, nil, nil, new(testBackOff).BackOff)
+	cursor := new(memCursor)
+
+	// Stream from the initial offset, until the callback fails.
+	var offsets []uint64
+	err := p.StreamApprovedFromCursor(context.Background(), chainVer, cursor, "test", func(_ context.Context, approved xchain.Attestation) error {
+		if approved.AttestOffset == failOffset {
+			return errors.New("test error")
+		}
+		offsets = append(offsets, approved.AttestOffset)
+
+		return nil
+	})
+	require.ErrorContains(t, err, "test error")
+	require.Equal(t, []uint64{1, 2, 3}, offsets)
+	require.Equal(t, uint64(failOffset-1), cursor.offset)
+
+	// Resume from the cursor without re-delivery.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	offsets = nil
+	err = p.StreamApprovedFromCursor(ctx, chainVer, cursor, "test", func(_ context.Context, approved xchain.Attestation) error {
+		offsets = append(offsets, approved.AttestOffset)
+		if len(offsets) == 3 {
+			cancel()
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4, 5, 6}, offsets)
+	require.Equal(t, uint64(6), cursor.offset)
+}
+
+// memCursor is an in-memory cchain.AttestCursor.
+type memCursor struct {
+	offset uint64
+	ok     bool
+}
+
+func (c *memCursor) Load(context.Context) (uint64, bool, error) {
+	return c.offset, c.ok, nil
+}
+
+func (c *memCursor) Save(_ context.Context, attestOffset uint64) error {
+	c.offset = attestOffset
+	c.ok = true
+
+	return nil
+}
+
 func newTestFetcher(errs, maxCount int) *testFetcher {
 	return &testFetcher{
 		errs:     errs,
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChainError is the error of a single chain of a multi-chain operation.
+type ChainError struct {
+	ChainID uint64
+	Chain   string
+	Err     error
+}
+
+// Error returns the error message prefixed with the chain identifiers and implements the error interface.
+func (e ChainError) Error() string {
+	return fmt.Sprintf("%s (%d): %v", e.Chain, e.ChainID, e.Err)
+}
+
+// Unwrap returns the underlying error and
+// provides compatibility with stdlib errors.
+func (e ChainError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError accumulates the per-chain errors of a multi-chain operation,
+// so all failures are reported at once instead of only the first.
+//
+// Use As to extract the per-chain breakdown from a (wrapped) MultiError.
+// Is and As also match any of the per-chain errors.
+type MultiError struct {
+	Errs []ChainError
+}
+
+// Add adds the chain's error. Nil errors are ignored.
+func (m *MultiError) Add(chainID uint64, chain string, err error) {
+	if err == nil {
+		return
+	}
+
+	m.Errs = append(m.Errs, ChainError{ChainID: chainID, Chain: chain, Err: err})
+}
+
+// ErrOrNil returns the MultiError if any errors were added, or nil otherwise.
+func (m *MultiError) ErrOrNil() error {
+	if len(m.Errs) == 0 {
+		return nil
+	}
+
+	return *m
+}
+
+// Error returns all per-chain error messages and implements the error interface.
+func (m MultiError) Error() string {
+	msgs := make([]string, 0, len(m.Errs))
+	for _, err := range m.Errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf("%d chain errors: [%s]", len(m.Errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the per-chain errors and
+// provides compatibility with stdlib errors.
+func (m MultiError) Unwrap() []error {
+	resp := make([]error, 0, len(m.Errs))
+	for _, err := range m.Errs {
+		resp = append(resp, err)
+	}
+
+	return resp
+}
@@ -0,0 +1,43 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiError(t *testing.T) {
+	t.Parallel()
+
+	var multi errors.MultiError
+	require.NoError(t, multi.ErrOrNil())
+
+	multi.Add(1, "chain_a", nil) // Ignored
+	require.NoError(t, multi.ErrOrNil())
+
+	errB := errors.New("dial failed", "rpc", "http://b")
+	multi.Add(2, "chain_b", errB)
+	multi.Add(3, "chain_c", errors.Wrap(io.EOF, "read"))
+
+	err := errors.Wrap(multi.ErrOrNil(), "new xprovider")
+	require.Equal(t, "new xprovider: 2 chain errors: [chain_b (2): dial failed; chain_c (3): read: EOF]", err.Error())
+
+	// Per-chain errors are matched.
+	require.True(t, errors.Is(err, errB))
+	require.True(t, errors.Is(err, io.EOF))
+
+	// Per-chain breakdown is extracted.
+	var actual errors.MultiError
+	require.True(t, errors.As(err, &actual))
+	require.Len(t, actual.Errs, 2)
+	require.Equal(t, uint64(2), actual.Errs[0].ChainID)
+	require.Equal(t, "chain_b", actual.Errs[0].Chain)
+	require.Equal(t, uint64(3), actual.Errs[1].ChainID)
+
+	var chainErr errors.ChainError
+	require.True(t, errors.As(err, &chainErr))
+	require.Equal(t, "chain_b", chainErr.Chain)
+}
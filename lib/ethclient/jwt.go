@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/omni-network/omni/lib/errors"
@@ -44,6 +45,27 @@ func (t *jwtRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// LoadJWT loads a hex encoded JWT secret from the provided environment variable if set and non-empty,
+// otherwise from the provided file. This supports secret-managed deployments that inject the secret via
+// the environment, while still supporting a fixed file path.
+func LoadJWT(envVar string, file string) ([]byte, error) {
+	if !JWTEnvSet(envVar) {
+		return LoadJWTHexFile(file)
+	}
+
+	jwtBytes, err := decodeJWTHex([]byte(os.Getenv(envVar)))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode jwt env var", "env_var", envVar)
+	}
+
+	return jwtBytes, nil
+}
+
+// JWTEnvSet returns true if the JWT secret environment variable is set and not only whitespace, see LoadJWT.
+func JWTEnvSet(envVar string) bool {
+	return strings.TrimSpace(os.Getenv(envVar)) != ""
+}
+
 // LoadJWTHexFile loads a hex encoded JWT secret from the provided file.
 func LoadJWTHexFile(file string) ([]byte, error) {
 	jwtHex, err := os.ReadFile(file)
@@ -51,12 +73,24 @@ func LoadJWTHexFile(file string) ([]byte, error) {
 		return nil, errors.Wrap(err, "read jwt file")
 	}
 
+	jwtBytes, err := decodeJWTHex(jwtHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode jwt file")
+	}
+
+	return jwtBytes, nil
+}
+
+// decodeJWTHex returns the decoded hex encoded JWT secret, ignoring surrounding whitespace and an optional 0x prefix.
+func decodeJWTHex(jwtHex []byte) ([]byte, error) {
 	jwtHex = bytes.TrimSpace(jwtHex)
 	jwtHex = bytes.TrimPrefix(jwtHex, []byte("0x"))
 
 	jwtBytes, err := hex.DecodeString(string(jwtHex))
 	if err != nil {
-		return nil, errors.Wrap(err, "decode jwt file")
+		return nil, errors.Wrap(err, "decode hex")
+	} else if len(jwtBytes) == 0 {
+		return nil, errors.New("empty jwt secret")
 	}
 
 	return jwtBytes, nil
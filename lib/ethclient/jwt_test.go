@@ -0,0 +1,49 @@
+package ethclient_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/omni-network/omni/lib/ethclient"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Setenv modifies global state.
+func TestLoadJWT(t *testing.T) {
+	const envVar = "TEST_ETHCLIENT_JWT"
+
+	file := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(file, []byte("0x0102\n"), 0o600))
+
+	tests := []struct {
+		name   string
+		env    string
+		file   string
+		want   []byte
+		errStr string
+	}{
+		{name: "file fallback", file: file, want: []byte{0x01, 0x02}},
+		{name: "empty env fallback", env: " ", file: file, want: []byte{0x01, 0x02}},
+		{name: "env precedence", env: "0x0a0b", file: file, want: []byte{0x0a, 0x0b}},
+		{name: "env without file", env: " 0a0b\n", want: []byte{0x0a, 0x0b}},
+		{name: "invalid env hex", env: "0xzz", file: file, errStr: "decode jwt env var"},
+		{name: "missing file", file: filepath.Join(t.TempDir(), "missing"), errStr: "read jwt file"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(envVar, test.env)
+
+			got, err := ethclient.LoadJWT(envVar, test.file)
+			if test.errStr != "" {
+				require.ErrorContains(t, err, test.errStr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package expbackoff
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the passing of time for backoff functions, see WithClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time after the duration elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// WithClock configures the backoff with the provided clock instead of real time.
+// It allows deterministic tests of retry behavior, see NewVirtualClock.
+// It may be applied before or after With, since With retains the clock.
+func WithClock(clock Clock) func(*Config) {
+	return func(config *Config) {
+		config.clock = clock
+	}
+}
+
+// VirtualClock is a Clock that advances virtual time instantly when sleeping.
+// It records all sleep durations, allowing tests to assert the exact retry schedule.
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+// NewVirtualClock returns a new virtual clock starting at the provided time.
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After advances the virtual time by the duration and returns a channel that already contains the new time.
+func (c *VirtualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+// Advance advances the virtual time by the duration without recording a sleep.
+// It simulates time passing between backoffs, e.g. for NewWithAutoReset.
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Sleeps returns a copy of all the recorded sleep durations.
+func (c *VirtualClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// afterFunc returns the after function of the config's clock or the (test-aliased) real time.
+func (c Config) afterFunc() func(time.Duration) <-chan time.Time {
+	if c.clock != nil {
+		return c.clock.After
+	}
+
+	return after
+}
+
+// nowFunc returns the now function of the config's clock or real time.
+func (c Config) nowFunc() func() time.Time {
+	if c.clock != nil {
+		return c.clock.Now
+	}
+
+	return time.Now
+}
@@ -28,6 +28,9 @@ type Config struct {
 	Jitter float64
 	// MaxDelay is the upper bound of backoff delay.
 	MaxDelay time.Duration
+
+	// clock overrides real time if not nil, see WithClock.
+	clock Clock
 }
 
 // DefaultConfig is a backoff configuration with the default values specified
@@ -61,15 +64,18 @@ func WithPeriodicConfig(period time.Duration) func(*Config) {
 }
 
 // WithFastConfig configures the backoff with FastConfig.
+// It retains the clock if already configured, see WithClock.
 func WithFastConfig() func(*Config) {
-	return func(config *Config) {
-		*config = FastConfig
-	}
+	return With(FastConfig)
 }
 
 // With configures the backoff with the provided config.
+// It retains the clock if already configured and not provided, see WithClock.
 func With(c Config) func(*Config) {
 	return func(config *Config) {
+		if c.clock == nil {
+			c.clock = config.clock
+		}
 		*config = c
 	}
 }
@@ -132,7 +138,7 @@ func NewWithReset(ctx context.Context, opts ...func(*Config)) (backoff func(), r
 
 		select {
 		case <-ctx.Done():
-		case <-after(Backoff(conf, retries)):
+		case <-conf.afterFunc()(Backoff(conf, retries)):
 		}
 		retries++
 	}
@@ -159,24 +165,25 @@ func NewWithAutoReset(ctx context.Context, opts ...func(*Config)) (backoff func(
 	}
 
 	var retries int
+	now := conf.nowFunc()
 
-	lastBackoff := time.Now()
+	lastBackoff := now()
 	backoff = func() {
 		if ctx.Err() != nil {
 			return
 		}
 
 		autoResetAt := lastBackoff.Add(Backoff(conf, retries))
-		if time.Now().After(autoResetAt) {
+		if now().After(autoResetAt) {
 			retries = 0
 		}
 
 		select {
 		case <-ctx.Done():
-		case <-after(Backoff(conf, retries)):
+		case <-conf.afterFunc()(Backoff(conf, retries)):
 		}
 		retries++
-		lastBackoff = time.Now()
+		lastBackoff = now()
 	}
 
 	return backoff
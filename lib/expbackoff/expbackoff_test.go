@@ -135,3 +135,69 @@ func TestNewWithReset(t *testing.T) {
 	backoff()
 	elapsed(t, "16s") // +0s
 }
+
+func TestVirtualClock(t *testing.T) {
+	t0 := time.Now()
+	clock := expbackoff.NewVirtualClock(t0)
+	conf := expbackoff.Config{
+		BaseDelay:  time.Second,
+		Multiplier: 2,
+		Jitter:     0,
+		MaxDelay:   time.Second * 5,
+	}
+
+	t.Run("reset", func(t *testing.T) {
+		backoff, reset := expbackoff.NewWithReset(context.Background(),
+			expbackoff.With(conf), expbackoff.WithClock(clock))
+
+		for i := 0; i < 5; i++ {
+			backoff()
+		}
+		reset()
+		backoff()
+
+		require.Equal(t, []time.Duration{
+			time.Second,
+			time.Second * 2,
+			time.Second * 4,
+			time.Second * 5,
+			time.Second * 5,
+			time.Second,
+		}, clock.Sleeps())
+		require.Equal(t, time.Second*18, clock.Now().Sub(t0))
+	})
+
+	t.Run("auto reset", func(t *testing.T) {
+		clock := expbackoff.NewVirtualClock(t0)
+		backoff := expbackoff.NewWithAutoReset(context.Background(),
+			expbackoff.With(conf), expbackoff.WithClock(clock))
+
+		backoff()
+		backoff()
+		clock.Advance(time.Second * 3) // Less than next backoff (4s)
+		backoff()
+		clock.Advance(time.Second * 10) // More than next backoff (5s)
+		backoff()
+
+		require.Equal(t, []time.Duration{
+			time.Second,
+			time.Second * 2,
+			time.Second * 4,
+			time.Second,
+		}, clock.Sleeps())
+	})
+
+	t.Run("clock before config", func(t *testing.T) {
+		clock := expbackoff.NewVirtualClock(t0)
+		backoff := expbackoff.New(context.Background(),
+			expbackoff.WithClock(clock), expbackoff.With(conf))
+
+		backoff()
+		backoff()
+
+		require.Equal(t, []time.Duration{
+			time.Second,
+			time.Second * 2,
+		}, clock.Sleeps())
+	})
+}
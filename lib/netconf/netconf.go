@@ -5,6 +5,8 @@ package netconf
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/omni-network/omni/lib/errors"
@@ -281,6 +283,8 @@ type Chain struct {
 	Name           string           // Chain name as per https://chainlist.org
 	PortalAddress  common.Address   // Address of the omni portal contract on the chain
 	DeployHeight   uint64           // Height that the portal contracts were deployed
+	StartHeight    uint64           // Optional height to start streaming from, zero defaults to DeployHeight
+	FinalityTag    string           // Optional finalized head resolution, see ParseFinalityTag, empty defaults to "finalized"
 	BlockPeriod    time.Duration    // Block period of the chain
 	Shards         []xchain.ShardID // Supported xmsg shards
 	AttestInterval uint64           // Attest to every Nth block, even if empty.
@@ -348,5 +352,46 @@ func (c Chain) Verify() error {
 		return errors.New("empty shards")
 	}
 
+	if c.StartHeight != 0 && c.StartHeight < c.DeployHeight {
+		return errors.New("start height below deploy height", "start", c.StartHeight, "deploy", c.DeployHeight)
+	}
+
+	if _, _, err := ParseFinalityTag(c.FinalityTag); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+const (
+	// FinalityFinalized resolves finalized heads via the "finalized" block tag, this is the default.
+	FinalityFinalized = "finalized"
+	// FinalitySafe resolves finalized heads via the "safe" block tag.
+	FinalitySafe = "safe"
+	// FinalityConfDepthPrefix prefixes finality tags that resolve finalized heads
+	// as the latest head minus a confirmation depth, e.g. "confdepth:64".
+	FinalityConfDepthPrefix = "confdepth:"
+)
+
+// ParseFinalityTag returns the block tag ("finalized" or "safe") or the confirmation depth
+// that resolves the finalized head of a chain as per its finality tag.
+// Exactly one of the block tag or the depth is non-zero. The empty tag defaults to "finalized".
+func ParseFinalityTag(tag string) (string, uint64, error) {
+	switch {
+	case tag == "":
+		return FinalityFinalized, 0, nil
+	case tag == FinalityFinalized, tag == FinalitySafe:
+		return tag, 0, nil
+	case strings.HasPrefix(tag, FinalityConfDepthPrefix):
+		depth, err := strconv.ParseUint(strings.TrimPrefix(tag, FinalityConfDepthPrefix), 10, 64)
+		if err != nil {
+			return "", 0, errors.Wrap(err, "parse finality tag confirmation depth", "tag", tag)
+		} else if depth == 0 {
+			return "", 0, errors.New("zero finality tag confirmation depth", "tag", tag)
+		}
+
+		return "", depth, nil
+	default:
+		return "", 0, errors.New("invalid finality tag", "tag", tag)
+	}
+}
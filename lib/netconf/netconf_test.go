@@ -227,6 +227,58 @@ func TestConfLevels(t *testing.T) {
 	require.EqualValues(t, []xchain.ConfLevel{xchain.ConfLatest, xchain.ConfFinalized}, chain.ConfLevels())
 }
 
+func TestStartHeight(t *testing.T) {
+	t.Parallel()
+
+	chain := netconf.Chain{
+		ID:           1,
+		Name:         "test",
+		Shards:       []xchain.ShardID{xchain.ShardFinalized0},
+		DeployHeight: 100,
+	}
+	require.NoError(t, chain.Verify())
+
+	chain.StartHeight = 100
+	require.NoError(t, chain.Verify())
+
+	chain.StartHeight = 99
+	require.ErrorContains(t, chain.Verify(), "start height below deploy height")
+}
+
+func TestParseFinalityTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag    string
+		head   string
+		depth  uint64
+		errStr string
+	}{
+		{tag: "", head: "finalized"},
+		{tag: "finalized", head: "finalized"},
+		{tag: "safe", head: "safe"},
+		{tag: "confdepth:64", depth: 64},
+		{tag: "confdepth:0", errStr: "zero finality tag confirmation depth"},
+		{tag: "confdepth:-1", errStr: "parse finality tag confirmation depth"},
+		{tag: "latest", errStr: "invalid finality tag"},
+	}
+
+	for _, test := range tests {
+		head, depth, err := netconf.ParseFinalityTag(test.tag)
+		if test.errStr != "" {
+			require.ErrorContains(t, err, test.errStr, test.tag)
+			continue
+		}
+
+		require.NoError(t, err, test.tag)
+		require.Equal(t, test.head, head, test.tag)
+		require.Equal(t, test.depth, depth, test.tag)
+	}
+
+	chain := netconf.Chain{ID: 1, Name: "test", Shards: []xchain.ShardID{xchain.ShardFinalized0}, FinalityTag: "unknown"}
+	require.ErrorContains(t, chain.Verify(), "invalid finality tag")
+}
+
 func TestAddrs(t *testing.T) {
 	t.Parallel()
 
@@ -140,6 +140,30 @@ func SubmissionFromBinding(sub bindings.XSubmission, destChainID uint64) Submiss
 	}
 }
 
+// EncodeSubmission returns the destination chain portal xsubmit call data of the submission.
+// Only EVM destination chains are supported, since submissions are not sent to the consensus chain.
+func EncodeSubmission(sub Submission) ([]byte, error) {
+	if sub.DestChainID == 0 {
+		return nil, errors.New("missing submission destination chain")
+	} else if sub.DestChainID == sub.AttHeader.ConsensusChainID {
+		return nil, errors.New("consensus chain submissions not supported", "chain", sub.DestChainID)
+	}
+
+	return EncodeXSubmit(SubmissionToBinding(sub))
+}
+
+// DecodeSubmission returns the submission decoded from the xsubmit call data sent to the destination chain.
+// It is the inverse of EncodeSubmission, except for fields not included in the call data,
+// i.e., the block height, and the msgs' source chain IDs, tx hashes, and fees.
+func DecodeSubmission(txCallData []byte, destChainID uint64) (Submission, error) {
+	xsub, err := DecodeXSubmit(txCallData)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	return SubmissionFromBinding(xsub, destChainID), nil
+}
+
 // SubmissionToBinding converts a go xchain submission to a solidity binding submission.
 func SubmissionToBinding(sub Submission) bindings.XSubmission {
 	// Sort the signatures by validator address to ensure deterministic ordering.
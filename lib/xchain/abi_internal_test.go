@@ -82,6 +82,38 @@ func TestSubmissionToFromBinding(t *testing.T) {
 	require.Equal(t, sub, reversedSub)
 }
 
+func TestEncodeDecodeSubmission(t *testing.T) {
+	t.Parallel()
+	var sub Submission
+	fuzz.New().NilChance(0).Fuzz(&sub)
+	sub.AttHeader.ChainVersion.ID = sub.BlockHeader.ChainID // Align headers
+	sub.DestChainID = sub.AttHeader.ConsensusChainID + 1
+
+	calldata, err := EncodeSubmission(sub)
+	require.NoError(t, err)
+
+	decoded, err := DecodeSubmission(calldata, sub.DestChainID)
+	require.NoError(t, err)
+
+	// Zero fields not included in the call data.
+	for i := range sub.Msgs {
+		sub.Msgs[i].TxHash = common.Hash{}
+		sub.Msgs[i].SourceChainID = 0
+		sub.Msgs[i].Fees = nil
+	}
+	sub.BlockHeader.BlockHeight = 0
+	require.Equal(t, sub, decoded)
+
+	// Consensus chain and missing destinations are not supported.
+	sub.DestChainID = sub.AttHeader.ConsensusChainID
+	_, err = EncodeSubmission(sub)
+	require.ErrorContains(t, err, "consensus chain")
+
+	sub.DestChainID = 0
+	_, err = EncodeSubmission(sub)
+	require.ErrorContains(t, err, "missing")
+}
+
 func TestXSubmitEncodeDecode(t *testing.T) {
 	t.Parallel()
 	var sub bindings.XSubmission
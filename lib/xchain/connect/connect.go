@@ -100,7 +100,10 @@ func New(ctx context.Context, netID netconf.ID, endpoints xchain.RPCEndpoints) (
 
 	cprov := cprovider.NewABCIProvider(cometCl, netID, netconf.ChainVersionNamer(netID))
 
-	xprov := xprovider.New(network, ethClients, cprov)
+	xprov, err := xprovider.New(network, ethClients, cprov)
+	if err != nil {
+		return Connector{}, errors.Wrap(err, "create xprovider")
+	}
 
 	return Connector{
 		Network:    network,
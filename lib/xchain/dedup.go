@@ -0,0 +1,75 @@
+package xchain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UniqueID returns a canonical stable identifier of the message derived from its MsgID
+// and all its fields. Unlike MsgID.Hash, messages re-emitted with different contents
+// at the same stream offset (e.g. due to a reorg) have different unique IDs.
+func (m Msg) UniqueID() common.Hash {
+	h := sha256.New()
+	write := func(bz []byte) {
+		_ = binary.Write(h, binary.BigEndian, uint64(len(bz))) // Length prefix ensures unambiguous encoding.
+		_, _ = h.Write(bz)
+	}
+
+	msgIDHash := m.MsgID.Hash()
+	write(msgIDHash[:])
+	write(m.SourceMsgSender[:])
+	write(m.DestAddress[:])
+	write(m.Data)
+	write(binary.BigEndian.AppendUint64(nil, m.DestGasLimit))
+	write(m.TxHash[:])
+	if m.Fees != nil {
+		write(m.Fees.Bytes())
+	} else {
+		write(nil)
+	}
+
+	return common.Hash(h.Sum(nil))
+}
+
+// Deduper detects duplicate messages delivered to at-least-once consumers, see Msg.UniqueID.
+// It has bounded memory, remembering only the most recent messages. It is thread safe.
+type Deduper struct {
+	size int
+
+	mu    sync.Mutex
+	seen  map[common.Hash]bool
+	order []common.Hash // FIFO of seen IDs, oldest first
+}
+
+// NewDeduper returns a new deduper remembering the provided number of most recent messages.
+func NewDeduper(size int) *Deduper {
+	return &Deduper{
+		size: max(size, 1),
+		seen: make(map[common.Hash]bool),
+	}
+}
+
+// Duplicate returns true if the message was seen before, else it records the message and returns false.
+func (d *Deduper) Duplicate(msg Msg) bool {
+	id := msg.UniqueID()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.seen[id] {
+		return true
+	}
+
+	if len(d.order) >= d.size {
+		delete(d.seen, d.order[0])
+		d.order = d.order[1:]
+	}
+
+	d.seen[id] = true
+	d.order = append(d.order, id)
+
+	return false
+}
@@ -0,0 +1,62 @@
+package xchain_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgUniqueID(t *testing.T) {
+	t.Parallel()
+
+	var msg xchain.Msg
+	fuzz.New().NilChance(0).Fuzz(&msg.MsgID)
+	msg.Data = []byte("data")
+	msg.Fees = big.NewInt(1)
+
+	// Stable
+	require.Equal(t, msg.UniqueID(), msg.UniqueID())
+
+	// All fields affect the ID.
+	mutations := []func(*xchain.Msg){
+		func(m *xchain.Msg) { m.StreamOffset++ },
+		func(m *xchain.Msg) { m.SourceChainID++ },
+		func(m *xchain.Msg) { m.SourceMsgSender = common.Address{1} },
+		func(m *xchain.Msg) { m.DestAddress = common.Address{1} },
+		func(m *xchain.Msg) { m.Data = []byte("other") },
+		func(m *xchain.Msg) { m.DestGasLimit++ },
+		func(m *xchain.Msg) { m.TxHash = common.Hash{1} },
+		func(m *xchain.Msg) { m.Fees = big.NewInt(2) },
+		func(m *xchain.Msg) { m.Fees = nil },
+	}
+	for i, mutate := range mutations {
+		mutated := msg
+		mutate(&mutated)
+		require.NotEqual(t, msg.UniqueID(), mutated.UniqueID(), "mutation %d", i)
+	}
+}
+
+func TestDeduper(t *testing.T) {
+	t.Parallel()
+
+	msg := func(offset uint64) xchain.Msg {
+		return xchain.Msg{MsgID: xchain.MsgID{StreamOffset: offset}}
+	}
+
+	d := xchain.NewDeduper(2)
+	require.False(t, d.Duplicate(msg(1)))
+	require.True(t, d.Duplicate(msg(1)))
+	require.False(t, d.Duplicate(msg(2)))
+	require.True(t, d.Duplicate(msg(1)))
+
+	// Oldest is evicted
+	require.False(t, d.Duplicate(msg(3)))
+	require.False(t, d.Duplicate(msg(1)))
+	require.True(t, d.Duplicate(msg(3)))
+}
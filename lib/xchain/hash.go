@@ -0,0 +1,182 @@
+package xchain
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// blockHashVersion is the version of the canonical block hash encoding.
+// It must be incremented if the encoding changes, which should be avoided since hashes are compared across versions.
+const blockHashVersion uint8 = 1
+
+// hashBlock defines the canonical encoding of a Block used by Block.Hash.
+type hashBlock struct {
+	Version     uint8
+	ChainID     uint64
+	BlockHeight uint64
+	BlockHash   common.Hash
+	ParentHash  common.Hash
+	Timestamp   int64
+	Msgs        []hashMsg
+	Receipts    []hashReceipt
+}
+
+type hashMsg struct {
+	SourceChainID   uint64
+	DestChainID     uint64
+	ShardID         uint64
+	StreamOffset    uint64
+	SourceMsgSender common.Address
+	DestAddress     common.Address
+	Data            []byte
+	DestGasLimit    uint64
+	TxHash          common.Hash
+	Fees            *big.Int
+}
+
+type hashReceipt struct {
+	SourceChainID  uint64
+	DestChainID    uint64
+	ShardID        uint64
+	StreamOffset   uint64
+	GasUsed        uint64
+	Success        bool
+	Error          []byte
+	RelayerAddress common.Address
+	TxHash         common.Hash
+}
+
+//nolint:gochecknoglobals // Static ABI types
+var hashBlockABI = mustABITuple([]abi.ArgumentMarshaling{
+	{Name: "Version", Type: typUint8},
+	{Name: "ChainID", Type: typUint64},
+	{Name: "BlockHeight", Type: typUint64},
+	{Name: "BlockHash", Type: typBytes32},
+	{Name: "ParentHash", Type: typBytes32},
+	{Name: "Timestamp", Type: "int64"},
+	{Name: "Msgs", Type: "tuple[]", Components: []abi.ArgumentMarshaling{
+		{Name: "SourceChainID", Type: typUint64},
+		{Name: "DestChainID", Type: typUint64},
+		{Name: "ShardID", Type: typUint64},
+		{Name: "StreamOffset", Type: typUint64},
+		{Name: "SourceMsgSender", Type: typAddress},
+		{Name: "DestAddress", Type: typAddress},
+		{Name: "Data", Type: typBytes},
+		{Name: "DestGasLimit", Type: typUint64},
+		{Name: "TxHash", Type: typBytes32},
+		{Name: "Fees", Type: "uint256"},
+	}},
+	{Name: "Receipts", Type: "tuple[]", Components: []abi.ArgumentMarshaling{
+		{Name: "SourceChainID", Type: typUint64},
+		{Name: "DestChainID", Type: typUint64},
+		{Name: "ShardID", Type: typUint64},
+		{Name: "StreamOffset", Type: typUint64},
+		{Name: "GasUsed", Type: typUint64},
+		{Name: "Success", Type: "bool"},
+		{Name: "Error", Type: typBytes},
+		{Name: "RelayerAddress", Type: typAddress},
+		{Name: "TxHash", Type: typBytes32},
+	}},
+})
+
+// Hash returns the canonical hash of the block, used to compare and verify blocks
+// constructed by different providers or at different times.
+//
+// It is the keccak256 hash of the ABI encoding of a versioned tuple of all the block's fields.
+// Msgs and receipts are sorted by MsgID (source chain, dest chain, shard, offset), so their order
+// in the block doesn't affect the hash. The timestamp is encoded as unix seconds and nil fees as zero.
+// The format is locked by test vectors and must remain stable across versions.
+func (b Block) Hash() (common.Hash, error) {
+	bz, err := encodeHashBlock(b)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(bz), nil
+}
+
+// encodeHashBlock ABI encodes the canonical representation of the block.
+func encodeHashBlock(b Block) ([]byte, error) {
+	msgs := make([]hashMsg, 0, len(b.Msgs))
+	for _, msg := range b.Msgs {
+		fees := msg.Fees
+		if fees == nil {
+			fees = new(big.Int)
+		}
+
+		msgs = append(msgs, hashMsg{
+			SourceChainID:   msg.SourceChainID,
+			DestChainID:     msg.DestChainID,
+			ShardID:         uint64(msg.ShardID),
+			StreamOffset:    msg.StreamOffset,
+			SourceMsgSender: msg.SourceMsgSender,
+			DestAddress:     msg.DestAddress,
+			Data:            msg.Data,
+			DestGasLimit:    msg.DestGasLimit,
+			TxHash:          msg.TxHash,
+			Fees:            fees,
+		})
+	}
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return lessMsgID(msgs[i].msgID(), msgs[j].msgID())
+	})
+
+	receipts := make([]hashReceipt, 0, len(b.Receipts))
+	for _, receipt := range b.Receipts {
+		receipts = append(receipts, hashReceipt{
+			SourceChainID:  receipt.SourceChainID,
+			DestChainID:    receipt.DestChainID,
+			ShardID:        uint64(receipt.ShardID),
+			StreamOffset:   receipt.StreamOffset,
+			GasUsed:        receipt.GasUsed,
+			Success:        receipt.Success,
+			Error:          receipt.Error,
+			RelayerAddress: receipt.RelayerAddress,
+			TxHash:         receipt.TxHash,
+		})
+	}
+	sort.SliceStable(receipts, func(i, j int) bool {
+		return lessMsgID(receipts[i].msgID(), receipts[j].msgID())
+	})
+
+	resp, err := hashBlockABI.Pack(hashBlock{
+		Version:     blockHashVersion,
+		ChainID:     b.ChainID,
+		BlockHeight: b.BlockHeight,
+		BlockHash:   b.BlockHash,
+		ParentHash:  b.ParentHash,
+		Timestamp:   b.Timestamp.Unix(),
+		Msgs:        msgs,
+		Receipts:    receipts,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "pack xchain block")
+	}
+
+	return resp, nil
+}
+
+func (m hashMsg) msgID() [4]uint64 {
+	return [4]uint64{m.SourceChainID, m.DestChainID, m.ShardID, m.StreamOffset}
+}
+
+func (r hashReceipt) msgID() [4]uint64 {
+	return [4]uint64{r.SourceChainID, r.DestChainID, r.ShardID, r.StreamOffset}
+}
+
+// lessMsgID returns true if msg ID a sorts before b.
+func lessMsgID(a, b [4]uint64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return false
+}
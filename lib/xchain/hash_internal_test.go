@@ -0,0 +1,127 @@
+package xchain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/tutil"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHash(t *testing.T) {
+	t.Parallel()
+
+	msg := func(offset uint64) Msg {
+		return Msg{
+			MsgID: MsgID{
+				StreamID:     StreamID{SourceChainID: 1, DestChainID: 2, ShardID: ShardFinalized0},
+				StreamOffset: offset,
+			},
+			SourceMsgSender: common.HexToAddress("0xcbbc5Da52ea2728279560Dca8f4ec08d5F829985"),
+			DestAddress:     common.HexToAddress("0x9CC971e84FE5d09d0967f15AE05dfd553C5A1FA6"),
+			Data:            common.Hex2Bytes("d09de08a"),
+			DestGasLimit:    250_000,
+			TxHash:          common.HexToHash("0x01"),
+			Fees:            big.NewInt(1e9),
+		}
+	}
+
+	receipt := func(offset uint64) Receipt {
+		return Receipt{
+			MsgID: MsgID{
+				StreamID:     StreamID{SourceChainID: 3, DestChainID: 1, ShardID: ShardLatest0},
+				StreamOffset: offset,
+			},
+			GasUsed:        100_000,
+			Success:        offset%2 == 0,
+			Error:          common.Hex2Bytes("dead"),
+			RelayerAddress: common.HexToAddress("0x9CC971e84FE5d09d0967f15AE05dfd553C5A1FA6"),
+			TxHash:         common.HexToHash("0x02"),
+		}
+	}
+
+	header := BlockHeader{
+		ChainID:     1,
+		BlockHeight: 99,
+		BlockHash:   common.HexToHash("0x412d62a6a3115ab5a0e0cae9d63082ff8dfb002a98cc889d06dc986a9461586b"),
+	}
+	parent := common.HexToHash("0x4f8d1e3de5b6b9a2b8a6e7e6b2d9c1c5c4f5e9a1b2c3d4e5f6a7b8c9d0e1f2a3")
+	timestamp := time.Unix(1_700_000_000, 0)
+
+	vectors := map[string]Block{
+		"empty": {},
+		"header": {
+			BlockHeader: header,
+			ParentHash:  parent,
+			Timestamp:   timestamp,
+		},
+		"full": {
+			BlockHeader: header,
+			Msgs:        []Msg{msg(1), msg(2)},
+			Receipts:    []Receipt{receipt(7), receipt(8)},
+			ParentHash:  parent,
+			Timestamp:   timestamp,
+		},
+	}
+
+	hashes := make(map[string]common.Hash)
+	for name, block := range vectors {
+		hash, err := block.Hash()
+		require.NoError(t, err)
+		hashes[name] = hash
+	}
+
+	// Lock the hash format.
+	tutil.RequireGoldenJSON(t, hashes)
+
+	full := vectors["full"]
+
+	t.Run("order independent", func(t *testing.T) {
+		t.Parallel()
+		reordered := full
+		reordered.Msgs = []Msg{msg(2), msg(1)}
+		reordered.Receipts = []Receipt{receipt(8), receipt(7)}
+
+		hash, err := reordered.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hashes["full"], hash)
+	})
+
+	t.Run("nil fees", func(t *testing.T) {
+		t.Parallel()
+		zero := msg(1)
+		zero.Fees = big.NewInt(0)
+		nilFees := msg(1)
+		nilFees.Fees = nil
+
+		h1, err := Block{Msgs: []Msg{zero}}.Hash()
+		require.NoError(t, err)
+		h2, err := Block{Msgs: []Msg{nilFees}}.Hash()
+		require.NoError(t, err)
+		require.Equal(t, h1, h2)
+	})
+
+	t.Run("field changes", func(t *testing.T) {
+		t.Parallel()
+		mutations := map[string]func(*Block){
+			"height":    func(b *Block) { b.BlockHeight++ },
+			"hash":      func(b *Block) { b.BlockHash[0]++ },
+			"parent":    func(b *Block) { b.ParentHash[0]++ },
+			"timestamp": func(b *Block) { b.Timestamp = b.Timestamp.Add(time.Second) },
+			"msg data":  func(b *Block) { b.Msgs = []Msg{msg(1), msg(3)} },
+			"receipt":   func(b *Block) { b.Receipts = []Receipt{receipt(7)} },
+		}
+
+		for name, mutate := range mutations {
+			b := full
+			mutate(&b)
+			hash, err := b.Hash()
+			require.NoError(t, err)
+			require.NotEqual(t, hashes["full"], hash, name)
+		}
+	})
+}
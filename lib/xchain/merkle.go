@@ -82,6 +82,20 @@ func NewMsgTree(msgs []Msg) (MsgTree, error) {
 	}, nil
 }
 
+// BlockMsgRoot returns the message root of the block as attested to, or the zero hash if the block has no messages.
+func BlockMsgRoot(block Block) (common.Hash, error) {
+	if len(block.Msgs) == 0 {
+		return common.Hash{}, nil
+	}
+
+	tree, err := NewMsgTree(block.Msgs)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return tree.MsgRoot(), nil
+}
+
 func msgLeaf(msg Msg) ([32]byte, error) {
 	bz, err := encodeMsg(msg)
 	if err != nil {
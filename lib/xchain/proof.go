@@ -0,0 +1,59 @@
+package xchain
+
+import (
+	"bytes"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// ReceiptProof is a receipt-trie inclusion proof of the source chain transaction receipt that emitted a message.
+// It is self-contained, allowing trust-minimized consumers to verify message inclusion against the block hash
+// without re-querying the source chain.
+type ReceiptProof struct {
+	Header  []byte   // RLP encoded source chain block header, its hash is the block hash
+	TxIndex uint64   // Index of the transaction in the block, i.e., the trie key
+	Receipt []byte   // Consensus (binary) encoded receipt, i.e., the trie value
+	Nodes   [][]byte // RLP encoded trie nodes from the receipts root to the receipt
+}
+
+// Verify returns the decoded receipt if the proof is valid for the provided block hash, or an error.
+// It verifies that the header hashes to the block hash and that the receipt is included in the header's receipts root.
+// Note that callers should also verify that the receipt contains the expected message log.
+func (p ReceiptProof) Verify(blockHash common.Hash) (*types.Receipt, error) {
+	if crypto.Keccak256Hash(p.Header) != blockHash {
+		return nil, errors.New("proof header hash mismatch", "block_hash", blockHash)
+	}
+
+	var header types.Header
+	if err := rlp.DecodeBytes(p.Header, &header); err != nil {
+		return nil, errors.Wrap(err, "decode proof header")
+	}
+
+	nodes := memorydb.New()
+	for _, node := range p.Nodes {
+		if err := nodes.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, errors.Wrap(err, "put proof node")
+		}
+	}
+
+	value, err := trie.VerifyProof(header.ReceiptHash, rlp.AppendUint64(nil, p.TxIndex), nodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "verify receipt proof")
+	} else if !bytes.Equal(value, p.Receipt) {
+		return nil, errors.New("proof receipt mismatch", "tx_index", p.TxIndex)
+	}
+
+	receipt := new(types.Receipt)
+	if err := receipt.UnmarshalBinary(p.Receipt); err != nil {
+		return nil, errors.Wrap(err, "decode proof receipt")
+	}
+
+	return receipt, nil
+}
@@ -40,7 +40,9 @@ type Provider interface {
 	StreamBlocks(ctx context.Context, req ProviderRequest, callback ProviderCallback) error
 
 	// GetBlock returns the block for the given chain and height, or false if not available (not finalized yet),
-	// or an error. The AttestOffset field is populated with the provided offset (if required).
+	// or an error. False strictly means the chain head (of the requested confirmation level) hasn't reached
+	// the height yet, while RPC failures (including head queries) are always returned as errors.
+	// The AttestOffset field is populated with the provided offset (if required).
 	GetBlock(ctx context.Context, req ProviderRequest) (Block, bool, error)
 
 	// GetSubmittedCursor returns the submitted cursor for the provided stream,
@@ -64,6 +66,21 @@ type Provider interface {
 	GetSubmission(ctx context.Context, chainID uint64, txHash common.Hash) (Submission, error)
 }
 
+// ProviderMiddleware decorates a provider with cross-cutting behavior, e.g. logging, metrics, caching or rate-limiting.
+// Middlewares should return a provider that wraps (embeds) the provided provider, delegating all calls after
+// applying its behavior. This allows composing behavior without modifying the core provider, see DecorateProvider.
+type ProviderMiddleware func(Provider) Provider
+
+// DecorateProvider returns the provider decorated by the middlewares.
+// The first middleware is the outermost, i.e., it is called first and returns last.
+func DecorateProvider(p Provider, middlewares ...ProviderMiddleware) Provider {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		p = middlewares[i](p)
+	}
+
+	return p
+}
+
 // EmitRef specifies which block to query for emit cursors.
 type EmitRef struct {
 	// Height specifies an absolute height to query; if non-nil.
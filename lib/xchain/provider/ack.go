@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// AckFunc acknowledges that a block delivered by StreamAsyncAck was processed successfully.
+type AckFunc func()
+
+// NackFunc reports that a block delivered by StreamAsyncAck failed to process, so it is redelivered.
+type NackFunc func(err error)
+
+// AckCallback is called with each block delivered by StreamAsyncAck. It should return as soon as processing
+// started, and call either ack or nack once processing completed. Calls after the first are ignored.
+// A returned error is equivalent to calling nack.
+type AckCallback func(ctx context.Context, block xchain.Block, ack AckFunc, nack NackFunc) error
+
+// StreamAsyncAck starts a goroutine that streams xblocks asynchronously forever in ack mode.
+// It returns immediately. It only returns an error if the chainID in invalid or
+// if the stream is already active, see ErrStreamAlreadyActive and StopStream.
+//
+// Unlike StreamAsync, delivery is decoupled from processing: blocks are delivered in order without waiting
+// for processing to complete, up to a maximum number of un-acked blocks, see WithMaxPendingAcks.
+// Blocks are committed strictly in order once acked, and if the context contains a CommitTracker,
+// committed heights are reported to it, see WithCommitTracker.
+//
+// If a block is nacked or not acked within the ack timeout (see WithAckTimeout), all blocks from the first
+// un-acked block onwards are redelivered after a backoff, so consumers must handle redelivery.
+func (p *Provider) StreamAsyncAck(
+	ctx context.Context,
+	req xchain.ProviderRequest,
+	callback AckCallback,
+) error {
+	if _, err := p.configuredChain(req.ChainID); err != nil {
+		return err
+	}
+
+	return p.goStream(ctx, req, func(ctx context.Context) error {
+		return p.streamAck(ctx, req, callback)
+	})
+}
+
+// streamAck streams blocks in ack mode, restarting the stream from the first un-acked block on nacks.
+func (p *Provider) streamAck(ctx context.Context, req xchain.ProviderRequest, callback AckCallback) error {
+	tracker := commitTrackerFromCtx(ctx)
+	backoff := p.backoffFunc(ctx)
+
+	for {
+		streamCtx, cancel := context.WithCancel(ctx)
+		window := newAckWindow(p.opts.maxPendingAcks, p.opts.ackTimeout, tracker, cancel)
+
+		err := p.stream(streamCtx, req, func(ctx context.Context, block xchain.Block) error {
+			if err := window.Wait(ctx); err != nil {
+				return err
+			}
+
+			ack, nack := window.Add(block.BlockHeight)
+			if err := callback(ctx, block, ack, nack); err != nil {
+				nack(err)
+			}
+
+			return nil
+		}, true)
+		cancel()
+
+		nackErr := window.Close()
+		if ctx.Err() != nil || nackErr == nil {
+			return err
+		}
+
+		req.Height = window.Next(req.Height)
+		log.Warn(ctx, "Redelivering nacked xprovider blocks", nackErr,
+			"chain", p.network.ChainVersionName(req.ChainVersion()),
+			"height", req.Height,
+		)
+		backoff()
+	}
+}
+
+// ackEntry is a delivered block pending acknowledgement.
+type ackEntry struct {
+	height uint64
+	acked  bool
+	timer  *time.Timer
+}
+
+// ackWindow tracks delivered blocks of a single stream attempt, committing them in order once acked.
+// The first nack (or ack timeout) fails the window, canceling the stream attempt. It is safe for concurrent use.
+type ackWindow struct {
+	maxPending uint64
+	timeout    time.Duration
+	tracker    *CommitTracker
+	cancel     context.CancelFunc
+
+	mu        sync.Mutex
+	pending   []*ackEntry   // Ordered by height
+	space     chan struct{} // Closed and replaced when pending blocks are committed
+	committed uint64
+	ok        bool
+	nackErr   error
+	closed    bool
+}
+
+func newAckWindow(maxPending uint64, timeout time.Duration, tracker *CommitTracker, cancel context.CancelFunc) *ackWindow {
+	return &ackWindow{
+		maxPending: maxPending,
+		timeout:    timeout,
+		tracker:    tracker,
+		cancel:     cancel,
+		space:      make(chan struct{}),
+	}
+}
+
+// Wait blocks while the maximum number of blocks are pending.
+func (w *ackWindow) Wait(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		full := w.maxPending > 0 && uint64(len(w.pending)) >= w.maxPending
+		space := w.space
+		w.mu.Unlock()
+
+		if !full {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-space:
+		}
+	}
+}
+
+// Add adds a delivered block height and returns its ack and nack functions.
+func (w *ackWindow) Add(height uint64) (AckFunc, NackFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entry := &ackEntry{height: height}
+	w.pending = append(w.pending, entry)
+
+	var once sync.Once
+	nack := func(err error) {
+		once.Do(func() { w.nack(entry, err) })
+	}
+	ack := func() {
+		once.Do(func() { w.ack(entry) })
+	}
+
+	if w.timeout > 0 {
+		entry.timer = time.AfterFunc(w.timeout, func() {
+			nack(errors.New("ack timeout", "timeout", w.timeout))
+		})
+	}
+
+	return ack, nack
+}
+
+// ack marks the entry as acked and commits all acked blocks at the front of the window.
+func (w *ackWindow) ack(entry *ackEntry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	if w.closed || w.nackErr != nil {
+		return // Stale ack of a failed attempt, the block is redelivered.
+	}
+
+	entry.acked = true
+
+	var committed int
+	for _, e := range w.pending {
+		if !e.acked {
+			break
+		}
+		committed++
+		w.committed = e.height
+		w.ok = true
+	}
+	if committed == 0 {
+		return
+	}
+
+	w.pending = w.pending[committed:]
+	if w.tracker != nil {
+		w.tracker.Commit(w.committed)
+	}
+
+	close(w.space)
+	w.space = make(chan struct{})
+}
+
+// nack fails the window, canceling the stream attempt.
+func (w *ackWindow) nack(entry *ackEntry, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	if w.closed || w.nackErr != nil {
+		return
+	}
+
+	if err == nil {
+		err = errors.New("nack without error")
+	}
+	w.nackErr = errors.Wrap(err, "nack", "height", entry.height)
+	w.cancel()
+}
+
+// Close stops all ack timers, ignores any subsequent acks and nacks, and returns the nack error if any.
+func (w *ackWindow) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, e := range w.pending {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+	}
+	w.closed = true
+
+	return w.nackErr
+}
+
+// Next returns the next height to deliver, i.e., the first un-acked height, or fromHeight if nothing was committed.
+func (w *ackWindow) Next(fromHeight uint64) uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.ok {
+		return fromHeight
+	}
+
+	return w.committed + 1
+}
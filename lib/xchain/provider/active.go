@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// ErrStreamAlreadyActive is returned when starting an async stream that is already active, see StopStream.
+var ErrStreamAlreadyActive = errors.New("stream already active")
+
+// activeKey identifies an active async stream by chain version and stream metadata label,
+// so different consumers of the same chain version can stream concurrently, see WithStreamMeta.
+type activeKey struct {
+	ChainVersion xchain.ChainVersion
+	MetaLabel    string
+}
+
+// StreamState is the state of an active async stream, see ActiveStreams.
+type StreamState string
+
+const (
+	// StreamStarting is the state of a stream that was registered but didn't start streaming yet.
+	StreamStarting StreamState = "starting"
+	// StreamRunning is the state of a stream that is streaming blocks.
+	StreamRunning StreamState = "running"
+	// StreamStopping is the state of a stream that was stopped but didn't exit yet.
+	StreamStopping StreamState = "stopping"
+)
+
+// StreamInfo describes an active async stream, see ActiveStreams.
+type StreamInfo struct {
+	ChainVersion  xchain.ChainVersion
+	MetaLabel     string      // Stream metadata label, see WithStreamMeta.
+	StartHeight   uint64      // Height the stream started from, after clamping, see startHeight.
+	CurrentHeight uint64      // Next height to deliver.
+	State         StreamState // Current state of the stream.
+	StartedAt     time.Time   // Time the stream was started.
+}
+
+type activeStreamKey struct{}
+
+// activeStream is an active async stream.
+type activeStream struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu   sync.Mutex
+	info StreamInfo
+}
+
+// Streaming records that the stream started streaming from the (clamped) height.
+// Restarts, e.g. by the watchdog, only update the current height. It is nil-safe.
+func (s *activeStream) Streaming(fromHeight uint64) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.info.State == StreamStarting {
+		s.info.State = StreamRunning
+		s.info.StartHeight = fromHeight
+	}
+	s.info.CurrentHeight = fromHeight
+}
+
+// Delivered records a successfully delivered block height. It is nil-safe.
+func (s *activeStream) Delivered(height uint64) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.info.CurrentHeight = height + 1
+}
+
+// stop cancels the stream.
+func (s *activeStream) stop() {
+	s.mu.Lock()
+	s.info.State = StreamStopping
+	s.mu.Unlock()
+
+	s.cancel()
+}
+
+// Info returns a copy of the stream info.
+func (s *activeStream) Info() StreamInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.info
+}
+
+// activeFromCtx returns the active async stream attached to the context or nil.
+func activeFromCtx(ctx context.Context) *activeStream {
+	stream, _ := ctx.Value(activeStreamKey{}).(*activeStream)
+	return stream
+}
+
+// startActive registers a new active async stream, returning its context and a function to call once it stopped.
+// It returns ErrStreamAlreadyActive if the stream is already active.
+func (p *Provider) startActive(ctx context.Context, req xchain.ProviderRequest) (context.Context, func(), error) {
+	meta, err := p.streamMeta(ctx) // Register synchronously, so too many metadata sets are returned to the caller.
+	if err != nil {
+		return nil, nil, err
+	}
+	key := activeKey{ChainVersion: req.ChainVersion(), MetaLabel: meta.label}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.active[key]; ok {
+		return nil, nil, errors.Wrap(ErrStreamAlreadyActive, "start stream", "chain", p.network.ChainVersionName(key.ChainVersion))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &activeStream{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		info: StreamInfo{
+			ChainVersion:  key.ChainVersion,
+			MetaLabel:     key.MetaLabel,
+			StartHeight:   req.Height,
+			CurrentHeight: req.Height,
+			State:         StreamStarting,
+			StartedAt:     time.Now(),
+		},
+	}
+	p.active[key] = stream
+
+	stopped := func() {
+		cancel()
+
+		p.mu.Lock()
+		delete(p.active, key)
+		p.mu.Unlock()
+
+		close(stream.done)
+	}
+
+	return context.WithValue(ctx, activeStreamKey{}, stream), stopped, nil
+}
+
+// ActiveStreams returns the info of all active async streams, ordered by chain version and metadata label.
+// Synchronous streams, e.g. StreamBlocks, are not tracked.
+func (p *Provider) ActiveStreams() []StreamInfo {
+	p.mu.Lock()
+	streams := make([]*activeStream, 0, len(p.active))
+	for _, stream := range p.active {
+		streams = append(streams, stream)
+	}
+	p.mu.Unlock()
+
+	resp := make([]StreamInfo, 0, len(streams))
+	for _, stream := range streams {
+		resp = append(resp, stream.Info())
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].ChainVersion.ID != resp[j].ChainVersion.ID {
+			return resp[i].ChainVersion.ID < resp[j].ChainVersion.ID
+		} else if resp[i].ChainVersion.ConfLevel != resp[j].ChainVersion.ConfLevel {
+			return resp[i].ChainVersion.ConfLevel < resp[j].ChainVersion.ConfLevel
+		}
+
+		return resp[i].MetaLabel < resp[j].MetaLabel
+	})
+
+	return resp
+}
+
+// StopStream stops the active async stream of the chain version and waits for it to stop,
+// allowing it to be restarted. Like starting streams, the stream is identified by the chain version and
+// the context's stream metadata, see WithStreamMeta. It is a noop if the stream is not active.
+func (p *Provider) StopStream(ctx context.Context, chainVer xchain.ChainVersion) error {
+	key := activeKey{ChainVersion: chainVer, MetaLabel: metaFromCtx(ctx).label}
+
+	p.mu.Lock()
+	stream, ok := p.active[key]
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	stream.stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "wait stream stopped")
+	case <-stream.done:
+		return nil
+	}
+}
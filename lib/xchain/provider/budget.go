@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+const (
+	// budgetPollPeriod defines the period to poll a full budget.
+	budgetPollPeriod = time.Millisecond * 100
+
+	// Estimated overhead bytes of block elements, excluding variable length fields.
+	blockOverhead   = 256
+	msgOverhead     = 192
+	receiptOverhead = 160
+)
+
+// byteBudget is a soft memory budget for in-flight (fetched but not yet processed) blocks
+// and held orphan receipts shared by all streams of a provider. A nil budget is unlimited.
+type byteBudget struct {
+	maxBytes uint64
+
+	mu   sync.Mutex
+	used uint64
+}
+
+// newByteBudget returns a new budget or nil (unlimited) if maxBytes is zero.
+func newByteBudget(maxBytes uint64) *byteBudget {
+	if maxBytes == 0 {
+		return nil
+	}
+
+	return &byteBudget{maxBytes: maxBytes}
+}
+
+func (b *byteBudget) full() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.used >= b.maxBytes
+}
+
+func (b *byteBudget) add(n uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.used += n
+	inflightBytes.Set(float64(b.used))
+}
+
+func (b *byteBudget) sub(n uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.used -= min(n, b.used)
+	inflightBytes.Set(float64(b.used))
+}
+
+// streamBudget tracks the in-flight bytes of a single stream against the shared budget.
+// A nil stream budget is unlimited.
+type streamBudget struct {
+	global *byteBudget
+
+	mu     sync.Mutex
+	next   uint64            // Next height to process
+	sizes  map[uint64]uint64 // In-flight bytes by height
+	closed bool
+}
+
+// newStreamBudget returns a new stream budget or nil if the global budget is nil (unlimited).
+func newStreamBudget(global *byteBudget, fromHeight uint64) *streamBudget {
+	if global == nil {
+		return nil
+	}
+
+	return &streamBudget{
+		global: global,
+		next:   fromHeight,
+		sizes:  make(map[uint64]uint64),
+	}
+}
+
+// Wait blocks while the shared budget is full, pausing prefetching.
+// It never blocks for the next height to process, ensuring streams always progress.
+func (s *streamBudget) Wait(ctx context.Context, height uint64) error {
+	if s == nil {
+		return nil
+	}
+
+	for {
+		s.mu.Lock()
+		isNext := height <= s.next
+		s.mu.Unlock()
+
+		if isNext || !s.global.full() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(budgetPollPeriod):
+		}
+	}
+}
+
+// Fetched adds the fetched block's bytes to the budget.
+func (s *streamBudget) Fetched(block xchain.Block) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	} else if _, ok := s.sizes[block.BlockHeight]; ok {
+		return // Already tracked
+	}
+
+	size := blockSize(block)
+	s.sizes[block.BlockHeight] = size
+	s.global.add(size)
+}
+
+// Processed releases the processed block's bytes from the budget.
+func (s *streamBudget) Processed(block xchain.Block) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next = block.BlockHeight + 1
+
+	size, ok := s.sizes[block.BlockHeight]
+	if !ok {
+		return
+	}
+
+	delete(s.sizes, block.BlockHeight)
+	s.global.sub(size)
+}
+
+// Close releases all remaining in-flight bytes of the stream.
+func (s *streamBudget) Close() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for height, size := range s.sizes {
+		delete(s.sizes, height)
+		s.global.sub(size)
+	}
+	s.closed = true
+}
+
+// blockSize returns the estimated in-memory size of the block in bytes.
+func blockSize(block xchain.Block) uint64 {
+	size := uint64(blockOverhead)
+	for _, msg := range block.Msgs {
+		size += msgOverhead + uint64(len(msg.Data))
+	}
+	for _, receipt := range block.Receipts {
+		size += receiptSize(receipt)
+	}
+
+	return size
+}
+
+// receiptSize returns the estimated in-memory size of the receipt in bytes.
+func receiptSize(receipt xchain.Receipt) uint64 {
+	return receiptOverhead + uint64(len(receipt.Error))
+}
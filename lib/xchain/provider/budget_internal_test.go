@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamBudget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	block := func(height uint64) xchain.Block {
+		return xchain.Block{BlockHeader: xchain.BlockHeader{BlockHeight: height}}
+	}
+
+	// Nil budgets are unlimited noops.
+	var nilBudget *streamBudget
+	require.Nil(t, newStreamBudget(newByteBudget(0), 0))
+	require.NoError(t, nilBudget.Wait(ctx, 99))
+	nilBudget.Fetched(block(99))
+	nilBudget.Processed(block(99))
+	nilBudget.Close()
+
+	global := newByteBudget(blockOverhead * 2)
+	s1 := newStreamBudget(global, 1)
+	s2 := newStreamBudget(global, 1)
+
+	// Fill the budget
+	s1.Fetched(block(2))
+	s1.Fetched(block(2)) // Idempotent
+	s2.Fetched(block(3))
+	require.True(t, global.full())
+
+	// Next heights are never blocked.
+	require.NoError(t, s1.Wait(ctx, 1))
+	require.NoError(t, s2.Wait(ctx, 1))
+
+	// Other heights are blocked.
+	timeout, cancel := context.WithTimeout(ctx, budgetPollPeriod*2)
+	defer cancel()
+	require.ErrorIs(t, s1.Wait(timeout, 4), context.DeadlineExceeded)
+
+	// Processing releases the budget.
+	s1.Fetched(block(1))
+	s1.Processed(block(1))
+	s1.Processed(block(2))
+	require.False(t, global.full())
+	require.NoError(t, s1.Wait(ctx, 4))
+
+	// Closing releases remaining bytes and ignores subsequent fetches.
+	s2.Close()
+	s2.Fetched(block(4))
+	require.Zero(t, global.used)
+
+	// Wait unblocks once budget is released.
+	s1.Fetched(block(3))
+	s1.Fetched(block(4))
+	require.True(t, global.full())
+	go func() {
+		time.Sleep(budgetPollPeriod)
+		s1.Processed(block(3))
+	}()
+	require.NoError(t, s1.Wait(ctx, 5))
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// caughtUpDelta is the number of heights behind the chain head that a stream is considered caught up.
+const caughtUpDelta = 1
+
+// StreamUntilCaughtUp blocks, streaming finalized blocks of the chain from the height like StreamBlocks,
+// but returns nil once the stream caught up with the chain head, i.e., once the next height to deliver
+// is within a small delta of the head. The head is re-queried whenever the stream reaches it,
+// so the stream keeps going if the head advanced during catch-up. It is intended for "sync then do something"
+// CLIs and batch jobs.
+//
+// It returns nil immediately if the height is already caught up.
+// It returns the first callback or head query error, or an error if the context is canceled before catching up.
+func (p *Provider) StreamUntilCaughtUp(ctx context.Context, chainID uint64, fromHeight uint64, callback xchain.ProviderCallback) error {
+	if _, err := p.configuredChain(chainID); err != nil {
+		return err
+	}
+
+	chainVer := xchain.ChainVersion{ID: chainID, ConfLevel: xchain.ConfFinalized}
+	head, err := p.ChainVersionHeight(ctx, chainVer)
+	if err != nil {
+		return errors.Wrap(err, "chain version height")
+	} else if caughtUp(fromHeight, head) {
+		return nil
+	}
+
+	streamCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var done bool
+	req := xchain.ProviderRequest{ChainID: chainID, Height: fromHeight, ConfLevel: chainVer.ConfLevel}
+	err = p.StreamBlocks(streamCtx, req, func(ctx context.Context, block xchain.Block) error {
+		if err := callback(ctx, block); err != nil {
+			return err
+		}
+
+		next := block.BlockHeight + 1
+		if !caughtUp(next, head) {
+			return nil
+		}
+
+		// Re-query the head, since it may have advanced during catch-up.
+		latest, err := p.ChainVersionHeight(ctx, chainVer)
+		if err != nil {
+			return errors.Wrap(err, "chain version height")
+		}
+		head = latest
+		if !caughtUp(next, head) {
+			return nil
+		}
+
+		log.Debug(ctx, "Stream caught up with chain head", "height", block.BlockHeight, "head", head)
+		done = true
+		cancel(errStreamDone)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	} else if !done && ctx.Err() != nil {
+		return errors.Wrap(ctx.Err(), "stream canceled before caught up")
+	} else if !done {
+		return errors.New("stream stopped before caught up [BUG]")
+	}
+
+	return nil
+}
+
+// caughtUp returns true if the next height to deliver is within the caught up delta of the head.
+func caughtUp(next uint64, head uint64) bool {
+	return next+caughtUpDelta > head
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/omni-network/omni/lib/errors"
+)
+
+// ErrChainIDChanged is returned by streams that detected that the RPC endpoint's chain ID changed,
+// see WithReconnectChainIDCheck.
+var ErrChainIDChanged = errors.New("rpc chain ID changed")
+
+// chainIDCheck re-verifies the RPC endpoint's chain ID after fetch errors (i.e., likely reconnects),
+// see WithReconnectChainIDCheck. A nil check is disabled.
+type chainIDCheck struct {
+	p       *Provider
+	chainID uint64
+	halt    context.CancelFunc // Stops the stream on mismatch.
+
+	mu       sync.Mutex
+	pending  bool  // Whether a fetch failed since the last check.
+	mismatch error // Non-nil once a mismatch was detected.
+}
+
+// newChainIDCheck returns a new chain ID check of the EVM chain or nil if disabled.
+// The halt function is called when a mismatch is detected, it should stop the stream.
+func (p *Provider) newChainIDCheck(chainID uint64, halt context.CancelFunc) *chainIDCheck {
+	if !p.opts.reconnectChainIDCheck || chainID == p.cChainID {
+		return nil
+	}
+
+	return &chainIDCheck{p: p, chainID: chainID, halt: halt}
+}
+
+// FetchFailed marks the chain ID as pending re-verification.
+func (c *chainIDCheck) FetchFailed() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = true
+}
+
+// BeforeFetch re-verifies the chain ID if a fetch failed since the last check.
+// It returns an error if the check failed or the chain ID changed. Check errors are retried like fetch errors.
+func (c *chainIDCheck) BeforeFetch(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mismatch != nil {
+		return c.mismatch
+	} else if !c.pending {
+		return nil
+	}
+
+	_, rpcClient, err := c.p.getEVMChain(c.chainID)
+	if err != nil {
+		return err
+	}
+
+	actual, err := rpcClient.ChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get chain ID")
+	} else if !actual.IsUint64() || actual.Uint64() != c.chainID {
+		c.mismatch = errors.Wrap(ErrChainIDChanged, "verify chain ID", "expect", c.chainID, "actual", actual)
+		c.halt()
+
+		return c.mismatch
+	}
+
+	c.pending = false
+
+	return nil
+}
+
+// Mismatch returns the mismatch error if the chain ID changed.
+func (c *chainIDCheck) Mismatch() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.mismatch
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// ChainOptions defines per-chain stream tuning.
+// Zero fields are unset and fall back to the next level of precedence, see WithChainOptions.
+type ChainOptions struct {
+	// FetchTimeout is the timeout of each block fetch attempt. Zero disables the timeout.
+	FetchTimeout time.Duration
+	// FetchWorkers is the number of blocks fetched concurrently, i.e., the effective fetch batch size.
+	// Zero uses a default based on the chain block period.
+	FetchWorkers uint64
+	// PollInterval is the fixed interval to poll for new blocks once a stream reached the chain head.
+	// Zero uses the exponential stream backoff.
+	PollInterval time.Duration
+	// Backoff returns the backoff function of stream fetch and callback retries.
+	// Nil uses the provider backoff, see New.
+	Backoff func(ctx context.Context) func()
+	// Confirmations is the number of blocks required on top of a ConfLatest block before it is available.
+	// It has no effect on finalized streams. Zero delivers latest blocks immediately.
+	// It can be overridden per chain at startup via XCHAIN_CONFIRMATIONS_<chainID> environment variables.
+	Confirmations uint64
+}
+
+// merge returns the options with unset fields populated from the provided defaults.
+func (o ChainOptions) merge(defaults ChainOptions) ChainOptions {
+	if o.FetchTimeout == 0 {
+		o.FetchTimeout = defaults.FetchTimeout
+	}
+	if o.FetchWorkers == 0 {
+		o.FetchWorkers = defaults.FetchWorkers
+	}
+	if o.PollInterval == 0 {
+		o.PollInterval = defaults.PollInterval
+	}
+	if o.Backoff == nil {
+		o.Backoff = defaults.Backoff
+	}
+	if o.Confirmations == 0 {
+		o.Confirmations = defaults.Confirmations
+	}
+
+	return o
+}
+
+// chainOptions returns the effective options of the chain; per-chain options,
+// then global default options, then the provider built-in defaults.
+func (p *Provider) chainOptions(chainID uint64, blockPeriod time.Duration) ChainOptions {
+	var workers uint64 // Pick the first threshold that matches (or the last one)
+	for _, threshold := range fetchWorkerThresholds {
+		workers = threshold.Workers
+		if blockPeriod >= threshold.MinPeriod {
+			break
+		}
+	}
+
+	builtin := ChainOptions{
+		FetchWorkers: workers,
+		Backoff:      p.backoffFunc,
+	}
+
+	return p.opts.chainOpts[chainID].merge(p.opts.defaultChainOpts).merge(builtin)
+}
+
+// confirmations returns the effective number of confirmations of the chain's latest blocks.
+// Unlike chainOptions, it doesn't resolve built-in defaults, so it is cheap enough for each block fetch.
+func (p *Provider) confirmations(chainID uint64) uint64 {
+	return p.opts.chainOpts[chainID].merge(p.opts.defaultChainOpts).Confirmations
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainOptionsPrecedence(t *testing.T) {
+	t.Parallel()
+
+	var builtinBackoff, customBackoff int
+	p := &Provider{
+		backoffFunc: func(context.Context) func() {
+			builtinBackoff++
+			return func() {}
+		},
+		opts: options{
+			defaultChainOpts: ChainOptions{
+				FetchTimeout:  time.Second,
+				Confirmations: 1,
+			},
+			chainOpts: map[uint64]ChainOptions{
+				1: {
+					FetchTimeout: time.Minute,
+					FetchWorkers: 8,
+					PollInterval: time.Millisecond,
+					Backoff: func(context.Context) func() {
+						customBackoff++
+						return func() {}
+					},
+				},
+			},
+		},
+	}
+
+	// Per-chain options take precedence over global defaults, which take precedence over built-in defaults.
+	opts1 := p.chainOptions(1, time.Second)
+	require.Equal(t, time.Minute, opts1.FetchTimeout)
+	require.Equal(t, uint64(8), opts1.FetchWorkers)
+	require.Equal(t, time.Millisecond, opts1.PollInterval)
+	require.Equal(t, uint64(1), opts1.Confirmations)
+	opts1.Backoff(context.Background())
+	require.Equal(t, 1, customBackoff)
+
+	// Unconfigured chains use global defaults, then built-in defaults.
+	opts2 := p.chainOptions(2, time.Millisecond)
+	require.Equal(t, time.Second, opts2.FetchTimeout)
+	require.Equal(t, fetchWorkerThresholds[len(fetchWorkerThresholds)-1].Workers, opts2.FetchWorkers)
+	require.Zero(t, opts2.PollInterval)
+	require.Equal(t, uint64(1), opts2.Confirmations)
+	opts2.Backoff(context.Background())
+	require.Equal(t, 1, builtinBackoff)
+}
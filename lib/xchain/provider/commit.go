@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// commitPollPeriod defines the period to poll a stream paused due to commit lag.
+const commitPollPeriod = time.Millisecond * 100
+
+type commitKey struct{}
+
+// CommitTracker tracks the height a stream consumer has durably committed (e.g. persisted its cursor),
+// enabling commit lag backpressure, see WithMaxCommitLag. It is safe for concurrent use.
+type CommitTracker struct {
+	mu        sync.Mutex
+	committed uint64
+	ok        bool
+}
+
+// NewCommitTracker returns a new commit tracker without any committed height.
+func NewCommitTracker() *CommitTracker {
+	return &CommitTracker{}
+}
+
+// Commit reports the provided height as durably committed by the consumer.
+// Lower heights than previously committed are ignored.
+func (t *CommitTracker) Commit(height uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.ok && height <= t.committed {
+		return
+	}
+
+	t.committed = height
+	t.ok = true
+}
+
+// next returns the next height to commit, or fromHeight if nothing was committed yet.
+func (t *CommitTracker) next(fromHeight uint64) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.ok {
+		return fromHeight
+	}
+
+	return t.committed + 1
+}
+
+// WithCommitTracker returns a copy of the context with the provided commit tracker attached.
+// Streams started with the context pause fetching while the number of heights fetched beyond
+// the tracker's committed height reaches the provider's maximum commit lag, see WithMaxCommitLag.
+func WithCommitTracker(ctx context.Context, tracker *CommitTracker) context.Context {
+	return context.WithValue(ctx, commitKey{}, tracker)
+}
+
+// commitTrackerFromCtx returns the commit tracker attached to the context or nil.
+func commitTrackerFromCtx(ctx context.Context) *CommitTracker {
+	tracker, _ := ctx.Value(commitKey{}).(*CommitTracker)
+	return tracker
+}
+
+// waitCommitLag blocks while the height is maxLag or more heights beyond the next height to commit.
+// It returns immediately if the tracker is nil or maxLag is zero (disabled).
+func waitCommitLag(ctx context.Context, tracker *CommitTracker, maxLag uint64, fromHeight uint64, height uint64) error {
+	if tracker == nil || maxLag == 0 {
+		return nil
+	}
+
+	for {
+		next := tracker.next(fromHeight)
+		if height < next || height-next < maxLag {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(commitPollPeriod):
+		}
+	}
+}
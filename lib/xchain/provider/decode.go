@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PortalDecoder decodes the XMsg and XReceipt event logs of a portal contract ABI version.
+// It allows streaming across portal upgrades that changed the event schema, see WithPortalDecoder.
+type PortalDecoder interface {
+	// XMsgTopic returns the XMsg event topic (signature hash).
+	XMsgTopic() common.Hash
+	// XReceiptTopic returns the XReceipt event topic (signature hash).
+	XReceiptTopic() common.Hash
+	// DecodeXMsg decodes an XMsg event log emitted by the portal of the source chain.
+	DecodeXMsg(srcChainID uint64, log types.Log) (xchain.Msg, error)
+	// DecodeXReceipt decodes an XReceipt event log emitted by the portal of the destination chain.
+	DecodeXReceipt(destChainID uint64, log types.Log) (xchain.Receipt, error)
+}
+
+// decoderActivation is a portal decoder activated from a block height.
+type decoderActivation struct {
+	Height  uint64
+	Decoder PortalDecoder
+}
+
+// CurrentPortalDecoder returns the portal decoder of the current portal ABI (contracts/bindings).
+// It is used by default for all chains and heights.
+func CurrentPortalDecoder() (PortalDecoder, error) {
+	portalAbi, err := bindings.OmniPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "get abi")
+	}
+
+	// Parsing doesn't require the contract address or a backend.
+	filterer, err := bindings.NewOmniPortalFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new filterer")
+	}
+
+	return currentDecoder{
+		xmsgTopic:     portalAbi.Events["XMsg"].ID,
+		xreceiptTopic: portalAbi.Events["XReceipt"].ID,
+		filterer:      filterer,
+	}, nil
+}
+
+// currentDecoder implements PortalDecoder for the current portal ABI.
+type currentDecoder struct {
+	xmsgTopic     common.Hash
+	xreceiptTopic common.Hash
+	filterer      *bindings.OmniPortalFilterer
+}
+
+func (d currentDecoder) XMsgTopic() common.Hash {
+	return d.xmsgTopic
+}
+
+func (d currentDecoder) XReceiptTopic() common.Hash {
+	return d.xreceiptTopic
+}
+
+func (d currentDecoder) DecodeXMsg(srcChainID uint64, log types.Log) (xchain.Msg, error) {
+	e, err := d.filterer.ParseXMsg(log)
+	if err != nil {
+		return xchain.Msg{}, errors.Wrap(err, "parse xmsg log")
+	}
+
+	return xchain.Msg{
+		MsgID: xchain.MsgID{
+			StreamID: xchain.StreamID{
+				SourceChainID: srcChainID,
+				DestChainID:   e.DestChainId,
+				ShardID:       xchain.ShardID(e.ShardId),
+			},
+			StreamOffset: e.Offset,
+		},
+		SourceMsgSender: e.Sender,
+		DestAddress:     e.To,
+		Data:            e.Data,
+		DestGasLimit:    e.GasLimit,
+		TxHash:          e.Raw.TxHash,
+		Fees:            e.Fees,
+	}, nil
+}
+
+func (d currentDecoder) DecodeXReceipt(destChainID uint64, log types.Log) (xchain.Receipt, error) {
+	e, err := d.filterer.ParseXReceipt(log)
+	if err != nil {
+		return xchain.Receipt{}, errors.Wrap(err, "parse xreceipt log")
+	}
+
+	return xchain.Receipt{
+		MsgID: xchain.MsgID{
+			StreamID: xchain.StreamID{
+				SourceChainID: e.SourceChainId,
+				DestChainID:   destChainID,
+				ShardID:       xchain.ShardID(e.ShardId),
+			},
+			StreamOffset: e.Offset,
+		},
+		GasUsed:        e.GasUsed.Uint64(),
+		Success:        e.Success,
+		Error:          e.Err,
+		RelayerAddress: e.Relayer,
+		TxHash:         e.Raw.TxHash,
+	}, nil
+}
+
+// portalDecoder returns the portal decoder of the chain at the height.
+// This is the configured decoder with the highest activation height not above the height,
+// or the current decoder if none is activated.
+func (p *Provider) portalDecoder(chainID uint64, height uint64) (PortalDecoder, error) {
+	var (
+		resp      PortalDecoder
+		activated uint64
+	)
+	for _, a := range p.opts.portalDecoders[chainID] {
+		if a.Height <= height && (resp == nil || a.Height >= activated) {
+			resp, activated = a.Decoder, a.Height
+		}
+	}
+
+	if resp != nil {
+		return resp, nil
+	}
+
+	return CurrentPortalDecoder()
+}
+
+// xmsgTopics returns the XMsg event topics of all portal decoders of the chain.
+func (p *Provider) xmsgTopics(chainID uint64) ([]common.Hash, error) {
+	current, err := CurrentPortalDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []common.Hash{current.XMsgTopic()}
+	for _, a := range p.opts.portalDecoders[chainID] {
+		resp = append(resp, a.Decoder.XMsgTopic())
+	}
+
+	return resp, nil
+}
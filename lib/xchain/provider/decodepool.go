@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/forkjoin"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// chunksPerWorker is the number of chunks per decode worker, balancing load vs per-chunk overhead.
+const chunksPerWorker = 4
+
+// logChunk is a contiguous range [from, to) of log indexes decoded by a single worker.
+type logChunk struct {
+	From, To int
+}
+
+// decodeLogs returns the decoded logs in the same order as the logs.
+// It decodes contiguous chunks of logs concurrently using up to the provided number of workers,
+// assembling the results by index so the result is identical regardless of the number of workers.
+// It decodes sequentially if workers is less than two, see WithDecodeWorkers.
+func decodeLogs[T any](ctx context.Context, workers int, logs []types.Log, decode func(types.Log) (T, error),
+) ([]T, error) {
+	if len(logs) == 0 {
+		return nil, nil
+	}
+
+	resp := make([]T, len(logs))
+
+	if workers < 2 || len(logs) < 2 {
+		for i, l := range logs {
+			elem, err := decode(l)
+			if err != nil {
+				return nil, err
+			}
+			resp[i] = elem
+		}
+
+		return resp, nil
+	}
+
+	chunkSize := max(len(logs)/(workers*chunksPerWorker), 1)
+	var chunks []logChunk
+	for from := 0; from < len(logs); from += chunkSize {
+		chunks = append(chunks, logChunk{From: from, To: min(from+chunkSize, len(logs))})
+	}
+
+	work := func(ctx context.Context, chunk logChunk) (struct{}, error) {
+		for i := chunk.From; i < chunk.To; i++ {
+			if ctx.Err() != nil {
+				return struct{}{}, ctx.Err()
+			}
+
+			elem, err := decode(logs[i])
+			if err != nil {
+				return struct{}{}, err
+			}
+			resp[i] = elem // Each index is only written by a single worker.
+		}
+
+		return struct{}{}, nil
+	}
+
+	results, cancel := forkjoin.NewWithInputs(ctx, work, chunks,
+		forkjoin.WithWorkers(workers),
+		forkjoin.WithWaitOnCancel(),
+		forkjoin.WithInputBuffer(len(chunks)),
+	)
+	defer cancel()
+
+	if _, err := results.Flatten(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
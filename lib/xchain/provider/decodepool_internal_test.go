@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	decoder, err := CurrentPortalDecoder()
+	require.NoError(t, err)
+
+	decode := func(l types.Log) (xchain.Msg, error) {
+		return decoder.DecodeXMsg(1, l)
+	}
+
+	for _, count := range []int{0, 1, 2, 7, 100} {
+		logs := testXMsgLogs(t, count)
+
+		expected, err := decodeLogs(ctx, 0, logs, decode)
+		require.NoError(t, err)
+		require.Len(t, expected, count)
+		for i, msg := range expected {
+			require.Equal(t, uint64(i+1), msg.StreamOffset)
+		}
+
+		for _, workers := range []int{1, 2, 3, 8, 200} {
+			actual, err := decodeLogs(ctx, workers, logs, decode)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual, "count=%d workers=%d", count, workers)
+		}
+	}
+
+	// Errors are returned.
+	logs := testXMsgLogs(t, 10)
+	logs[5].Data = []byte("invalid")
+	for _, workers := range []int{0, 4} {
+		_, err := decodeLogs(ctx, workers, logs, decode)
+		require.Error(t, err)
+	}
+
+	_, err = decodeLogs(ctx, 4, logs, func(types.Log) (xchain.Msg, error) {
+		return xchain.Msg{}, errors.New("test error")
+	})
+	require.ErrorContains(t, err, "test error")
+}
+
+func BenchmarkDecodeLogs(b *testing.B) {
+	decoder, err := CurrentPortalDecoder()
+	require.NoError(b, err)
+
+	decode := func(l types.Log) (xchain.Msg, error) {
+		return decoder.DecodeXMsg(1, l)
+	}
+
+	logs := testXMsgLogs(b, 1000)
+	for _, workers := range []int{0, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := decodeLogs(context.Background(), workers, logs, decode)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// testXMsgLogs returns count XMsg logs with offsets 1..count.
+func testXMsgLogs(t testing.TB, count int) []types.Log {
+	t.Helper()
+
+	portalAbi, err := bindings.OmniPortalMetaData.GetAbi()
+	require.NoError(t, err)
+	event := portalAbi.Events["XMsg"]
+
+	var resp []types.Log
+	for i := 1; i <= count; i++ {
+		data, err := event.Inputs.NonIndexed().Pack(
+			common.BytesToAddress([]byte{byte(i)}),
+			common.BytesToAddress([]byte{byte(i + 1)}),
+			make([]byte, 256),
+			uint64(i),
+			big.NewInt(int64(i)),
+		)
+		require.NoError(t, err)
+
+		resp = append(resp, types.Log{
+			Topics: []common.Hash{
+				event.ID,
+				common.BigToHash(big.NewInt(2)),
+				common.BigToHash(big.NewInt(int64(xchain.ShardFinalized0))),
+				common.BigToHash(big.NewInt(int64(i))),
+			},
+			Data:  data,
+			Index: uint(i),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+// Disk cache key prefixes.
+const (
+	prefixBlock byte = 'b' // <prefix><chainID><height> -> cachedBlock
+	prefixSeq   byte = 's' // <prefix><seq> -> <chainID><height>
+)
+
+// cachedBlock is the disk cache value of a finalized block.
+type cachedBlock struct {
+	Block    xchain.Block `json:"block"`
+	Seq      uint64       `json:"seq"`
+	CachedAt time.Time    `json:"cached_at"`
+}
+
+// diskCache is an on-disk cache of finalized blocks keyed by chain ID and height.
+// It survives restarts, reducing re-fetching on recovery. This is safe since finalized blocks are immutable.
+// Entries are evicted in insertion order once the maximum number of blocks is exceeded or after the TTL,
+// zero values disable the respective eviction.
+// A nil cache is disabled.
+type diskCache struct {
+	db        dbm.DB
+	maxBlocks uint64
+	ttl       time.Duration
+
+	mu          sync.Mutex
+	initialized bool
+	nextSeq     uint64
+	count       uint64
+}
+
+// newDiskCache returns a new disk cache backed by the provided DB, or nil if the DB is nil (disabled).
+func newDiskCache(db dbm.DB, maxBlocks uint64, ttl time.Duration) *diskCache {
+	if db == nil {
+		return nil
+	}
+
+	return &diskCache{db: db, maxBlocks: maxBlocks, ttl: ttl}
+}
+
+// maybeInit resumes the insertion sequence and block count of existing entries on first use.
+// It must be called while holding the lock.
+func (c *diskCache) maybeInit() error {
+	if c.initialized {
+		return nil
+	}
+
+	iter, err := c.db.ReverseIterator([]byte{prefixSeq}, []byte{prefixSeq + 1})
+	if err != nil {
+		return errors.Wrap(err, "seq iterator")
+	}
+	if iter.Valid() {
+		c.nextSeq = binary.BigEndian.Uint64(iter.Key()[1:]) + 1
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Wrap(err, "close iterator")
+	}
+
+	iter, err = c.db.Iterator([]byte{prefixBlock}, []byte{prefixBlock + 1})
+	if err != nil {
+		return errors.Wrap(err, "block iterator")
+	}
+	for ; iter.Valid(); iter.Next() {
+		c.count++
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Wrap(err, "close iterator")
+	}
+
+	c.initialized = true
+
+	return nil
+}
+
+// Get returns the cached block of the chain and height, or false if not cached or expired.
+func (c *diskCache) Get(chainID uint64, height uint64) (xchain.Block, bool, error) {
+	if c == nil {
+		return xchain.Block{}, false, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.maybeInit(); err != nil {
+		return xchain.Block{}, false, err
+	}
+
+	cached, ok, err := c.get(diskBlockKey(chainID, height))
+	if err != nil {
+		return xchain.Block{}, false, err
+	} else if !ok {
+		return xchain.Block{}, false, nil
+	} else if c.expired(cached) {
+		return xchain.Block{}, false, c.delete(cached)
+	}
+
+	// Restore the local location of timestamps, lost during JSON encoding, see GetBlock.
+	cached.Block.Timestamp = cached.Block.Timestamp.Local()
+
+	return cached.Block, true, nil
+}
+
+// Put caches the finalized block, evicting the oldest entries if the cache is full.
+func (c *diskCache) Put(block xchain.Block) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.maybeInit(); err != nil {
+		return err
+	}
+
+	key := diskBlockKey(block.ChainID, block.BlockHeight)
+	if ok, err := c.db.Has(key); err != nil {
+		return errors.Wrap(err, "has block")
+	} else if ok {
+		return nil // Finalized blocks are immutable.
+	}
+
+	bz, err := json.Marshal(cachedBlock{Block: block, Seq: c.nextSeq, CachedAt: time.Now()})
+	if err != nil {
+		return errors.Wrap(err, "marshal block")
+	}
+
+	batch := c.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set(key, bz); err != nil {
+		return errors.Wrap(err, "set block")
+	} else if err := batch.Set(diskSeqKey(c.nextSeq), key); err != nil {
+		return errors.Wrap(err, "set seq")
+	} else if err := batch.Write(); err != nil {
+		return errors.Wrap(err, "write batch")
+	}
+
+	c.nextSeq++
+	c.count++
+
+	return c.evict()
+}
+
+// encodedBlockSize returns the size in bytes of the block as encoded by the disk cache.
+func encodedBlockSize(block xchain.Block) (int, error) {
+	bz, err := json.Marshal(block)
+	if err != nil {
+		return 0, errors.Wrap(err, "marshal block")
+	}
+
+	return len(bz), nil
+}
+
+// evict deletes the oldest entries while the cache is full or the oldest entry expired.
+func (c *diskCache) evict() error {
+	for {
+		iter, err := c.db.Iterator([]byte{prefixSeq}, []byte{prefixSeq + 1})
+		if err != nil {
+			return errors.Wrap(err, "seq iterator")
+		}
+
+		var key []byte
+		if iter.Valid() {
+			key = append([]byte(nil), iter.Value()...)
+		}
+		if err := iter.Close(); err != nil {
+			return errors.Wrap(err, "close iterator")
+		} else if key == nil {
+			return nil // Empty
+		}
+
+		cached, ok, err := c.get(key)
+		if err != nil {
+			return err
+		} else if !ok {
+			return errors.New("missing cached block [BUG]")
+		} else if (c.maxBlocks == 0 || c.count <= c.maxBlocks) && !c.expired(cached) {
+			return nil
+		}
+
+		if err := c.delete(cached); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *diskCache) get(key []byte) (cachedBlock, bool, error) {
+	bz, err := c.db.Get(key)
+	if err != nil {
+		return cachedBlock{}, false, errors.Wrap(err, "get block")
+	} else if bz == nil {
+		return cachedBlock{}, false, nil
+	}
+
+	var cached cachedBlock
+	if err := json.Unmarshal(bz, &cached); err != nil {
+		return cachedBlock{}, false, errors.Wrap(err, "unmarshal block")
+	}
+
+	return cached, true, nil
+}
+
+func (c *diskCache) delete(cached cachedBlock) error {
+	batch := c.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Delete(diskBlockKey(cached.Block.ChainID, cached.Block.BlockHeight)); err != nil {
+		return errors.Wrap(err, "delete block")
+	} else if err := batch.Delete(diskSeqKey(cached.Seq)); err != nil {
+		return errors.Wrap(err, "delete seq")
+	} else if err := batch.Write(); err != nil {
+		return errors.Wrap(err, "write batch")
+	}
+
+	c.count--
+
+	return nil
+}
+
+func (c *diskCache) expired(cached cachedBlock) bool {
+	return c.ttl > 0 && time.Since(cached.CachedAt) > c.ttl
+}
+
+// getDiskCached returns the finalized block from the disk cache or false if disabled or not cached.
+// Disk cache errors are logged and treated as misses, since the cache is best-effort.
+func (p *Provider) getDiskCached(ctx context.Context, req xchain.ProviderRequest) (xchain.Block, bool) {
+	if p.diskCache == nil || req.ConfLevel != xchain.ConfFinalized {
+		return xchain.Block{}, false
+	}
+
+	block, ok, err := p.diskCache.Get(req.ChainID, req.Height)
+	if err != nil {
+		log.Warn(ctx, "Failed getting block from disk cache (will fetch)", err, "height", req.Height)
+	}
+
+	result := "miss"
+	if ok {
+		result = "hit"
+	}
+	diskCacheLookups.WithLabelValues(p.network.ChainVersionName(req.ChainVersion()), result).Inc()
+
+	return block, ok
+}
+
+// putDiskCached stores the finalized block in the disk cache if enabled.
+// Disk cache errors are logged, since the cache is best-effort.
+func (p *Provider) putDiskCached(ctx context.Context, req xchain.ProviderRequest, block xchain.Block) {
+	if p.diskCache == nil || req.ConfLevel != xchain.ConfFinalized {
+		return
+	}
+
+	if err := p.diskCache.Put(block); err != nil {
+		log.Warn(ctx, "Failed putting block in disk cache (will ignore)", err, "height", req.Height)
+	}
+}
+
+func diskBlockKey(chainID uint64, height uint64) []byte {
+	key := []byte{prefixBlock}
+	key = binary.BigEndian.AppendUint64(key, chainID)
+
+	return binary.BigEndian.AppendUint64(key, height)
+}
+
+func diskSeqKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{prefixSeq}, seq)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCache(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 999
+	block := func(height uint64) xchain.Block {
+		return xchain.Block{
+			BlockHeader: xchain.BlockHeader{ChainID: chainID, BlockHeight: height, BlockHash: common.Hash{byte(height)}},
+			Msgs:        []xchain.Msg{{Data: []byte{byte(height)}}},
+			Timestamp:   time.Unix(int64(height), 0),
+		}
+	}
+
+	// Nil caches are disabled noops.
+	var nilCache *diskCache
+	require.Nil(t, newDiskCache(nil, 0, 0))
+	require.NoError(t, nilCache.Put(block(1)))
+	_, ok, err := nilCache.Get(chainID, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	db := dbm.NewMemDB()
+	cache := newDiskCache(db, 3, 0)
+
+	requireCached := func(t *testing.T, cache *diskCache, height uint64, expect bool) {
+		t.Helper()
+		actual, ok, err := cache.Get(chainID, height)
+		require.NoError(t, err)
+		require.Equal(t, expect, ok, "height %d", height)
+		if expect {
+			require.Equal(t, block(height), actual)
+		}
+	}
+
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(t, cache.Put(block(height)))
+	}
+	requireCached(t, cache, 1, true)
+	requireCached(t, cache, 2, true)
+	requireCached(t, cache, 3, true)
+	requireCached(t, cache, 4, false)
+	requireCached(t, cache, 1, true)
+
+	// Duplicate puts are ignored.
+	require.NoError(t, cache.Put(block(3)))
+	requireCached(t, cache, 1, true)
+
+	// Oldest evicted once full.
+	require.NoError(t, cache.Put(block(4)))
+	requireCached(t, cache, 1, false)
+	requireCached(t, cache, 2, true)
+	requireCached(t, cache, 4, true)
+
+	// Restarted cache resumes existing entries.
+	cache = newDiskCache(db, 3, 0)
+	requireCached(t, cache, 2, true)
+	require.NoError(t, cache.Put(block(5)))
+	requireCached(t, cache, 2, false)
+	requireCached(t, cache, 3, true)
+	requireCached(t, cache, 5, true)
+
+	// Expired entries are evicted.
+	cache = newDiskCache(db, 3, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	requireCached(t, cache, 3, false)
+	require.NoError(t, cache.Put(block(6)))
+	requireCached(t, cache, 4, false)
+	requireCached(t, cache, 5, false)
+	require.Zero(t, cache.count)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+)
+
+// confirmationsEnvPrefix is the prefix of environment variables overriding the confirmations of a chain,
+// suffixed with the chain ID, e.g. XCHAIN_CONFIRMATIONS_1=12.
+const confirmationsEnvPrefix = "XCHAIN_CONFIRMATIONS_"
+
+// applyEnvConfirmations returns a copy of the per-chain options with the confirmations overridden by
+// environment variables, see confirmationsEnvPrefix. This is intended for operational firefighting, e.g.
+// temporarily bumping confirmations while debugging finality issues, without changing config files or flags.
+// Overrides take precedence over all configured chain options, see WithChainOptions.
+//
+// Malformed entries, non-positive confirmations and unknown chains are ignored with a warning.
+func applyEnvConfirmations(ctx context.Context, network netconf.Network, chainOpts map[uint64]ChainOptions, environ []string,
+) map[uint64]ChainOptions {
+	resp := make(map[uint64]ChainOptions, len(chainOpts))
+	for chainID, opts := range chainOpts {
+		resp[chainID] = opts
+	}
+
+	for _, kv := range environ {
+		key, value, _ := strings.Cut(kv, "=")
+		suffix, ok := strings.CutPrefix(key, confirmationsEnvPrefix)
+		if !ok {
+			continue
+		}
+
+		chainID, confs, err := parseEnvConfirmations(suffix, value)
+		if err != nil {
+			log.Warn(ctx, "Ignoring malformed confirmations override", err, "env", key, "value", value)
+			continue
+		}
+
+		chain, ok := network.Chain(chainID)
+		if !ok {
+			log.Warn(ctx, "Ignoring confirmations override of unknown chain", nil, "env", key, "chain_id", chainID)
+			continue
+		}
+
+		opts := resp[chainID]
+		log.Info(ctx, "Overriding chain confirmations from environment",
+			"chain", chain.Name,
+			"env", key,
+			"confirmations", confs,
+			"configured", opts.Confirmations,
+		)
+		opts.Confirmations = confs
+		resp[chainID] = opts
+	}
+
+	return resp
+}
+
+// parseEnvConfirmations returns the chain ID and confirmations of an override environment variable.
+func parseEnvConfirmations(chainIDStr string, value string) (uint64, uint64, error) {
+	chainID, err := strconv.ParseUint(chainIDStr, 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "parse chain ID")
+	}
+
+	confs, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "parse confirmations")
+	} else if confs == 0 {
+		return 0, 0, errors.New("confirmations not positive")
+	}
+
+	return chainID, confs, nil
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/netconf"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnvConfirmations(t *testing.T) {
+	t.Parallel()
+
+	network := netconf.Network{
+		ID:     netconf.Simnet,
+		Chains: []netconf.Chain{{ID: 1}, {ID: 2}, {ID: 3}},
+	}
+
+	configured := map[uint64]ChainOptions{
+		1: {Confirmations: 2, PollInterval: time.Second},
+		3: {Confirmations: 4},
+	}
+
+	resp := applyEnvConfirmations(context.Background(), network, configured, []string{
+		"HOME=/root",
+		"XCHAIN_CONFIRMATIONS_1=12",
+		"XCHAIN_CONFIRMATIONS_2= 6",
+		"XCHAIN_CONFIRMATIONS_3=0",     // Not positive
+		"XCHAIN_CONFIRMATIONS_4=8",     // Unknown chain
+		"XCHAIN_CONFIRMATIONS_X=8",     // Malformed chain ID
+		"XCHAIN_CONFIRMATIONS_3=many",  // Malformed confirmations
+		"XCHAIN_CONFIRMATIONS_3",       // Malformed entry
+		"OTHER_XCHAIN_CONFIRMATIONS_3", // Other prefix
+	})
+
+	require.Equal(t, map[uint64]ChainOptions{
+		1: {Confirmations: 12, PollInterval: time.Second},
+		2: {Confirmations: 6},
+		3: {Confirmations: 4},
+	}, resp)
+
+	// Configured options aren't modified.
+	require.Equal(t, uint64(2), configured[1].Confirmations)
+	require.NotContains(t, configured, uint64(2))
+}
@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/omni-network/omni/contracts/bindings"
 	"github.com/omni-network/omni/lib/cchain"
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tracer"
 	"github.com/omni-network/omni/lib/umath"
 	"github.com/omni-network/omni/lib/xchain"
@@ -34,17 +37,12 @@ func (p *Provider) ChainVersionHeight(ctx context.Context, chainVer xchain.Chain
 		return xblock.BlockHeight, nil
 	}
 
-	_, ethCl, err := p.getEVMChain(chainVer.ID)
+	chain, ethCl, err := p.getEVMChain(chainVer.ID)
 	if err != nil {
 		return 0, err
 	}
 
-	headType, ok := headTypeFromConfLevel(chainVer.ConfLevel)
-	if !ok {
-		return 0, errors.New("unsupported conf level")
-	}
-
-	header, err := ethCl.HeaderByType(ctx, headType)
+	header, err := headerByConfLevel(ctx, chain, ethCl, chainVer.ConfLevel)
 	if err != nil {
 		return 0, err
 	}
@@ -92,11 +90,11 @@ func (p *Provider) GetEmittedCursor(ctx context.Context, ref xchain.EmitRef, str
 	opts := &bind.CallOpts{Context: ctx}
 	if ref.Height != nil {
 		opts.BlockNumber = umath.NewBigInt(*ref.Height)
-	} else if head, ok := headTypeFromConfLevel(*ref.ConfLevel); !ok {
+	} else if _, ok := headTypeFromConfLevel(*ref.ConfLevel); !ok {
 		return xchain.EmitCursor{}, false, errors.New("invalid conf level")
 	} else {
 		// Populate an explicit block number if not querying latest head.
-		header, err := rpcClient.HeaderByType(ctx, head)
+		header, err := headerByConfLevel(ctx, chain, rpcClient, *ref.ConfLevel)
 		if err != nil {
 			return xchain.EmitCursor{}, false, err
 		}
@@ -163,10 +161,17 @@ func (p *Provider) GetSubmittedCursor(ctx context.Context, stream xchain.StreamI
 
 // GetBlock returns the XBlock for the provided chain and height, or false if not available yet (not finalized),
 // or an error.
+//
+// The msgs and receipts of EVM blocks are ordered by log index, i.e., the order they were emitted on-chain,
+// irrespective of RPC response order or concurrent decoding. So repeated fetches of a block are identical.
 func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xchain.Block, bool, error) {
 	ctx, span := tracer.Start(ctx, spanName("get_block"))
 	defer span.End()
 
+	if b, ok := p.getInjected(req.ChainID, req.Height); ok {
+		return b, true, nil
+	}
+
 	if req.ChainID == p.cChainID {
 		b, ok, err := p.cProvider.XBlock(ctx, req.Height, false)
 		if err != nil {
@@ -182,11 +187,22 @@ func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xc
 		return b, true, nil
 	}
 
-	_, ethCl, err := p.getEVMChain(req.ChainID)
+	chain, ethCl, err := p.getEVMChain(req.ChainID)
 	if err != nil {
 		return xchain.Block{}, false, err
 	}
 
+	// Latest blocks are only available once confirmed by the required number of blocks on top.
+	var confirmations uint64
+	if req.ConfLevel == xchain.ConfLatest {
+		confirmations = p.confirmations(req.ChainID)
+	}
+	available := req.Height + confirmations
+
+	if b, ok := p.getDiskCached(ctx, req); ok {
+		return b, true, nil
+	}
+
 	// An xblock is constructed from an eth header, and xmsg logs, and xreceipt logs.
 	var (
 		header   *types.Header
@@ -195,20 +211,24 @@ func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xc
 	)
 
 	// First check if height is confirmed.
-	if !p.confirmedCache(req.ChainVersion(), req.Height) {
-		// No higher cached header available, so fetch the latest head
+	if !p.confirmedCache(req.ChainVersion(), available) {
+		// No higher cached header available, so fetch the latest head.
+		// Note that head query failures must be returned as errors (not false),
+		// since false strictly means the head hasn't reached the height yet.
 		latest, err := p.headerByChainVersion(ctx, req.ChainVersion())
 		if err != nil {
-			return xchain.Block{}, false, errors.Wrap(err, "header by strategy")
+			return xchain.Block{}, false, errors.Wrap(err, "chain head unreachable")
 		}
 
-		// If still lower, we reached the head of the chain, return false
-		if latest.Number.Uint64() < req.Height {
-			return xchain.Block{}, false, nil
-		}
-
-		// Use this header if it matches height
-		if latest.Number.Uint64() == req.Height {
+		// If still lower, we reached the head of the chain (or the primary node lags), try the archive or return false.
+		if latest.Number.Uint64() < available {
+			archiveCl, ok := p.archiveFinalized(ctx, chain, req)
+			if !ok {
+				return xchain.Block{}, false, nil
+			}
+			ethCl = archiveCl
+		} else if latest.Number.Uint64() == req.Height {
+			// Use this header if it matches height
 			header = latest
 		}
 	}
@@ -218,6 +238,8 @@ func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xc
 		header, err = ethCl.HeaderByNumber(ctx, umath.NewBigInt(req.Height))
 		if err != nil {
 			return xchain.Block{}, false, errors.Wrap(err, "header by number")
+		} else if header == nil {
+			return xchain.Block{}, false, errors.New("nil header by number")
 		}
 	}
 
@@ -225,13 +247,13 @@ func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xc
 	var eg errgroup.Group
 	eg.Go(func() error {
 		var err error
-		msgs, err = p.getXMsgLogs(ctx, req.ChainID, header.Hash())
+		msgs, err = p.getXMsgLogs(ctx, ethCl, req.ChainID, req.Height, header.Hash())
 
 		return err
 	})
 	eg.Go(func() error {
 		var err error
-		receipts, err = p.getXReceiptLogs(ctx, req.ChainID, header.Hash())
+		receipts, err = p.getXReceiptLogs(ctx, ethCl, req.ChainID, req.Height, header.Hash())
 
 		return err
 	})
@@ -240,12 +262,18 @@ func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xc
 		return xchain.Block{}, false, errors.Wrap(err, "wait")
 	}
 
+	if p.opts.inclusionProofs {
+		if err := addInclusionProofs(ctx, ethCl, header, msgs); err != nil {
+			return xchain.Block{}, false, errors.Wrap(err, "inclusion proofs")
+		}
+	}
+
 	timeSecs, err := umath.ToInt64(header.Time)
 	if err != nil {
 		return xchain.Block{}, false, err
 	}
 
-	return xchain.Block{
+	block := xchain.Block{
 		BlockHeader: xchain.BlockHeader{
 			ChainID:     req.ChainID,
 			BlockHeight: req.Height,
@@ -255,19 +283,173 @@ func (p *Provider) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xc
 		Receipts:   receipts,
 		ParentHash: header.ParentHash,
 		Timestamp:  time.Unix(timeSecs, 0),
-	}, true, nil
+	}
+
+	p.putDiskCached(ctx, req, block)
+
+	return block, true, nil
+}
+
+// archiveFinalized returns the chain's archive client if the requested height is finalized according to the archive,
+// see WithArchiveClients. Only finalized requests are served by archives, since lagging primary nodes
+// can't confirm other conf levels. Archive errors are logged, since the primary node remains authoritative.
+func (p *Provider) archiveFinalized(ctx context.Context, chain netconf.Chain, req xchain.ProviderRequest) (ethclient.Client, bool) {
+	archiveCl, ok := p.opts.archiveClients[req.ChainID]
+	if !ok || req.ConfLevel != xchain.ConfFinalized {
+		return nil, false
+	}
+
+	// Note the archive finalized head isn't cached, since it must not affect primary node confirmations.
+	head, err := headerByConfLevel(ctx, chain, archiveCl, xchain.ConfFinalized)
+	if err != nil {
+		log.Warn(ctx, "Failed fetching archive finalized head (will retry)", err, "chain", chain.Name)
+		return nil, false
+	} else if head.Number.Uint64() < req.Height {
+		return nil, false // Not finalized by the archive either.
+	}
+
+	archiveFetches.WithLabelValues(p.network.ChainVersionName(req.ChainVersion())).Inc()
+
+	return archiveCl, true
+}
+
+// GetHeaders returns the headers of the provided EVM chain for the inclusive height range [from, to].
+// It verifies chain continuity, i.e., that each header's parent hash matches the previous header's hash.
+// It is a cheap alternative to GetBlock when only the headers are required, e.g. to detect reorgs
+// before fetching full blocks.
+//
+// Note that headers are fetched by number irrespective of confirmation level, so callers must
+// ensure the range is finalized if that is required.
+// Ranges larger than the configured maximum are refused, see WithMaxBlockRange and WithAllowLargeBlockRange.
+func (p *Provider) GetHeaders(ctx context.Context, chainID uint64, from uint64, to uint64) ([]xchain.Header, error) {
+	ctx, span := tracer.Start(ctx, spanName("get_headers"))
+	defer span.End()
+
+	if to < from {
+		return nil, errors.New("invalid block range", "from", from, "to", to)
+	} else if heights := to - from + 1; heights > p.opts.maxBlockRange && !p.opts.allowLargeBlockRange {
+		return nil, errors.New("block range exceeds maximum, fetch smaller chunks instead",
+			"from", from, "to", to, "heights", heights, "max", p.opts.maxBlockRange)
+	}
+
+	_, ethCl, err := p.getEVMChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]xchain.Header, 0, to-from+1)
+	for height := from; ; height++ {
+		header, err := ethCl.HeaderByNumber(ctx, umath.NewBigInt(height))
+		if err != nil {
+			return nil, errors.Wrap(err, "header by number", "height", height)
+		} else if header == nil {
+			return nil, errors.New("nil header by number", "height", height)
+		} else if header.Number == nil || header.Number.Uint64() != height {
+			return nil, errors.New("unexpected header height", "height", height)
+		}
+
+		if len(resp) > 0 && resp[len(resp)-1].BlockHash != header.ParentHash {
+			return nil, errors.New("header parent hash mismatch",
+				"height", height,
+				"parent", header.ParentHash,
+				"prev", resp[len(resp)-1].BlockHash,
+			)
+		}
+
+		timeSecs, err := umath.ToInt64(header.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, xchain.Header{
+			BlockHeader: xchain.BlockHeader{
+				ChainID:     chainID,
+				BlockHeight: height,
+				BlockHash:   header.Hash(),
+			},
+			ParentHash: header.ParentHash,
+			Timestamp:  time.Unix(timeSecs, 0),
+		})
+
+		if height == to { // Avoids overflow if to is MaxUint64
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+// BlockResult is the result of fetching a single height, see GetBlocksResults.
+type BlockResult struct {
+	Height uint64
+	Block  xchain.Block // Fetched block, only valid if Exists.
+	Exists bool         // False if the block doesn't exist yet (not finalized).
+	Err    error        // Fetch error of the height, if any.
+}
+
+// GetBlocksResults returns the finalized blocks of the chain at the provided heights, in the same order.
+// Unlike GetBlock, it doesn't abort on the first problem, rather each result reports whether its block
+// was fetched, doesn't exist yet, or failed, allowing tools like audits and gap-fillers to handle arbitrary height sets.
+// Heights are fetched concurrently by the chain's fetch workers, see ChainOptions.
+//
+// It only returns an error for invalid requests or if the context is canceled.
+// Unknown chains return an error wrapping ErrChainNotConfigured.
+// Requests with more heights than the configured maximum are refused, see WithMaxBlockRange and WithAllowLargeBlockRange.
+func (p *Provider) GetBlocksResults(ctx context.Context, chainID uint64, heights []uint64) ([]BlockResult, error) {
+	ctx, span := tracer.Start(ctx, spanName("get_blocks_results"))
+	defer span.End()
+
+	chain, err := p.configuredChain(chainID)
+	if err != nil {
+		return nil, err
+	} else if umath.Len(heights) > p.opts.maxBlockRange && !p.opts.allowLargeBlockRange {
+		return nil, errors.New("heights exceed maximum block range, fetch smaller chunks instead",
+			"heights", len(heights), "max", p.opts.maxBlockRange)
+	}
+
+	workers := p.chainOptions(chainID, chain.BlockPeriod).FetchWorkers
+	if workers == 0 {
+		return nil, errors.New("zero workers [BUG]")
+	}
+
+	resp := make([]BlockResult, len(heights))
+
+	var eg errgroup.Group
+	eg.SetLimit(int(workers)) //nolint:gosec // Fetch workers are small.
+	for i, height := range heights {
+		eg.Go(func() error {
+			req := xchain.ProviderRequest{ChainID: chainID, Height: height, ConfLevel: xchain.ConfFinalized}
+			block, exists, err := p.GetBlock(ctx, req)
+			resp[i] = BlockResult{Height: height, Block: block, Exists: exists, Err: err}
+
+			return nil // Errors are reported per height.
+		})
+	}
+	_ = eg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, errors.Wrap(ctx.Err(), "get blocks")
+	}
+
+	return resp, nil
 }
 
-func (p *Provider) getXReceiptLogs(ctx context.Context, chainID uint64, blockHash common.Hash) ([]xchain.Receipt, error) {
+func (p *Provider) getXReceiptLogs(ctx context.Context, rpcClient ethclient.Client, chainID uint64, height uint64, blockHash common.Hash,
+) ([]xchain.Receipt, error) {
 	ctx, span := tracer.Start(ctx, spanName("get_receipt_logs"))
 	defer span.End()
 
-	chain, rpcClient, err := p.getEVMChain(chainID)
+	chain, _, err := p.getEVMChain(chainID)
 	if err != nil {
 		return nil, errors.Wrap(err, "get evm chain")
 	}
 
-	logs, err := getLogs(ctx, rpcClient, chain.PortalAddress, blockHash, "XReceipt")
+	decoder, err := p.portalDecoder(chainID, height)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := getLogs(ctx, rpcClient, chain.PortalAddress, blockHash, decoder.XReceiptTopic())
 	if err != nil {
 		return nil, errors.Wrap(err, "get xreceipt logs")
 	}
@@ -277,56 +459,41 @@ func (p *Provider) getXReceiptLogs(ctx context.Context, chainID uint64, blockHas
 		expectedShards[uint64(stream.ShardID)] = true
 	}
 
-	filterer, err := bindings.NewOmniPortalFilterer(chain.PortalAddress, rpcClient)
+	receipts, err := decodeLogs(ctx, p.opts.decodeWorkers, logs, func(l types.Log) (xchain.Receipt, error) {
+		return decoder.DecodeXReceipt(chain.ID, l)
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "new filterer")
+		return nil, err
 	}
 
-	var receipts []xchain.Receipt
-	for _, xreceiptLog := range logs {
-		e, err := filterer.ParseXReceipt(xreceiptLog)
-		if err != nil {
-			return nil, errors.Wrap(err, "parse xreceipt log")
-		}
-
-		if !expectedShards[e.ShardId] {
+	for _, receipt := range receipts {
+		if !expectedShards[uint64(receipt.ShardID)] {
 			return nil, errors.New("unexpected receipt shard",
-				"shard", e.ShardId,
-				"src_chain", e.SourceChainId,
-				"expected", p.network.StreamsBetween(e.SourceChainId, chainID),
+				"shard", receipt.ShardID,
+				"src_chain", receipt.SourceChainID,
+				"expected", p.network.StreamsBetween(receipt.SourceChainID, chainID),
 			)
 		}
-
-		receipts = append(receipts, xchain.Receipt{
-			MsgID: xchain.MsgID{
-				StreamID: xchain.StreamID{
-					SourceChainID: e.SourceChainId,
-					DestChainID:   chain.ID,
-					ShardID:       xchain.ShardID(e.ShardId),
-				},
-				StreamOffset: e.Offset,
-			},
-			GasUsed:        e.GasUsed.Uint64(),
-			Success:        e.Success,
-			Error:          e.Err,
-			RelayerAddress: e.Relayer,
-			TxHash:         e.Raw.TxHash,
-		})
 	}
 
 	return receipts, nil
 }
 
-func (p *Provider) getXMsgLogs(ctx context.Context, chainID uint64, blockHash common.Hash) ([]xchain.Msg, error) {
+func (p *Provider) getXMsgLogs(ctx context.Context, rpcClient ethclient.Client, chainID uint64, height uint64, blockHash common.Hash) ([]xchain.Msg, error) {
 	ctx, span := tracer.Start(ctx, spanName("get_msg_logs"))
 	defer span.End()
 
-	chain, rpcClient, err := p.getEVMChain(chainID)
+	chain, _, err := p.getEVMChain(chainID)
 	if err != nil {
 		return nil, errors.Wrap(err, "get evm chain")
 	}
 
-	logs, err := getLogs(ctx, rpcClient, chain.PortalAddress, blockHash, "XMsg")
+	decoder, err := p.portalDecoder(chainID, height)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := getLogs(ctx, rpcClient, chain.PortalAddress, blockHash, decoder.XMsgTopic())
 	if err != nil {
 		return nil, errors.Wrap(err, "get xmsg logs")
 	}
@@ -336,41 +503,44 @@ func (p *Provider) getXMsgLogs(ctx context.Context, chainID uint64, blockHash co
 		expectedShards[uint64(shard)] = true
 	}
 
-	filterer, err := bindings.NewOmniPortalFilterer(chain.PortalAddress, rpcClient)
+	xmsgs, err := decodeLogs(ctx, p.opts.decodeWorkers, logs, func(l types.Log) (xchain.Msg, error) {
+		return decoder.DecodeXMsg(chain.ID, l)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var xmsgs []xchain.Msg
-	for _, xmsgLog := range logs {
-		e, err := filterer.ParseXMsg(xmsgLog)
-		if err != nil {
-			return nil, errors.Wrap(err, "parse xmsg log")
+	for _, msg := range xmsgs {
+		if !expectedShards[uint64(msg.ShardID)] {
+			return nil, errors.New("unexpected xmsg shard", "shard", msg.ShardID)
 		}
+	}
 
-		if !expectedShards[e.ShardId] {
-			return nil, errors.New("unexpected xmsg shard", "shard", e.ShardId)
+	if err := p.verifyMsgPayloads(chain.Name, xmsgs); err != nil {
+		return nil, err
+	}
+
+	return xmsgs, nil
+}
+
+// verifyMsgPayloads returns an error if any xmsg payload exceeds the maximum, see WithMaxMsgPayloadBytes.
+func (p *Provider) verifyMsgPayloads(chainName string, xmsgs []xchain.Msg) error {
+	maxBytes := p.opts.maxMsgPayloadBytes
+	if maxBytes == 0 {
+		return nil
+	}
+
+	for _, msg := range xmsgs {
+		if uint64(len(msg.Data)) <= maxBytes {
+			continue
 		}
 
-		xmsgs = append(xmsgs, xchain.Msg{
-			MsgID: xchain.MsgID{
-				StreamID: xchain.StreamID{
-					SourceChainID: chain.ID,
-					DestChainID:   e.DestChainId,
-					ShardID:       xchain.ShardID(e.ShardId),
-				},
-				StreamOffset: e.Offset,
-			},
-			SourceMsgSender: e.Sender,
-			DestAddress:     e.To,
-			Data:            e.Data,
-			DestGasLimit:    e.GasLimit,
-			TxHash:          e.Raw.TxHash,
-			Fees:            e.Fees,
-		})
+		oversizedMsgs.WithLabelValues(chainName).Inc()
+
+		return errors.New("xmsg payload exceeds maximum", "id", msg.MsgID, "size", len(msg.Data), "max", maxBytes, "tx", msg.TxHash)
 	}
 
-	return xmsgs, nil
+	return nil
 }
 
 // GetSubmission returns the submission associated with the transaction hash or an error.
@@ -385,12 +555,12 @@ func (p *Provider) GetSubmission(ctx context.Context, chainID uint64, txHash com
 		return xchain.Submission{}, errors.Wrap(err, "tx by hash")
 	}
 
-	sub, err := xchain.DecodeXSubmit(tx.Data())
+	sub, err := xchain.DecodeSubmission(tx.Data(), chain.ID)
 	if err != nil {
 		return xchain.Submission{}, errors.Wrap(err, "decode xsubmit")
 	}
 
-	return xchain.SubmissionFromBinding(sub, chain.ID), nil
+	return sub, nil
 }
 
 // confirmedCache returns true if the height is confirmedCache based on the chain version
@@ -405,26 +575,23 @@ func (p *Provider) confirmedCache(chainVer xchain.ChainVersion, height uint64) b
 // headerByChainVersion returns the chain's header by confirmation level (finalization/latest)
 // by querying via ethclient. It caches the result.
 func (p *Provider) headerByChainVersion(ctx context.Context, chainVer xchain.ChainVersion) (*types.Header, error) {
-	_, rpcClient, err := p.getEVMChain(chainVer.ID)
+	chain, rpcClient, err := p.getEVMChain(chainVer.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	headType, ok := headTypeFromConfLevel(chainVer.ConfLevel)
-	if !ok {
-		return nil, errors.New("unsupported conf level")
-	}
-
 	// Fetch the header from the ethclient
-	header, err := rpcClient.HeaderByType(ctx, headType)
+	header, err := headerByConfLevel(ctx, chain, rpcClient, chainVer.ConfLevel)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update the strategy cache
 	p.mu.Lock()
-	defer p.mu.Unlock()
 	p.confHeads[chainVer] = header.Number.Uint64()
+	p.mu.Unlock()
+
+	p.saveHead(ctx, chainVer, header.Number.Uint64())
 
 	return header, nil
 }
@@ -468,20 +635,33 @@ func headTypeFromConfLevel(conf xchain.ConfLevel) (ethclient.HeadType, bool) {
 	}
 }
 
-func getLogs(ctx context.Context, rpcClient ethclient.Client, contractAddr common.Address, blockHash common.Hash, topicName string) ([]types.Log, error) {
-	portalAbi, err := bindings.OmniPortalMetaData.GetAbi()
-	if err != nil {
-		return nil, errors.Wrap(err, "get abi")
-	}
-
+func getLogs(ctx context.Context, rpcClient ethclient.Client, contractAddr common.Address, blockHash common.Hash, topic common.Hash) ([]types.Log, error) {
 	logs, err := rpcClient.FilterLogs(ctx, ethereum.FilterQuery{
 		BlockHash: &blockHash,
 		Addresses: []common.Address{contractAddr},
-		Topics:    [][]common.Hash{{portalAbi.Events[topicName].ID}},
+		Topics:    [][]common.Hash{{topic}},
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "filter xreceipt logs")
 	}
 
+	// Reject inconsistent responses of buggy or lagging RPC nodes serving stitched-together data.
+	// This is treated as a fetch error, so the block is refetched.
+	for _, l := range logs {
+		if l.BlockHash != blockHash {
+			return nil, errors.New("inconsistent rpc response: log block hash mismatch",
+				"expected", blockHash, "actual", l.BlockHash, "tx", l.TxHash)
+		} else if l.Address != contractAddr {
+			return nil, errors.New("inconsistent rpc response: log address mismatch",
+				"expected", contractAddr, "actual", l.Address, "tx", l.TxHash)
+		}
+	}
+
+	// Sort by log index, since RPC response order isn't guaranteed, see GetBlock.
+	// Log indexes are unique per block, so this is the canonical on-chain (and therefore offset) order.
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Index < logs[j].Index
+	})
+
 	return logs, nil
 }
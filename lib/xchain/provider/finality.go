@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/umath"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// headerByConfLevel returns the chain's head header of the confirmation level.
+// Finalized heads are resolved as per the chain's finality tag, see netconf.ParseFinalityTag.
+func headerByConfLevel(ctx context.Context, chain netconf.Chain, ethCl ethclient.Client, conf xchain.ConfLevel) (*types.Header, error) {
+	headType, ok := headTypeFromConfLevel(conf)
+	if !ok {
+		return nil, errors.New("unsupported conf level")
+	}
+
+	var depth uint64
+	if conf == xchain.ConfFinalized {
+		tag, d, err := netconf.ParseFinalityTag(chain.FinalityTag)
+		if err != nil {
+			return nil, err
+		} else if d > 0 {
+			headType, depth = ethclient.HeadLatest, d
+		} else {
+			headType = ethclient.HeadType(tag)
+		}
+	}
+
+	header, err := ethCl.HeaderByType(ctx, headType)
+	if err != nil {
+		return nil, err
+	} else if header == nil || header.Number == nil {
+		return nil, errors.New("nil head header")
+	} else if depth == 0 {
+		return header, nil
+	}
+
+	height, ok := umath.Subtract(header.Number.Uint64(), depth)
+	if !ok {
+		return nil, errors.New("chain shorter than finality confirmation depth", "head", header.Number, "depth", depth)
+	}
+
+	header, err = ethCl.HeaderByNumber(ctx, umath.NewBigInt(height))
+	if err != nil {
+		return nil, errors.Wrap(err, "header by number")
+	} else if header == nil || header.Number == nil {
+		return nil, errors.New("nil head header")
+	}
+
+	return header, nil
+}
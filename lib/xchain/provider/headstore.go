@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+// headStore persists the last observed head height per chain version across restarts, see WithHeadStore.
+// All methods are nil-safe, so a nil store (disabled) doesn't persist anything.
+type headStore struct {
+	db dbm.DB
+
+	once    sync.Once
+	loaded  map[xchain.ChainVersion]uint64 // Heads persisted by previous runs.
+	loadErr error
+}
+
+// newHeadStore returns a new head store backed by the provided DB, or nil if the DB is nil (disabled).
+func newHeadStore(db dbm.DB) *headStore {
+	if db == nil {
+		return nil
+	}
+
+	return &headStore{db: db}
+}
+
+// Get returns the head height of the chain version persisted by previous runs, or false if none.
+func (s *headStore) Get(chainVer xchain.ChainVersion) (uint64, bool, error) {
+	if s == nil {
+		return 0, false, nil
+	}
+
+	s.once.Do(func() {
+		s.loaded, s.loadErr = s.load()
+	})
+	if s.loadErr != nil {
+		return 0, false, s.loadErr
+	}
+
+	head, ok := s.loaded[chainVer]
+
+	return head, ok, nil
+}
+
+// Save persists the head height of the chain version.
+func (s *headStore) Save(chainVer xchain.ChainVersion, height uint64) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.db.Set(headKey(chainVer), binary.BigEndian.AppendUint64(nil, height)); err != nil {
+		return errors.Wrap(err, "set head")
+	}
+
+	return nil
+}
+
+func (s *headStore) load() (map[xchain.ChainVersion]uint64, error) {
+	iter, err := s.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "head iterator")
+	}
+	defer iter.Close()
+
+	resp := make(map[xchain.ChainVersion]uint64)
+	for ; iter.Valid(); iter.Next() {
+		key, value := iter.Key(), iter.Value()
+		if len(key) != 9 || len(value) != 8 {
+			return nil, errors.New("invalid persisted head", "key_len", len(key), "value_len", len(value))
+		}
+
+		chainVer := xchain.ChainVersion{
+			ID:        binary.BigEndian.Uint64(key[:8]),
+			ConfLevel: xchain.ConfLevel(key[8]),
+		}
+		resp[chainVer] = binary.BigEndian.Uint64(value)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "iterate heads")
+	}
+
+	return resp, nil
+}
+
+// headKey returns the DB key of the chain version: 8 bytes chain ID followed by the conf level byte.
+func headKey(chainVer xchain.ChainVersion) []byte {
+	return append(binary.BigEndian.AppendUint64(nil, chainVer.ID), byte(chainVer.ConfLevel))
+}
+
+// HeadEstimate returns the latest observed head height of the chain version, or false if unknown.
+// Before the first head query of this process, it falls back to the head persisted by previous runs
+// if a head store is configured, see WithHeadStore. The estimate is advisory, e.g. for lag metrics,
+// since it may be outdated. It doesn't query the chain, see ChainVersionHeight.
+func (p *Provider) HeadEstimate(ctx context.Context, chainVer xchain.ChainVersion) (uint64, bool) {
+	p.mu.Lock()
+	head, ok := p.confHeads[chainVer]
+	p.mu.Unlock()
+	if ok {
+		return head, true
+	}
+
+	head, ok, err := p.heads.Get(chainVer)
+	if err != nil {
+		log.Warn(ctx, "Failed loading persisted heads (will ignore)", err)
+		return 0, false
+	}
+
+	return head, ok
+}
+
+// saveHead persists the observed head height of the chain version, logging errors since persisted heads are advisory.
+func (p *Provider) saveHead(ctx context.Context, chainVer xchain.ChainVersion, height uint64) {
+	if err := p.heads.Save(chainVer, height); err != nil {
+		log.Warn(ctx, "Failed persisting head (will ignore)", err, "chain", p.network.ChainVersionName(chainVer))
+	}
+}
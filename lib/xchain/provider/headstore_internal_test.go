@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := dbm.NewMemDB()
+	finalized := xchain.ChainVersion{ID: 1, ConfLevel: xchain.ConfFinalized}
+	latest := xchain.ChainVersion{ID: 1, ConfLevel: xchain.ConfLatest}
+
+	// First run observes heads.
+	p1 := &Provider{heads: newHeadStore(db), confHeads: make(map[xchain.ChainVersion]uint64)}
+	_, ok := p1.HeadEstimate(ctx, finalized)
+	require.False(t, ok)
+	p1.saveHead(ctx, finalized, 100)
+	p1.saveHead(ctx, latest, 110)
+	p1.saveHead(ctx, finalized, 105)
+
+	// Restart reuses persisted heads as estimates.
+	p2 := &Provider{heads: newHeadStore(db), confHeads: make(map[xchain.ChainVersion]uint64)}
+	head, ok := p2.HeadEstimate(ctx, finalized)
+	require.True(t, ok)
+	require.Equal(t, uint64(105), head)
+	head, ok = p2.HeadEstimate(ctx, latest)
+	require.True(t, ok)
+	require.Equal(t, uint64(110), head)
+	_, ok = p2.HeadEstimate(ctx, xchain.ChainVersion{ID: 2, ConfLevel: xchain.ConfFinalized})
+	require.False(t, ok)
+
+	// Observed heads are preferred over persisted heads.
+	p2.confHeads[finalized] = 120
+	head, ok = p2.HeadEstimate(ctx, finalized)
+	require.True(t, ok)
+	require.Equal(t, uint64(120), head)
+
+	// Disabled store is nil-safe.
+	p3 := &Provider{confHeads: make(map[xchain.ChainVersion]uint64)}
+	p3.saveHead(ctx, finalized, 100)
+	_, ok = p3.HeadEstimate(ctx, finalized)
+	require.False(t, ok)
+}
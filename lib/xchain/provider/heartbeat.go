@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// HeartbeatFunc is called with the current chain head height when a stream didn't deliver any block
+// within the heartbeat interval, see WithHeartbeat.
+type HeartbeatFunc func(ctx context.Context, chainVer xchain.ChainVersion, head uint64)
+
+// heartbeat tracks the last block delivery of a stream.
+type heartbeat struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Delivered records a block delivery. It is nil-safe, so streams without heartbeats don't track deliveries.
+func (h *heartbeat) Delivered() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = time.Now()
+}
+
+// idle returns the duration since the last delivery or heartbeat.
+func (h *heartbeat) idle() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return time.Since(h.last)
+}
+
+// startHeartbeat starts a goroutine calling the heartbeat function whenever the stream didn't deliver
+// any block within the heartbeat interval. It returns a nil heartbeat if disabled.
+// The goroutine stops when the context is canceled.
+func (p *Provider) startHeartbeat(ctx context.Context, chainVer xchain.ChainVersion) *heartbeat {
+	interval := p.opts.heartbeatInterval
+	if interval == 0 || p.opts.heartbeatFunc == nil {
+		return nil
+	}
+
+	h := &heartbeat{last: time.Now()}
+
+	go func() {
+		ticker := time.NewTicker(interval / 4)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if h.idle() < interval {
+				continue
+			}
+
+			head, err := p.ChainVersionHeight(ctx, chainVer)
+			if ctx.Err() != nil {
+				return
+			} else if err != nil {
+				log.Warn(ctx, "Failed fetching stream heartbeat head (will retry)", err)
+				continue
+			}
+
+			p.opts.heartbeatFunc(ctx, chainVer, head)
+			h.Delivered() // Next heartbeat after another interval.
+		}
+	}()
+
+	return h
+}
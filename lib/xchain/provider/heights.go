@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/stream"
+	"github.com/omni-network/omni/lib/tracer"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderCallback is called for each streamed header, see StreamHeights.
+type HeaderCallback func(ctx context.Context, header xchain.Header) error
+
+// StreamHeights blocks, streaming the finalized headers (height and block hash) of the EVM chain from the height,
+// without constructing full xblocks, i.e., without fetching and decoding any msgs or receipts.
+// It is intended for lightweight progress tracking consumers that don't require message content.
+//
+// Like StreamBlocks, it retries fetch errors forever, returns callback errors, and returns nil
+// when the context is canceled. The consensus chain isn't supported.
+func (p *Provider) StreamHeights(ctx context.Context, chainID uint64, fromHeight uint64, callback HeaderCallback) error {
+	chain, err := p.configuredChain(chainID)
+	if err != nil {
+		return err
+	} else if chainID == p.cChainID {
+		return errors.New("consensus chain heights not supported")
+	}
+
+	chainVer := xchain.ChainVersion{ID: chainID, ConfLevel: xchain.ConfFinalized}
+	chainVersionName := p.network.ChainVersionName(chainVer)
+	meta, err := p.streamMeta(ctx)
+	if err != nil {
+		return err
+	}
+	chainOpts := p.chainOptions(chainID, chain.BlockPeriod)
+	fromHeight = p.startHeight(ctx, chain, fromHeight)
+
+	deps := stream.Deps[xchain.Header]{
+		FetchWorkers: chainOpts.FetchWorkers,
+		FetchBatch: func(ctx context.Context, chainID uint64, height uint64) ([]xchain.Header, error) {
+			// Only finalized heights are streamed.
+			if !p.confirmedCache(chainVer, height) {
+				latest, err := p.headerByChainVersion(ctx, chainVer)
+				if err != nil {
+					return nil, errors.Wrap(err, "chain head unreachable")
+				} else if latest.Number.Uint64() < height {
+					return nil, nil // Stream backs off
+				}
+			}
+
+			return p.GetHeaders(ctx, chainID, height, height)
+		},
+		Backoff:       chainOpts.Backoff,
+		ElemLabel:     "header",
+		HeightLabel:   "height",
+		RetryCallback: false,
+		Height: func(header xchain.Header) uint64 {
+			return header.BlockHeight
+		},
+		Verify: func(_ context.Context, header xchain.Header, h uint64) error {
+			if header.ChainID != chainID {
+				return errors.New("invalid header source chain id")
+			} else if header.BlockHeight != h {
+				return errors.New("invalid header height")
+			}
+
+			return nil
+		},
+		IncFetchErr: func() {
+			fetchErrTotal.WithLabelValues(chainVersionName).Inc()
+		},
+		IncCallbackErr: func() {
+			callbackErrTotal.WithLabelValues(chainVersionName).Inc()
+		},
+		SetStreamHeight: func(h uint64) {
+			streamHeight.WithLabelValues(chainVersionName).Set(float64(h))
+		},
+		SetCallbackLatency: func(d time.Duration) {
+			callbackLatency.WithLabelValues(chainVersionName).Observe(d.Seconds())
+		},
+		StartTrace: func(ctx context.Context, height uint64, spanName string) (context.Context, trace.Span) {
+			return tracer.StartChainHeight(ctx, p.network.ID, chain.Name, height, path.Join("xprovider_heights", spanName))
+		},
+	}
+
+	ctx = log.WithCtx(ctx, "chain", chainVersionName)
+	ctx = log.WithCtx(ctx, meta.attrs...)
+	log.Info(ctx, "Streaming xprovider heights", "from_height", fromHeight)
+
+	return stream.Stream(ctx, deps, chainID, fromHeight, stream.Callback[xchain.Header](callback))
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// injectKey identifies an injected block.
+type injectKey struct {
+	ChainID uint64
+	Height  uint64
+}
+
+// InjectBlock injects the block for the chain, so it is returned by GetBlock (and streams) as if it was fetched,
+// bypassing RPC and availability checks, for all confirmation levels. Injecting a block at the same height replaces it.
+// This allows driving consumer logic with crafted blocks in tests or replays.
+//
+// It returns an error unless block injection is enabled, see WithBlockInjection.
+func (p *Provider) InjectBlock(chainID uint64, block xchain.Block) error {
+	if !p.opts.allowInjection {
+		return errors.New("block injection disabled")
+	} else if block.ChainID != chainID {
+		return errors.New("injected block chain mismatch", "chain_id", chainID, "block_chain_id", block.ChainID)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.injected == nil {
+		p.injected = make(map[injectKey]xchain.Block)
+	}
+	p.injected[injectKey{ChainID: chainID, Height: block.BlockHeight}] = block
+
+	return nil
+}
+
+// getInjected returns the injected block for the chain and height or false if none.
+func (p *Provider) getInjected(chainID uint64, height uint64) (xchain.Block, bool) {
+	if !p.opts.allowInjection {
+		return xchain.Block{}, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	block, ok := p.injected[injectKey{ChainID: chainID, Height: height}]
+
+	return block, ok
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultMaxLookahead is the default maximum number of heights fetched ahead of the stream's cursor.
+// It is large enough to not limit concurrent fetching, while bounding wasted work on consumer rollback.
+const defaultMaxLookahead = 256
+
+// lookahead bounds the number of heights a stream fetches ahead of its cursor, see WithMaxLookahead.
+// The cursor is the consumer's committed height if a CommitTracker is attached, otherwise the delivered height.
+// A nil lookahead is unlimited.
+type lookahead struct {
+	max        uint64
+	fromHeight uint64
+	tracker    *CommitTracker // Nil if not attached
+
+	mu     sync.Mutex
+	next   uint64        // Next height to deliver
+	notify chan struct{} // Closed and replaced on each delivery
+}
+
+// newLookahead returns a new lookahead starting at fromHeight or nil if max is zero (unlimited).
+func newLookahead(max uint64, fromHeight uint64, tracker *CommitTracker) *lookahead {
+	if max == 0 {
+		return nil
+	}
+
+	return &lookahead{
+		max:        max,
+		fromHeight: fromHeight,
+		tracker:    tracker,
+		next:       fromHeight,
+		notify:     make(chan struct{}),
+	}
+}
+
+// Delivered records the delivery of the height, unblocking waiting fetches.
+func (l *lookahead) Delivered(height uint64) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if height < l.next {
+		return
+	}
+
+	l.next = height + 1
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// cursor returns the next height of the stream's cursor and a channel closed on the next delivery.
+func (l *lookahead) cursor() (uint64, <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := l.next
+	if l.tracker != nil {
+		next = min(next, l.tracker.next(l.fromHeight))
+	}
+
+	return next, l.notify
+}
+
+// Wait blocks while the height is max or more heights ahead of the stream's cursor.
+func (l *lookahead) Wait(ctx context.Context, height uint64) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		next, notify := l.cursor()
+		if height < next || height-next < l.max {
+			return nil
+		}
+
+		// Commits aren't notified, so poll if a commit tracker is attached.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		case <-time.After(commitPollPeriod):
+		}
+	}
+}
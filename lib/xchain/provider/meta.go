@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/omni-network/omni/lib/errors"
+)
+
+const (
+	// maxMetaPairs is the maximum number of key-value pairs per stream metadata.
+	maxMetaPairs = 4
+	// maxMetaLabels is the maximum number of distinct stream metadata metric labels per provider.
+	maxMetaLabels = 32
+)
+
+var metaRegex = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+type metaKey struct{}
+
+// streamMeta is validated stream metadata.
+type streamMeta struct {
+	attrs []any  // Log attributes
+	label string // Metric label value
+}
+
+// WithStreamMeta returns a copy of the context with the provided stream metadata attached.
+// Streams started with the context include the metadata in all logs, in the "meta" label
+// of the stream_meta_info metric and of the per-stream delivery metrics (e.g. blocks_delivered_total)
+// as sorted comma-separated "key=value" pairs.
+//
+// Metadata keys and values must match [a-z0-9_-]{1,32} and at most 4 pairs are allowed.
+// To bound metric cardinality, streams with more than 32 distinct metadata sets per provider return an error.
+func WithStreamMeta(ctx context.Context, kvs map[string]string) (context.Context, error) {
+	if len(kvs) > maxMetaPairs {
+		return nil, errors.New("too many stream metadata pairs", "max", maxMetaPairs)
+	}
+
+	keys := make([]string, 0, len(kvs))
+	for k, v := range kvs {
+		if !metaRegex.MatchString(k) {
+			return nil, errors.New("invalid stream metadata key", "key", k)
+		} else if !metaRegex.MatchString(v) {
+			return nil, errors.New("invalid stream metadata value", "key", k, "value", v)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var meta streamMeta
+	var pairs []string
+	for _, k := range keys {
+		meta.attrs = append(meta.attrs, k, kvs[k])
+		pairs = append(pairs, k+"="+kvs[k])
+	}
+	meta.label = strings.Join(pairs, ",")
+
+	return context.WithValue(ctx, metaKey{}, meta), nil
+}
+
+// metaRegistry tracks the distinct stream metadata metric labels of a provider, ensuring bounded cardinality.
+type metaRegistry struct {
+	mu     sync.Mutex
+	labels map[string]bool
+}
+
+// Register registers the metric label, returning an error if the max is exceeded.
+// The empty label of streams without metadata isn't counted.
+func (r *metaRegistry) Register(label string) error {
+	if label == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.labels[label] {
+		return nil
+	} else if len(r.labels) >= maxMetaLabels {
+		return errors.New("too many distinct stream metadata sets", "max", maxMetaLabels)
+	}
+
+	if r.labels == nil {
+		r.labels = make(map[string]bool)
+	}
+	r.labels[label] = true
+
+	return nil
+}
+
+// streamMeta returns the stream metadata attached to the context after registering its metric label.
+func (p *Provider) streamMeta(ctx context.Context) (streamMeta, error) {
+	meta := metaFromCtx(ctx)
+	if err := p.metaLabels.Register(meta.label); err != nil {
+		return streamMeta{}, err
+	}
+
+	return meta, nil
+}
+
+// metaFromCtx returns the stream metadata attached to the context or empty metadata.
+func metaFromCtx(ctx context.Context) streamMeta {
+	meta, _ := ctx.Value(metaKey{}).(streamMeta)
+	return meta
+}
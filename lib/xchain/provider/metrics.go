@@ -27,6 +27,13 @@ var (
 		Help:      "Latest streamed xblock height per source chain version. Alert if not growing.",
 	}, []string{"chain_version"})
 
+	streamHeadLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "stream_head_lag",
+		Help:      "Number of heights the stream lags behind the estimated chain version head, see HeadEstimate. Alert if growing.",
+	}, []string{"chain_version", "meta"})
+
 	callbackLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "lib",
 		Subsystem: "xprovider",
@@ -34,4 +41,97 @@ var (
 		Help:      "Callback latency in seconds per source chain version. Alert if growing.",
 		Buckets:   []float64{.001, .002, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
 	}, []string{"chain_version"})
+
+	streamMetaInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "stream_meta_info",
+		Help:      "Constant 1 per source chain version and metadata of streams started with metadata, see WithStreamMeta.",
+	}, []string{"chain_version", "meta"})
+
+	blockSizeBytes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "block_size_bytes",
+		Help:      "Encoded size in bytes of delivered xblocks per source chain version, see encodedBlockSize.",
+		Buckets:   prometheus.ExponentialBuckets(256, 4, 8), // 256B to 4MB
+	}, []string{"chain_version", "meta"})
+
+	blocksFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "blocks_fetched_total",
+		Help:      "Total number of xblocks fetched by streams per source chain version, including refetches.",
+	}, []string{"chain_version", "meta"})
+
+	blocksDelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "blocks_delivered_total",
+		Help:      "Total number of xblocks delivered to stream callbacks per source chain version.",
+	}, []string{"chain_version", "meta"})
+
+	msgsFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "msgs_fetched_total",
+		Help:      "Total number of xmsgs in xblocks fetched by streams per source chain version, before enrichment.",
+	}, []string{"chain_version", "meta"})
+
+	msgsDelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "msgs_delivered_total",
+		Help:      "Total number of xmsgs in xblocks delivered to stream callbacks per source chain version. Compare with fetched to quantify filtering.",
+	}, []string{"chain_version", "meta"})
+
+	inflightBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "inflight_bytes",
+		Help:      "Estimated bytes of fetched but unprocessed xblocks. Only populated if a max in-flight budget is configured.",
+	})
+
+	watchdogRestarts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "stream_watchdog_restarts_total",
+		Help:      "Total number of async streams restarted by the watchdog per source chain version. Alert if growing.",
+	}, []string{"chain_version", "meta"})
+
+	reorgTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "reorg_total",
+		Help:      "Total number of reorgs detected by streams per source chain version. Only populated if reorg detection is enabled.",
+	}, []string{"chain_version"})
+
+	reorgDepth = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "reorg_depth",
+		Help:      "Depth of reorgs detected by streams per source chain version. Only populated if reorg detection is enabled.",
+		Buckets:   []float64{0, 1, 2, 4, 8, 16, 32, 64},
+	}, []string{"chain_version"})
+
+	diskCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "disk_cache_lookups_total",
+		Help:      "Total number of xblock disk cache lookups per source chain version and result (hit/miss). Only populated if a disk cache is configured.",
+	}, []string{"chain_version", "result"})
+
+	archiveFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "archive_fetches_total",
+		Help:      "Total number of finalized xblocks fetched from archive nodes while the primary node lagged per source chain version. Only populated if archive clients are configured.",
+	}, []string{"chain_version"})
+
+	oversizedMsgs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider",
+		Name:      "oversized_msgs_total",
+		Help:      "Total number of fetched xmsgs exceeding the maximum payload size per source chain. Oversized xmsgs fail block fetches, stalling the stream. Only populated if a maximum is configured. Alert if growing.",
+	}, []string{"chain"})
 )
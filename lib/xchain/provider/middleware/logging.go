@@ -0,0 +1,101 @@
+// Package middleware provides reference xchain.Provider decorators, see xchain.ProviderMiddleware.
+//
+// Decorators embed the decorated provider and only override the methods they instrument,
+// so they are composable in any order via xchain.DecorateProvider, e.g.:
+//
+//	xprov = xchain.DecorateProvider(xprov, middleware.Logging(), middleware.Metrics())
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Logging returns a middleware that logs all provider calls at debug level, and failed calls at warn level.
+// Note that async streams are logged when started, not when they stop.
+func Logging() xchain.ProviderMiddleware {
+	return func(p xchain.Provider) xchain.Provider {
+		return logging{Provider: p}
+	}
+}
+
+var _ xchain.Provider = logging{}
+
+type logging struct {
+	xchain.Provider
+}
+
+func (l logging) StreamAsync(ctx context.Context, req xchain.ProviderRequest, callback xchain.ProviderCallback) error {
+	t0 := time.Now()
+	err := l.Provider.StreamAsync(ctx, req, callback)
+	logCall(ctx, "StreamAsync", t0, err, reqAttrs(req)...)
+
+	return err
+}
+
+func (l logging) StreamBlocks(ctx context.Context, req xchain.ProviderRequest, callback xchain.ProviderCallback) error {
+	t0 := time.Now()
+	err := l.Provider.StreamBlocks(ctx, req, callback)
+	logCall(ctx, "StreamBlocks", t0, err, reqAttrs(req)...)
+
+	return err
+}
+
+func (l logging) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xchain.Block, bool, error) {
+	t0 := time.Now()
+	block, ok, err := l.Provider.GetBlock(ctx, req)
+	logCall(ctx, "GetBlock", t0, err, append(reqAttrs(req), "found", ok)...)
+
+	return block, ok, err
+}
+
+func (l logging) GetSubmittedCursor(ctx context.Context, stream xchain.StreamID) (xchain.SubmitCursor, bool, error) {
+	t0 := time.Now()
+	cursor, ok, err := l.Provider.GetSubmittedCursor(ctx, stream)
+	logCall(ctx, "GetSubmittedCursor", t0, err, "stream", stream, "found", ok)
+
+	return cursor, ok, err
+}
+
+func (l logging) GetEmittedCursor(ctx context.Context, ref xchain.EmitRef, stream xchain.StreamID) (xchain.EmitCursor, bool, error) {
+	t0 := time.Now()
+	cursor, ok, err := l.Provider.GetEmittedCursor(ctx, ref, stream)
+	logCall(ctx, "GetEmittedCursor", t0, err, "stream", stream, "found", ok)
+
+	return cursor, ok, err
+}
+
+func (l logging) ChainVersionHeight(ctx context.Context, chainVer xchain.ChainVersion) (uint64, error) {
+	t0 := time.Now()
+	height, err := l.Provider.ChainVersionHeight(ctx, chainVer)
+	logCall(ctx, "ChainVersionHeight", t0, err, "chain_id", chainVer.ID, "conf", chainVer.ConfLevel, "height", height)
+
+	return height, err
+}
+
+func (l logging) GetSubmission(ctx context.Context, chainID uint64, txHash common.Hash) (xchain.Submission, error) {
+	t0 := time.Now()
+	sub, err := l.Provider.GetSubmission(ctx, chainID, txHash)
+	logCall(ctx, "GetSubmission", t0, err, "chain_id", chainID, "tx", txHash)
+
+	return sub, err
+}
+
+func reqAttrs(req xchain.ProviderRequest) []any {
+	return []any{"chain_id", req.ChainID, "height", req.Height, "conf", req.ConfLevel}
+}
+
+func logCall(ctx context.Context, method string, t0 time.Time, err error, attrs ...any) {
+	attrs = append(attrs, "method", method, "duration", time.Since(t0))
+	if err != nil {
+		log.Warn(ctx, "Provider call failed", err, attrs...)
+		return
+	}
+
+	log.Debug(ctx, "Provider call", attrs...)
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	callLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider_middleware",
+		Name:      "call_latency_seconds",
+		Help:      "Latency (in seconds) of provider calls per method. Blocking streams observe the stream duration.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method"})
+
+	callErrTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider_middleware",
+		Name:      "call_error_total",
+		Help:      "Total number of failed provider calls per method. Alert if growing.",
+	}, []string{"method"})
+
+	streamedBlocksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "xprovider_middleware",
+		Name:      "streamed_blocks_total",
+		Help:      "Total number of blocks successfully processed by stream callbacks per source chain ID",
+	}, []string{"chain_id"})
+)
+
+// Metrics returns a middleware that instruments the latency and errors of all provider calls,
+// and the number of blocks processed by stream callbacks.
+func Metrics() xchain.ProviderMiddleware {
+	return func(p xchain.Provider) xchain.Provider {
+		return metrics{Provider: p}
+	}
+}
+
+var _ xchain.Provider = metrics{}
+
+type metrics struct {
+	xchain.Provider
+}
+
+func (m metrics) StreamAsync(ctx context.Context, req xchain.ProviderRequest, callback xchain.ProviderCallback) error {
+	done := instrumentCall("StreamAsync")
+	err := m.Provider.StreamAsync(ctx, req, instrumentCallback(req, callback))
+	done(err)
+
+	return err
+}
+
+func (m metrics) StreamBlocks(ctx context.Context, req xchain.ProviderRequest, callback xchain.ProviderCallback) error {
+	done := instrumentCall("StreamBlocks")
+	err := m.Provider.StreamBlocks(ctx, req, instrumentCallback(req, callback))
+	done(err)
+
+	return err
+}
+
+func (m metrics) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xchain.Block, bool, error) {
+	done := instrumentCall("GetBlock")
+	block, ok, err := m.Provider.GetBlock(ctx, req)
+	done(err)
+
+	return block, ok, err
+}
+
+func (m metrics) GetSubmittedCursor(ctx context.Context, stream xchain.StreamID) (xchain.SubmitCursor, bool, error) {
+	done := instrumentCall("GetSubmittedCursor")
+	cursor, ok, err := m.Provider.GetSubmittedCursor(ctx, stream)
+	done(err)
+
+	return cursor, ok, err
+}
+
+func (m metrics) GetEmittedCursor(ctx context.Context, ref xchain.EmitRef, stream xchain.StreamID) (xchain.EmitCursor, bool, error) {
+	done := instrumentCall("GetEmittedCursor")
+	cursor, ok, err := m.Provider.GetEmittedCursor(ctx, ref, stream)
+	done(err)
+
+	return cursor, ok, err
+}
+
+func (m metrics) ChainVersionHeight(ctx context.Context, chainVer xchain.ChainVersion) (uint64, error) {
+	done := instrumentCall("ChainVersionHeight")
+	height, err := m.Provider.ChainVersionHeight(ctx, chainVer)
+	done(err)
+
+	return height, err
+}
+
+func (m metrics) GetSubmission(ctx context.Context, chainID uint64, txHash common.Hash) (xchain.Submission, error) {
+	done := instrumentCall("GetSubmission")
+	sub, err := m.Provider.GetSubmission(ctx, chainID, txHash)
+	done(err)
+
+	return sub, err
+}
+
+// instrumentCall returns a function that instruments the call latency and error (if not nil) when called.
+func instrumentCall(method string) func(err error) {
+	t0 := time.Now()
+	return func(err error) {
+		callLatency.WithLabelValues(method).Observe(time.Since(t0).Seconds())
+		if err != nil {
+			callErrTotal.WithLabelValues(method).Inc()
+		}
+	}
+}
+
+// instrumentCallback returns the callback wrapped to count successfully processed blocks.
+func instrumentCallback(req xchain.ProviderRequest, callback xchain.ProviderCallback) xchain.ProviderCallback {
+	counter := streamedBlocksTotal.WithLabelValues(strconv.FormatUint(req.ChainID, 10))
+	return func(ctx context.Context, block xchain.Block) error {
+		if err := callback(ctx, block); err != nil {
+			return err
+		}
+		counter.Inc()
+
+		return nil
+	}
+}
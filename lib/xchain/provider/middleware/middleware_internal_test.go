@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errTest := errors.New("test error")
+
+	var calls []string
+	record := func(name string) xchain.ProviderMiddleware {
+		return func(p xchain.Provider) xchain.Provider {
+			return recorder{Provider: p, name: name, calls: &calls}
+		}
+	}
+
+	stub := &stubProvider{
+		blocks: []xchain.Block{{BlockHeader: xchain.BlockHeader{ChainID: 1, BlockHeight: 1}}, {BlockHeader: xchain.BlockHeader{ChainID: 1, BlockHeight: 2}}},
+	}
+	xprov := xchain.DecorateProvider(stub, record("outer"), Logging(), Metrics(), record("inner"))
+
+	// Calls pass through all middlewares in order.
+	block, ok, err := xprov.GetBlock(ctx, xchain.ProviderRequest{ChainID: 1, Height: 1})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, stub.blocks[0], block)
+	require.Equal(t, []string{"outer", "inner"}, calls)
+
+	// Errors are returned as is.
+	stub.err = errTest
+	_, _, err = xprov.GetBlock(ctx, xchain.ProviderRequest{ChainID: 1, Height: 1})
+	require.ErrorIs(t, err, errTest)
+	stub.err = nil
+
+	// Stream callbacks are called with all blocks.
+	var streamed []xchain.Block
+	err = xprov.StreamBlocks(ctx, xchain.ProviderRequest{ChainID: 1}, func(_ context.Context, block xchain.Block) error {
+		streamed = append(streamed, block)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, stub.blocks, streamed)
+
+	// Unknown chain ID to avoid metric conflicts.
+	stub.blocks = []xchain.Block{{BlockHeader: xchain.BlockHeader{ChainID: 4321}}}
+	err = xprov.StreamBlocks(ctx, xchain.ProviderRequest{ChainID: 4321}, func(context.Context, xchain.Block) error { return nil })
+	require.NoError(t, err)
+	require.InDelta(t, 1, testutil.ToFloat64(streamedBlocksTotal.WithLabelValues("4321")), 0)
+}
+
+// recorder is a middleware recording its name when GetBlock is called.
+type recorder struct {
+	xchain.Provider
+	name  string
+	calls *[]string
+}
+
+func (r recorder) GetBlock(ctx context.Context, req xchain.ProviderRequest) (xchain.Block, bool, error) {
+	*r.calls = append(*r.calls, r.name)
+	return r.Provider.GetBlock(ctx, req)
+}
+
+// stubProvider is a stub provider that only supports GetBlock and StreamBlocks.
+type stubProvider struct {
+	xchain.Provider
+	blocks []xchain.Block
+	err    error
+}
+
+func (s *stubProvider) GetBlock(_ context.Context, req xchain.ProviderRequest) (xchain.Block, bool, error) {
+	if s.err != nil {
+		return xchain.Block{}, false, s.err
+	}
+
+	for _, block := range s.blocks {
+		if block.BlockHeight == req.Height {
+			return block, true, nil
+		}
+	}
+
+	return xchain.Block{}, false, nil
+}
+
+func (s *stubProvider) StreamBlocks(ctx context.Context, _ xchain.ProviderRequest, callback xchain.ProviderCallback) error {
+	for _, block := range s.blocks {
+		if err := callback(ctx, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package provider
 
 import (
 	"context"
+	"encoding/binary"
 	"io"
 	"math/rand"
 	"sync"
@@ -16,10 +17,14 @@ import (
 	"github.com/omni-network/omni/lib/xchain"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
 	_ xchain.Provider = (*Mock)(nil)
+
+	// mockGenesisTime is the timestamp of height zero of deterministic mock chains.
+	mockGenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
 // Mock is a mock implementation of the xchain.Provider interface.
@@ -32,6 +37,32 @@ type Mock struct {
 	cChainID   uint64
 	cProvider  cchain.Provider
 	destChains [2]uint64
+	seed       int64                  // Seed of deterministic randomness
+	fixedClock bool                   // Derive block timestamps from height instead of wall clock
+	reorgs     map[uint64]*mockReorgs // Simulated reorgs by chain ID, see SimulateReorg
+}
+
+// mockReorgs tracks the simulated reorgs of a chain.
+type mockReorgs struct {
+	count      uint64                  // Number of simulated reorgs
+	fromHeight uint64                  // First reorged height of the latest reorg
+	blocks     map[uint64]xchain.Block // Reorged blocks by height
+}
+
+// NewDeterministicMock returns a mock provider that generates fully deterministic blocks for the provided seed.
+// Block contents (hashes, messages) are derived from the seed, chain ID and height, and block timestamps
+// are derived from the height and block period, so data delivered to consumers is stable across runs.
+// Note that it doesn't support the omni consensus chain.
+func NewDeterministicMock(seed int64, blockPeriod time.Duration) (*Mock, error) {
+	m, err := NewMock(blockPeriod, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m.seed = seed
+	m.fixedClock = true
+
+	return m, nil
 }
 
 func NewMock(period time.Duration, cChainID uint64, cProvider cchain.Provider) (*Mock, error) {
@@ -49,6 +80,7 @@ func NewMock(period time.Duration, cChainID uint64, cProvider cchain.Provider) (
 	return &Mock{
 		period:     period,
 		blocks:     make(map[blockKey]xchain.Block),
+		reorgs:     make(map[uint64]*mockReorgs),
 		cChainID:   cChainID,
 		cProvider:  cProvider,
 		destChains: [2]uint64(destChains),
@@ -74,6 +106,116 @@ func (*Mock) ChainVersionHeight(context.Context, xchain.ChainVersion) (uint64, e
 	return 0, errors.New("unsupported")
 }
 
+// SimulateReorg simulates a reorg of the new blocks' chain at the latest confirmation level:
+// the new blocks replace the existing blocks from fromHeight, and active latest streams of the chain
+// rewind to fromHeight and deliver the new blocks before continuing with generated blocks.
+//
+// The new blocks must have contiguous heights starting at fromHeight and non-zero hashes. Their parent hashes
+// are overwritten to chain onto the existing block at fromHeight-1 and each other, and subsequently
+// generated blocks chain onto the last new block. Finalized streams are not affected.
+func (m *Mock) SimulateReorg(fromHeight uint64, newBlocks []xchain.Block) error {
+	if len(newBlocks) == 0 {
+		return errors.New("no reorg blocks")
+	}
+
+	chainID := newBlocks[0].ChainID
+	if chainID == m.cChainID {
+		return errors.New("consensus chain reorgs not supported")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var parentHash common.Hash
+	if parentHeight, ok := umath.Subtract(fromHeight, 1); ok {
+		parent, ok := m.blocks[blockKey{ChainID: chainID, Height: parentHeight, ConfLevel: xchain.ConfLatest}]
+		if !ok {
+			return errors.New("reorg parent block not produced yet", "height", parentHeight)
+		}
+		parentHash = parent.BlockHash
+	}
+
+	blocks := make(map[uint64]xchain.Block, len(newBlocks))
+	for i, block := range newBlocks {
+		if block.ChainID != chainID {
+			return errors.New("reorg blocks chain mismatch", "chain_id", block.ChainID)
+		} else if block.BlockHeight != fromHeight+uint64(i) {
+			return errors.New("non-contiguous reorg block heights", "height", block.BlockHeight)
+		} else if block.BlockHash == (common.Hash{}) {
+			return errors.New("zero reorg block hash", "height", block.BlockHeight)
+		}
+
+		block.ParentHash = parentHash
+		parentHash = block.BlockHash
+		blocks[block.BlockHeight] = block
+	}
+
+	// Drop the replaced blocks, and add the new ones.
+	for key := range m.blocks {
+		if key.ChainID == chainID && key.ConfLevel == xchain.ConfLatest && key.Height >= fromHeight {
+			delete(m.blocks, key)
+		}
+	}
+	for height, block := range blocks {
+		m.blocks[blockKey{ChainID: chainID, Height: height, ConfLevel: xchain.ConfLatest}] = block
+	}
+
+	reorgs, ok := m.reorgs[chainID]
+	if !ok {
+		reorgs = &mockReorgs{blocks: make(map[uint64]xchain.Block)}
+		m.reorgs[chainID] = reorgs
+	}
+	for height := range reorgs.blocks {
+		if height >= fromHeight {
+			delete(reorgs.blocks, height) // Replaced by this reorg.
+		}
+	}
+	for height, block := range blocks {
+		reorgs.blocks[height] = block
+	}
+	reorgs.count++
+	reorgs.fromHeight = fromHeight
+
+	return nil
+}
+
+// reorgSince returns the first reorged height and the total reorg count if the chain version
+// was reorged since the provided count, or false.
+func (m *Mock) reorgSince(chainVer xchain.ChainVersion, count uint64) (uint64, uint64, bool) {
+	if chainVer.ConfLevel != xchain.ConfLatest {
+		return 0, 0, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reorgs, ok := m.reorgs[chainVer.ID]
+	if !ok || reorgs.count == count {
+		return 0, 0, false
+	}
+
+	return reorgs.fromHeight, reorgs.count, true
+}
+
+// reorgedBlock returns the reorged block of the chain version at the height, or false.
+func (m *Mock) reorgedBlock(chainVer xchain.ChainVersion, height uint64) (xchain.Block, bool) {
+	if chainVer.ConfLevel != xchain.ConfLatest {
+		return xchain.Block{}, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reorgs, ok := m.reorgs[chainVer.ID]
+	if !ok {
+		return xchain.Block{}, false
+	}
+
+	block, ok := reorgs.blocks[height]
+
+	return block, ok
+}
+
 func (*Mock) GetSubmission(context.Context, uint64, common.Hash) (xchain.Submission, error) {
 	return xchain.Submission{}, errors.New("unsupported")
 }
@@ -98,7 +240,7 @@ func (m *Mock) stream(
 	} else {
 		// Populate historical blocks for mocked chains so offsets are consistent for heights.
 		for i := uint64(0); i < fromHeight; i++ {
-			m.addBlock(m.nextBlock(ctx, chainVer, i, sOffset), req.ConfLevel)
+			m.addBlock(m.block(ctx, chainVer, i, sOffset), req.ConfLevel)
 		}
 	}
 
@@ -106,9 +248,18 @@ func (m *Mock) stream(
 		log.Debug(ctx, "Mock subscription ended")
 	}()
 	height := fromHeight
+	_, reorgCount, _ := m.reorgSince(chainVer, 0)
 
 	for ctx.Err() == nil {
-		block := m.nextBlock(ctx, chainVer, height, sOffset)
+		// Rewind to the first reorged height on simulated reorgs.
+		if reorgHeight, count, ok := m.reorgSince(chainVer, reorgCount); ok {
+			reorgCount = count
+			if reorgHeight < height {
+				height = reorgHeight
+			}
+		}
+
+		block := m.block(ctx, chainVer, height, sOffset)
 		m.addBlock(block, req.ConfLevel)
 
 		err := callback(ctx, block)
@@ -188,6 +339,20 @@ func (m *Mock) addBlock(block xchain.Block, confLevel xchain.ConfLevel) {
 	m.blocks[key] = block
 }
 
+// block returns the simulated reorged block at the height if any, otherwise the next generated block.
+func (m *Mock) block(
+	ctx context.Context,
+	chainVer xchain.ChainVersion,
+	height uint64,
+	sOffsetFunc func(xchain.StreamID) uint64,
+) xchain.Block {
+	if block, ok := m.reorgedBlock(chainVer, height); ok {
+		return block
+	}
+
+	return m.nextBlock(ctx, chainVer, height, sOffsetFunc)
+}
+
 func (m *Mock) nextBlock(
 	ctx context.Context,
 	chainVer xchain.ChainVersion,
@@ -211,8 +376,8 @@ func (m *Mock) nextBlock(
 		}
 	}
 
-	// Use deterministic randomness based on the chainID and height.
-	r := rand.New(rand.NewSource(int64(chainVer.ID ^ height)))
+	// Use deterministic randomness based on the seed, chainID and height.
+	r := rand.New(mockRandSource(m.seed, chainVer.ID, height))
 
 	// TODO(corver): add xreceipts
 	var msgs []xchain.Msg
@@ -242,12 +407,31 @@ func (m *Mock) nextBlock(
 			BlockHash:   random32(r),
 		},
 		Msgs:       msgs,
-		Receipts:   nil,        // TODO(corver): Add receipts
-		Timestamp:  time.Now(), // Should this also be deterministic?
+		Receipts:   nil, // TODO(corver): Add receipts
+		Timestamp:  m.timestamp(height),
 		ParentHash: m.parentBlockHash(chainVer, height),
 	}
 }
 
+// timestamp returns the block timestamp for the provided height.
+func (m *Mock) timestamp(height uint64) time.Time {
+	if !m.fixedClock {
+		return time.Now()
+	}
+
+	return mockGenesisTime.Add(m.period * time.Duration(height))
+}
+
+// mockRandSource returns the deterministic randomness source of the block at the chain height for the seed.
+// It is derived from the keccak hash of the inputs, since combining them arithmetically (e.g. xor) collides.
+func mockRandSource(seed int64, chainID uint64, height uint64) rand.Source {
+	bz := binary.BigEndian.AppendUint64(nil, uint64(seed))
+	bz = binary.BigEndian.AppendUint64(bz, chainID)
+	bz = binary.BigEndian.AppendUint64(bz, height)
+
+	return rand.NewSource(int64(binary.BigEndian.Uint64(crypto.Keccak256(bz))))
+}
+
 func newMsg(r *rand.Rand, srcChain, destChain uint64, offsetFunc func(xchain.StreamID) uint64) xchain.Msg {
 	streamID := xchain.StreamID{
 		SourceChainID: srcChain,
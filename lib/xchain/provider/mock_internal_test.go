@@ -7,6 +7,8 @@ import (
 
 	"github.com/omni-network/omni/lib/xchain"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,6 +54,131 @@ func TestMock(t *testing.T) {
 	assertOffsets(t, blocks)
 }
 
+func TestDeterministicMock(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chainID = 123
+		total   = 8
+	)
+
+	streamN := func(t *testing.T, seed int64) []xchain.Block {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mock, err := NewDeterministicMock(seed, time.Millisecond)
+		require.NoError(t, err)
+
+		req := xchain.ProviderRequest{
+			ChainID:   chainID,
+			ConfLevel: xchain.ConfLatest,
+		}
+		var blocks []xchain.Block
+		err = mock.StreamBlocks(ctx, req, func(ctx context.Context, block xchain.Block) error {
+			blocks = append(blocks, block)
+			if len(blocks) == total {
+				cancel()
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		return blocks
+	}
+
+	blocks := streamN(t, 1)
+	require.Len(t, blocks, total)
+	require.Equal(t, blocks, streamN(t, 1))
+	require.NotEqual(t, blocks, streamN(t, 2))
+	assertOffsets(t, blocks)
+
+	for i, block := range blocks {
+		require.Equal(t, mockGenesisTime.Add(time.Millisecond*time.Duration(i)), block.Timestamp)
+		if i > 0 {
+			require.Equal(t, blocks[i-1].BlockHash, block.ParentHash)
+		}
+	}
+}
+
+func TestMockRandSource(t *testing.T) {
+	t.Parallel()
+
+	// These inputs collide when xor-ed: 1^123^0 == 0^123^1 == 0^122^0.
+	a := mockRandSource(1, 123, 0).Int63()
+	b := mockRandSource(0, 123, 1).Int63()
+	c := mockRandSource(0, 122, 0).Int63()
+	require.NotEqual(t, a, b)
+	require.NotEqual(t, a, c)
+	require.NotEqual(t, b, c)
+	require.Equal(t, a, mockRandSource(1, 123, 0).Int63())
+}
+
+func TestMockReorg(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		chainID     = 123
+		reorgAt     = 5 // Height at which the reorg is simulated
+		reorgHeight = 3 // First reorged height
+		total       = 10
+	)
+
+	mock, err := NewDeterministicMock(1, time.Millisecond)
+	require.NoError(t, err)
+
+	newBlocks := []xchain.Block{
+		{BlockHeader: xchain.BlockHeader{ChainID: chainID, BlockHeight: reorgHeight, BlockHash: common.Hash{0x01}}},
+		{BlockHeader: xchain.BlockHeader{ChainID: chainID, BlockHeight: reorgHeight + 1, BlockHash: common.Hash{0x02}}},
+	}
+
+	require.ErrorContains(t, mock.SimulateReorg(reorgHeight, newBlocks), "reorg parent block not produced yet")
+
+	req := xchain.ProviderRequest{
+		ChainID:   chainID,
+		ConfLevel: xchain.ConfLatest,
+	}
+	var blocks []xchain.Block
+	err = mock.StreamBlocks(ctx, req, func(ctx context.Context, block xchain.Block) error {
+		blocks = append(blocks, block)
+		if block.BlockHeight == reorgAt && len(blocks) == reorgAt+1 {
+			require.ErrorContains(t, mock.SimulateReorg(reorgHeight+1, newBlocks), "non-contiguous reorg block heights")
+			require.NoError(t, mock.SimulateReorg(reorgHeight, newBlocks))
+		}
+		if len(blocks) == total {
+			cancel()
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, blocks, total)
+
+	// Stream rewinds to the reorged height after the reorg.
+	var heights []uint64
+	for _, block := range blocks {
+		heights = append(heights, block.BlockHeight)
+	}
+	require.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 3, 4, 5, 6}, heights)
+
+	// New blocks are delivered and chained consistently.
+	reorged := blocks[reorgAt+1:]
+	require.Equal(t, newBlocks[0].BlockHash, reorged[0].BlockHash)
+	require.Equal(t, blocks[reorgHeight-1].BlockHash, reorged[0].ParentHash)
+	for i := 1; i < len(reorged); i++ {
+		require.Equal(t, reorged[i-1].BlockHash, reorged[i].ParentHash)
+	}
+
+	// GetBlock returns the reorged blocks.
+	block, ok, err := mock.GetBlock(ctx, xchain.ProviderRequest{ChainID: chainID, Height: reorgHeight, ConfLevel: xchain.ConfLatest})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, reorged[0], block)
+}
+
 func assertOffsets(t *testing.T, blocks []xchain.Block) {
 	t.Helper()
 	sOffsets := make(map[xchain.StreamID]uint64)
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// NetworkFile is the JSON network file loaded by NewFromNetworkFile.
+type NetworkFile struct {
+	Network   netconf.Network     `json:"network"`   // Network configuration
+	Endpoints xchain.RPCEndpoints `json:"endpoints"` // RPC endpoints by chain name or ID
+}
+
+// NewFromNetworkFile returns a new xchain provider from the network file at the path, see NetworkFile.
+// It verifies the network and dials all its EVM chains, verifying their chain IDs,
+// returning the errors of all failed chains at once. Simnet networks return the mock provider.
+//
+// Note that streaming the omni consensus chain isn't supported since no consensus chain provider is configured.
+func NewFromNetworkFile(ctx context.Context, path string, opts ...Option) (xchain.Provider, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read network file")
+	}
+
+	var file NetworkFile
+	if err := json.Unmarshal(bz, &file); err != nil {
+		return nil, errors.Wrap(err, "unmarshal network file")
+	}
+
+	network := file.Network
+	if err := network.Verify(); err != nil {
+		return nil, errors.Wrap(err, "invalid network")
+	}
+
+	if network.ID == netconf.Simnet {
+		omni, ok := network.OmniConsensusChain()
+		if !ok {
+			return nil, errors.New("omni chain not found in network")
+		}
+
+		return NewMock(omni.BlockPeriod*8/10, omni.ID, nil)
+	}
+
+	ethClients, err := dialChains(ctx, network, file.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(network, ethClients, nil, opts...)
+}
+
+// dialChains returns RPC clients of all EVM chains in the network, verifying their chain IDs.
+// All chains are dialed, returning the errors of all failed chains at once.
+func dialChains(ctx context.Context, network netconf.Network, endpoints xchain.RPCEndpoints) (map[uint64]ethclient.Client, error) {
+	var dialErrs errors.MultiError
+	ethClients := make(map[uint64]ethclient.Client)
+	for _, chain := range network.EVMChains() {
+		rpc, err := endpoints.ByNameOrID(chain.Name, chain.ID)
+		if err != nil {
+			dialErrs.Add(chain.ID, chain.Name, err)
+			continue
+		}
+
+		ethCl, err := ethclient.Dial(chain.Name, rpc)
+		if err != nil {
+			dialErrs.Add(chain.ID, chain.Name, err)
+			continue
+		}
+
+		chainID, err := ethCl.ChainID(ctx)
+		if err != nil {
+			dialErrs.Add(chain.ID, chain.Name, errors.Wrap(err, "chain id"))
+			continue
+		} else if chainID.Uint64() != chain.ID {
+			dialErrs.Add(chain.ID, chain.Name, errors.New("chain id mismatch", "actual", chainID.Uint64()))
+			continue
+		}
+
+		ethClients[chain.ID] = ethCl
+	}
+
+	if err := dialErrs.ErrOrNil(); err != nil {
+		return nil, errors.Wrap(err, "dial chains")
+	}
+
+	return ethClients, nil
+}
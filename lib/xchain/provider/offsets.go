@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/umath"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxMsgOffsetRange is the maximum number of offsets queried by GetMsgsByOffset.
+// It bounds the number of topics in the log filter query.
+const maxMsgOffsetRange = 1000
+
+// GetMsgsByOffset returns the XMsgs emitted on the source chain to the destination chain
+// with stream offsets in the inclusive range [fromOffset, toOffset], ordered by block and log index.
+// It resolves the offset range to the blocks that emitted them and returns only the messages in the range.
+// Note that offsets are per shard, so messages of all shards of the source-destination pair are returned.
+func (p *Provider) GetMsgsByOffset(ctx context.Context, srcChainID, destChainID, fromOffset, toOffset uint64,
+) ([]xchain.Msg, error) {
+	if fromOffset == 0 || fromOffset > toOffset {
+		return nil, errors.New("invalid offset range", "from", fromOffset, "to", toOffset)
+	} else if toOffset-fromOffset >= maxMsgOffsetRange {
+		return nil, errors.New("offset range too large", "from", fromOffset, "to", toOffset, "max", maxMsgOffsetRange)
+	} else if srcChainID == p.cChainID {
+		return nil, errors.New("consensus chain offsets not supported")
+	}
+
+	chain, rpcClient, err := p.getEVMChain(srcChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the topics of all portal ABI versions, assuming the indexed fields didn't change.
+	topics, err := p.xmsgTopics(srcChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := rpcClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var offsets []common.Hash
+	for offset := fromOffset; offset <= toOffset; offset++ {
+		offsets = append(offsets, common.BigToHash(umath.NewBigInt(offset)))
+	}
+
+	// Resolve the offsets to blocks using the indexed XMsg topics: [event, destChainId, shardId, offset].
+	logs, err := rpcClient.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: umath.NewBigInt(chain.DeployHeight),
+		ToBlock:   umath.NewBigInt(head),
+		Addresses: []common.Address{chain.PortalAddress},
+		Topics: [][]common.Hash{
+			topics,
+			{common.BigToHash(umath.NewBigInt(destChainID))},
+			nil,
+			offsets,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "filter xmsg logs")
+	}
+
+	var blocks []types.Log // First log of each block
+	seen := make(map[common.Hash]bool)
+	for _, l := range logs {
+		if seen[l.BlockHash] {
+			continue
+		}
+		seen[l.BlockHash] = true
+		blocks = append(blocks, l)
+	}
+
+	// Fetch the msgs per block, since that validates the logs and shards consistently with GetBlock.
+	var resp []xchain.Msg
+	for _, block := range blocks {
+		msgs, err := p.getXMsgLogs(ctx, rpcClient, srcChainID, block.BlockNumber, block.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, msg := range msgs {
+			if msg.DestChainID != destChainID || msg.StreamOffset < fromOffset || msg.StreamOffset > toOffset {
+				continue
+			}
+
+			resp = append(resp, msg)
+		}
+	}
+
+	return resp, nil
+}
+
+// OutXStreamOffset returns the offset of the latest message emitted on the source chain to the destination chain
+// and shard, by querying the source chain portal's OutXMsgOffset at the latest block. Zero means no messages were emitted.
+// Note that consensus chain offsets are not supported, see GetEmittedCursor.
+func (p *Provider) OutXStreamOffset(ctx context.Context, srcChainID, destChainID uint64, shardID xchain.ShardID) (uint64, error) {
+	if srcChainID == p.cChainID {
+		return 0, errors.New("consensus chain offsets not supported")
+	}
+
+	caller, err := p.portalCaller(srcChainID)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := caller.OutXMsgOffset(&bind.CallOpts{Context: ctx}, destChainID, uint64(shardID))
+	if err != nil {
+		return 0, errors.Wrap(err, "call OutXMsgOffset", "src_chain", srcChainID, "dest_chain", destChainID)
+	}
+
+	return offset, nil
+}
+
+// InXStreamOffset returns the offset of the latest message submitted to the destination chain from the source chain
+// and shard, by querying the destination chain portal's InXMsgOffset at the latest block. Zero means no messages were submitted.
+func (p *Provider) InXStreamOffset(ctx context.Context, destChainID, srcChainID uint64, shardID xchain.ShardID) (uint64, error) {
+	caller, err := p.portalCaller(destChainID)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := caller.InXMsgOffset(&bind.CallOpts{Context: ctx}, srcChainID, uint64(shardID))
+	if err != nil {
+		return 0, errors.Wrap(err, "call InXMsgOffset", "src_chain", srcChainID, "dest_chain", destChainID)
+	}
+
+	return offset, nil
+}
+
+// portalCaller returns a portal contract caller of the EVM chain using its configured RPC client.
+func (p *Provider) portalCaller(chainID uint64) (*bindings.OmniPortalCaller, error) {
+	chain, rpcClient, err := p.getEVMChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := bindings.NewOmniPortalCaller(chain.PortalAddress, rpcClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "new caller")
+	}
+
+	return caller, nil
+}
@@ -0,0 +1,470 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/xchain"
+
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+// options defines the optional provider configuration.
+type options struct {
+	// onPermanentError is called when an async stream stops permanently.
+	onPermanentError func(ctx context.Context, chainVer xchain.ChainVersion, err error)
+	// maxInFlightBytes is the soft memory budget of fetched but unprocessed blocks, zero is unlimited.
+	maxInFlightBytes uint64
+	// allowBelowDeployHeight disables clamping stream start heights to the chain's deploy height.
+	allowBelowDeployHeight bool
+	// enrichBlock is called for each fetched block before the stream callback.
+	enrichBlock func(ctx context.Context, block *xchain.Block) error
+	// maxBlockRange is the maximum number of heights of a single StreamBlocksRange call.
+	maxBlockRange uint64
+	// allowLargeBlockRange disables the maxBlockRange guard.
+	allowLargeBlockRange bool
+	// callbackTimeout is the maximum duration of a stream callback invocation, zero disables.
+	callbackTimeout time.Duration
+	// finalityMargins are the finalized block delivery delays by chain ID.
+	finalityMargins map[uint64]time.Duration
+	// maxCommitLag is the maximum number of fetched heights beyond the consumer's committed height, zero is unlimited.
+	maxCommitLag uint64
+	// maxLookahead is the maximum number of heights fetched ahead of the stream's cursor, zero is unlimited.
+	maxLookahead uint64
+	// diskCacheDB is the on-disk cache DB of finalized blocks, nil disables the cache.
+	diskCacheDB dbm.DB
+	// diskCacheMaxBlocks is the maximum number of cached blocks, zero is unlimited.
+	diskCacheMaxBlocks uint64
+	// diskCacheTTL is the maximum age of cached blocks, zero is unlimited.
+	diskCacheTTL time.Duration
+	// defaultChainOpts are the global default chain options.
+	defaultChainOpts ChainOptions
+	// chainOpts are the per-chain options by chain ID.
+	chainOpts map[uint64]ChainOptions
+	// callbackBackoff is the backoff of blocking stream callback retries, nil disables retries.
+	callbackBackoff func(ctx context.Context) func()
+	// callbackMaxAttempts is the maximum number of blocking stream callback attempts, zero is unlimited.
+	callbackMaxAttempts uint64
+	// allowInjection enables InjectBlock.
+	allowInjection bool
+	// watchdogWindow is the maximum duration without async stream progress before it is restarted, zero disables.
+	watchdogWindow time.Duration
+	// maxPendingAcks is the maximum number of delivered but un-acked blocks of ack mode streams, zero is unlimited.
+	maxPendingAcks uint64
+	// ackTimeout is the maximum duration after delivery before an un-acked block is nacked, zero disables.
+	ackTimeout time.Duration
+	// heartbeatInterval is the maximum duration without stream deliveries before a heartbeat, zero disables.
+	heartbeatInterval time.Duration
+	// heartbeatFunc is called with the chain head on heartbeats.
+	heartbeatFunc HeartbeatFunc
+	// maxReorgDepth is the maximum tolerated reorg depth of streams, zero disables reorg detection.
+	maxReorgDepth uint64
+	// reorgLogDB is the DB of the durable reorg log, nil disables the log.
+	reorgLogDB dbm.DB
+	// portalDecoders are the activated portal event decoders by chain ID.
+	portalDecoders map[uint64][]decoderActivation
+	// requiredChains are the chain IDs that must be configured, see WithRequiredChains.
+	requiredChains []uint64
+	// reconnectChainIDCheck enables re-verifying the RPC chain ID after stream fetch errors.
+	reconnectChainIDCheck bool
+	// decodeWorkers is the number of workers decoding the event logs of a block, less than two decodes sequentially.
+	decodeWorkers int
+	// callbackRoutes are the StreamMany callbacks by chain group, see WithCallbackRoutes.
+	callbackRoutes callbackRoutes
+	// inclusionProofs enables populating the receipt-trie inclusion proofs of fetched messages.
+	inclusionProofs bool
+	// orphanReceipts enables flagging receipts of undelivered messages, see WithOrphanReceipts.
+	orphanReceipts bool
+	// orphanReceiptWindow is the maximum duration orphan receipts are held, zero flags them without holding.
+	orphanReceiptWindow time.Duration
+	// skipVerification are the trusted chain IDs of which streamed blocks aren't verified.
+	skipVerification map[uint64]bool
+	// allowedClockSkew is the maximum duration streamed block timestamps may be in the future, zero disables the check.
+	allowedClockSkew time.Duration
+	// headStoreDB is the DB persisting the last observed head heights, nil disables persistence.
+	headStoreDB dbm.DB
+	// attestPreview is called with the attestation preview of each delivered block, nil disables previews.
+	attestPreview AttestPreviewFunc
+	// maxMsgPayloadBytes is the maximum xmsg payload size, zero is unlimited.
+	maxMsgPayloadBytes uint64
+	// archiveClients are the archive node clients by chain ID, see WithArchiveClients.
+	archiveClients map[uint64]ethclient.Client
+}
+
+// Option configures the provider.
+type Option func(*options)
+
+// WithPermanentErrorHandler returns an option configuring a handler that is called when
+// a StreamAsync goroutine stops permanently due to an unexpected error or panic.
+// It allows supervisors to react, since the stream will not be restarted.
+func WithPermanentErrorHandler(fn func(ctx context.Context, chainVer xchain.ChainVersion, err error)) Option {
+	return func(o *options) {
+		o.onPermanentError = fn
+	}
+}
+
+// WithMaxInFlightBytes returns an option configuring a soft memory budget in bytes shared by all streams
+// of the provider. Prefetching pauses while the estimated size of fetched but unprocessed blocks, including blocks
+// read from the disk cache, and of held orphan receipts (see WithOrphanReceipts) exceeds the budget.
+// The disk cache itself isn't counted, since it isn't held in memory by the provider.
+// The next block of each stream is always fetched, so the budget may be exceeded by one block per stream.
+// The default of zero disables the budget.
+func WithMaxInFlightBytes(maxBytes uint64) Option {
+	return func(o *options) {
+		o.maxInFlightBytes = maxBytes
+	}
+}
+
+// WithAllowBelowDeployHeight returns an option that allows streaming from heights below the chain's
+// deploy height. By default, stream start heights are clamped to the deploy height since no portal
+// activity can exist before deployment. This is unusual, e.g. to confirm no activity existed before a redeployment.
+func WithAllowBelowDeployHeight() Option {
+	return func(o *options) {
+		o.allowBelowDeployHeight = true
+	}
+}
+
+// WithBlockEnricher returns an option configuring a function that transforms or enriches each streamed block
+// before the stream callback is called. This centralizes enrichment logic shared by multiple consumers.
+// Enricher errors are treated as fetch errors, so the block is refetched and enriched again after a backoff.
+// Note the enricher is called concurrently by fetch workers, so it must be thread-safe.
+func WithBlockEnricher(fn func(ctx context.Context, block *xchain.Block) error) Option {
+	return func(o *options) {
+		o.enrichBlock = fn
+	}
+}
+
+// WithMaxBlockRange returns an option configuring the maximum number of heights
+// a single StreamBlocksRange call may stream. Larger ranges are refused and should be chunked.
+// It defaults to 1,000,000 heights.
+func WithMaxBlockRange(maxHeights uint64) Option {
+	return func(o *options) {
+		o.maxBlockRange = maxHeights
+	}
+}
+
+// WithAllowLargeBlockRange returns an option that disables the StreamBlocksRange maximum range guard.
+// This is only intended for deliberate large backfills.
+func WithAllowLargeBlockRange() Option {
+	return func(o *options) {
+		o.allowLargeBlockRange = true
+	}
+}
+
+// WithCallbackTimeout returns an option configuring the maximum duration of a stream callback invocation.
+// When exceeded, a warning is logged, the callback context is canceled, and the invocation is treated as a callback error
+// once the callback returns, so it is retried with backoff (StreamAsync) or returned (StreamBlocks).
+// This surfaces hung callbacks instead of silent stalls.
+//
+// Invocations never run concurrently, so a callback that ignores its context cancellation still blocks the stream
+// until it returns. The default of zero disables the timeout.
+func WithCallbackTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.callbackTimeout = timeout
+	}
+}
+
+// WithFinalitySafetyMargin returns an option configuring per-chain safety margins for finalized blocks.
+// Delivery of a finalized block is delayed until its margin has elapsed since the block timestamp.
+// This absorbs edge-case reorgs of chains claiming (near) instant finality, trading latency for safety.
+// Note this only applies to finalized streams and is distinct from confirmation depth.
+func WithFinalitySafetyMargin(margins map[uint64]time.Duration) Option {
+	return func(o *options) {
+		o.finalityMargins = margins
+	}
+}
+
+// WithMaxCommitLag returns an option configuring commit lag backpressure for streams started with a
+// CommitTracker context, see WithCommitTracker. Fetching pauses while maxHeights heights beyond the
+// consumer's committed height have been fetched, bounding the backlog by durable commit progress
+// rather than buffer size.
+//
+// Note that streams stall if the consumer commits less often than every maxHeights blocks.
+// The default of zero disables commit lag backpressure.
+func WithMaxCommitLag(maxHeights uint64) Option {
+	return func(o *options) {
+		o.maxCommitLag = maxHeights
+	}
+}
+
+// WithMaxLookahead returns an option bounding the number of heights streams fetch ahead of their cursor,
+// i.e., the consumer's committed height if a CommitTracker is attached (see WithCommitTracker), otherwise the
+// delivered height. This caps wasted RPC work on consumer rollback and bounds memory independently of
+// the byte budget, see WithMaxInFlightBytes. It defaults to 256 heights, zero is unlimited.
+func WithMaxLookahead(maxHeights uint64) Option {
+	return func(o *options) {
+		o.maxLookahead = maxHeights
+	}
+}
+
+// WithDiskCache returns an option configuring an on-disk cache of finalized blocks backed by the provided DB,
+// keyed by chain ID and height. Cached blocks survive restarts, reducing re-fetching on recovery, e.g. of indexers.
+// This is safe since finalized blocks are immutable. Only finalized blocks of EVM chains are cached.
+//
+// The oldest blocks are evicted once more than maxBlocks are cached or when older than ttl, zero values disable
+// the respective eviction. The DB must not be shared by multiple providers.
+func WithDiskCache(db dbm.DB, maxBlocks uint64, ttl time.Duration) Option {
+	return func(o *options) {
+		o.diskCacheDB = db
+		o.diskCacheMaxBlocks = maxBlocks
+		o.diskCacheTTL = ttl
+	}
+}
+
+// WithDefaultChainOptions returns an option configuring the global default chain options of all chains.
+// See WithChainOptions for precedence.
+func WithDefaultChainOptions(chainOpts ChainOptions) Option {
+	return func(o *options) {
+		o.defaultChainOpts = chainOpts
+	}
+}
+
+// WithChainOptions returns an option configuring per-chain options by chain ID.
+//
+// Each field is resolved with the following precedence: the non-zero per-chain field,
+// then the non-zero global default field (see WithDefaultChainOptions), then the provider built-in default.
+func WithChainOptions(chainOpts map[uint64]ChainOptions) Option {
+	return func(o *options) {
+		o.chainOpts = chainOpts
+	}
+}
+
+// WithCallbackRetry returns an option that enables callback error retries for blocking streams
+// (StreamBlocks and StreamBlocksRange), providing resilience against transient sink failures.
+// Retries use the provided backoff, which is independent of the fetch backoff. The last callback error
+// is returned once maxAttempts (including the first) are exhausted, zero retries forever.
+//
+// By default, blocking streams return the first callback error. StreamAsync always retries callbacks.
+func WithCallbackRetry(maxAttempts uint64, backoff func(ctx context.Context) func()) Option {
+	return func(o *options) {
+		o.callbackBackoff = backoff
+		o.callbackMaxAttempts = maxAttempts
+	}
+}
+
+// WithBlockInjection returns an option that enables injecting blocks via InjectBlock.
+// This is only intended for tests and replays, never enable it in production.
+func WithBlockInjection() Option {
+	return func(o *options) {
+		o.allowInjection = true
+	}
+}
+
+// WithStreamWatchdog returns an option that enables a watchdog for each StreamAsync stream.
+// If a stream doesn't fetch or process any block within the window while the chain head has reached
+// its next unprocessed height, the stream is torn down and restarted from that height.
+// This self-heals unexpected silent stalls, which are logged and counted.
+//
+// The window should exceed the longest expected pause, e.g. due to slow callbacks or commit lag backpressure.
+// The default of zero disables the watchdog.
+func WithStreamWatchdog(window time.Duration) Option {
+	return func(o *options) {
+		o.watchdogWindow = window
+	}
+}
+
+// WithMaxPendingAcks returns an option configuring the maximum number of delivered but un-acked blocks
+// of ack mode streams, see StreamAsyncAck. Delivery pauses while the maximum is reached.
+// It defaults to 64, zero is unlimited.
+func WithMaxPendingAcks(maxPending uint64) Option {
+	return func(o *options) {
+		o.maxPendingAcks = maxPending
+	}
+}
+
+// WithAckTimeout returns an option configuring the maximum duration after delivery before an un-acked block
+// of an ack mode stream is treated as nacked and redelivered, see StreamAsyncAck.
+// It defaults to 1 minute, zero disables the timeout.
+func WithAckTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.ackTimeout = timeout
+	}
+}
+
+// WithHeartbeat returns an option configuring a heartbeat for idle streams. When a stream didn't deliver
+// any block for the interval, the function is called with the current chain head height, and again after
+// every further idle interval. This allows consumers to distinguish quiet chains from dead streams.
+//
+// The function is called from a separate goroutine, concurrently with the stream callback.
+// The default of zero disables heartbeats.
+func WithHeartbeat(interval time.Duration, fn HeartbeatFunc) Option {
+	return func(o *options) {
+		o.heartbeatInterval = interval
+		o.heartbeatFunc = fn
+	}
+}
+
+// WithMaxReorgDepth returns an option that enables reorg detection of EVM chain streams.
+// A reorg is detected when a block's parent hash doesn't match the previously delivered block.
+// Its depth is the number of delivered blocks that are no longer canonical.
+//
+// Reorgs up to maxDepth are logged and tolerated: the stream continues on the new canonical chain
+// and consumers can detect the reorg via block parent hashes. Deeper reorgs likely indicate a serious
+// chain problem or a compromised endpoint, so they halt the stream with ErrReorgTooDeep.
+// The default of zero disables reorg detection.
+func WithMaxReorgDepth(maxDepth uint64) Option {
+	return func(o *options) {
+		o.maxReorgDepth = maxDepth
+	}
+}
+
+// WithReorgLog returns an option that durably records each reorg detected by streams in the provided DB,
+// including the chain, depth and old and new hashes. Use ReadReorgLog to replay the history for post-mortem
+// analysis of which chains reorg and how deeply. It requires reorg detection, see WithMaxReorgDepth.
+func WithReorgLog(db dbm.DB) Option {
+	return func(o *options) {
+		o.reorgLogDB = db
+	}
+}
+
+// WithPortalDecoder returns an option that decodes portal event logs of the chain using the provided decoder
+// from the activation height (inclusive). The decoder with the highest activation height not above a block's height
+// is used, falling back to CurrentPortalDecoder if none is activated.
+// This allows streaming across portal upgrades that changed the event schema without gaps, e.g. configure
+// a legacy decoder from height zero and CurrentPortalDecoder from the upgrade height.
+func WithPortalDecoder(chainID uint64, activationHeight uint64, decoder PortalDecoder) Option {
+	return func(o *options) {
+		if o.portalDecoders == nil {
+			o.portalDecoders = make(map[uint64][]decoderActivation)
+		}
+		o.portalDecoders[chainID] = append(o.portalDecoders[chainID], decoderActivation{
+			Height:  activationHeight,
+			Decoder: decoder,
+		})
+	}
+}
+
+// WithRequiredChains returns an option that requires the provided chains to be configured.
+// New returns an error listing the missing chains if any required chain is not in the network,
+// or if an EVM chain has no RPC client. This detects config drift at construction time.
+func WithRequiredChains(chainIDs ...uint64) Option {
+	return func(o *options) {
+		o.requiredChains = append(o.requiredChains, chainIDs...)
+	}
+}
+
+// WithDecodeWorkers returns an option that decodes the XMsg and XReceipt event logs of each block
+// concurrently using a bounded pool of the provided number of workers. This speeds up constructing
+// large blocks, since decoding is CPU-bound. The resulting blocks are identical regardless of the number of workers.
+// Less than two workers decodes sequentially (the default).
+func WithDecodeWorkers(workers int) Option {
+	return func(o *options) {
+		o.decodeWorkers = workers
+	}
+}
+
+// WithReconnectChainIDCheck returns an option that re-verifies the RPC endpoint's chain ID (eth_chainId)
+// before resuming streaming after fetch errors (e.g. network blips and reconnects). Streams halt with
+// ErrChainIDChanged if the chain ID changed, since the endpoint silently switched networks.
+func WithReconnectChainIDCheck() Option {
+	return func(o *options) {
+		o.reconnectChainIDCheck = true
+	}
+}
+
+// WithCallbackRoutes returns an option that routes the blocks streamed by StreamMany to group-specific
+// callbacks. The groups map chain IDs to group names (e.g. "l1" and "l2") and the callbacks map group names
+// to the group's callback. This allows a single provider to feed separate processing pipelines.
+// Blocks of chains not mapped to a group are delivered to StreamMany's default callback.
+func WithCallbackRoutes(groups map[uint64]string, callbacks map[string]xchain.ProviderCallback) Option {
+	return func(o *options) {
+		o.callbackRoutes = callbackRoutes{groups: groups, callbacks: callbacks}
+	}
+}
+
+// WithInclusionProofs returns an option that populates the receipt-trie inclusion proof of each message
+// of fetched blocks, see xchain.ReceiptProof. This allows trust-minimized consumers to verify message inclusion
+// against the block hash without re-querying the source chain. It is opt-in since it is much heavier,
+// fetching all the receipts of each block containing messages. Consensus chain blocks don't have proofs.
+func WithInclusionProofs(enabled bool) Option {
+	return func(o *options) {
+		o.inclusionProofs = enabled
+	}
+}
+
+// WithOrphanReceipts returns an option that handles orphan receipts, i.e., receipts of messages
+// not yet delivered by any stream of the provider, e.g. when the destination chain stream is ahead
+// of the source chain stream. This allows consumers like the monitor to link messages and receipts correctly.
+//
+// If the window is zero, orphan receipts are delivered immediately and flagged as unmatched.
+// Otherwise, they are removed from their block and held for up to the window, and delivered with a later
+// block of the same stream once their message was delivered, or flagged as unmatched once the window expired.
+// Note that holding receipts alters delivered blocks (and their attestation hash), and that held receipts
+// are dropped when the stream stops. Messages are only matched if the provider also streams their source chain.
+func WithOrphanReceipts(window time.Duration) Option {
+	return func(o *options) {
+		o.orphanReceipts = true
+		o.orphanReceiptWindow = window
+	}
+}
+
+// WithSkipVerification returns an option that disables the sanity checks of streamed blocks of the provided
+// trusted chains, i.e., that each block's source chain ID and height match the stream. This avoids their minor
+// overhead for trusted in-process sources like simnet or mocks. Blocks of other chains are still verified.
+// Reorg detection (see WithMaxReorgDepth) is not affected.
+//
+// This must never be used for chains backed by untrusted RPC endpoints,
+// since invalid blocks would be delivered to consumers unnoticed.
+func WithSkipVerification(chainIDs ...uint64) Option {
+	return func(o *options) {
+		if o.skipVerification == nil {
+			o.skipVerification = make(map[uint64]bool)
+		}
+		for _, chainID := range chainIDs {
+			o.skipVerification[chainID] = true
+		}
+	}
+}
+
+// WithAllowedClockSkew returns an option that verifies the timestamps of streamed blocks, rejecting
+// blocks with timestamps more than the allowed skew in the future, since these indicate corrupt data.
+// The skew allowance prevents false rejections due to minor RPC or node clock differences of well-behaved chains.
+// Like other verification failures, rejected blocks halt the stream. The default of zero disables the check.
+func WithAllowedClockSkew(skew time.Duration) Option {
+	return func(o *options) {
+		o.allowedClockSkew = skew
+	}
+}
+
+// WithHeadStore returns an option that persists the last observed head height of each chain version in the
+// provided DB, and reuses them as initial head estimates after restarts, see HeadEstimate. This makes the
+// stream head lag metric meaningful immediately on startup, rather than only after the first head query.
+// Persisted heads are advisory and always refreshed by the first head query. The DB must not be shared.
+func WithHeadStore(db dbm.DB) Option {
+	return func(o *options) {
+		o.headStoreDB = db
+	}
+}
+
+// WithAttestationPreview returns an option that computes the attestation preview of each streamed block,
+// i.e., the canonical block hash and the payload the attester would sign, and calls the function with it
+// just before the stream callback. This allows operators to verify attestation content against an independent
+// source before attesting. Nothing is signed. Preview errors are logged, they don't affect the stream.
+func WithAttestationPreview(fn AttestPreviewFunc) Option {
+	return func(o *options) {
+		o.attestPreview = fn
+	}
+}
+
+// WithMaxMsgPayloadBytes returns an option that guards against xmsgs with payloads (data) larger than the maximum,
+// protecting against memory exhaustion by malicious or buggy sources. Oversized xmsgs are logged and counted,
+// and fail the block fetch, so the stream stalls until the limit is increased. Oversized xmsgs are never dropped,
+// since that would result in permanent stream offset gaps and different attested blocks per node.
+// The default of zero disables the guard.
+func WithMaxMsgPayloadBytes(maxBytes uint64) Option {
+	return func(o *options) {
+		o.maxMsgPayloadBytes = maxBytes
+	}
+}
+
+func defaultOptions() options {
+	return options{
+		maxBlockRange:    1_000_000,
+		maxLookahead:     defaultMaxLookahead,
+		maxPendingAcks:   64,
+		ackTimeout:       time.Minute,
+		onPermanentError: func(context.Context, xchain.ChainVersion, error) {},      // Noop by default.
+		enrichBlock:      func(context.Context, *xchain.Block) error { return nil }, // Noop by default.
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// orphanSeen tracks the highest delivered message offset by xstream across all streams of the provider,
+// see WithOrphanReceipts. Since stream offsets are sequential, a receipt is matched if the
+// offset of its message was delivered. A nil orphanSeen is disabled.
+type orphanSeen struct {
+	mu      sync.Mutex
+	offsets map[xchain.StreamID]uint64
+}
+
+func newOrphanSeen(enabled bool) *orphanSeen {
+	if !enabled {
+		return nil
+	}
+
+	return &orphanSeen{offsets: make(map[xchain.StreamID]uint64)}
+}
+
+// Delivered records the delivered messages.
+func (s *orphanSeen) Delivered(msgs []xchain.Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, msg := range msgs {
+		if msg.StreamOffset > s.offsets[msg.StreamID] {
+			s.offsets[msg.StreamID] = msg.StreamOffset
+		}
+	}
+}
+
+// Matched returns true if the receipt's message was delivered.
+func (s *orphanSeen) Matched(receipt xchain.Receipt) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.offsets[receipt.StreamID] >= receipt.StreamOffset
+}
+
+// heldReceipt is an orphan receipt held until its message is delivered or the window expires.
+type heldReceipt struct {
+	receipt xchain.Receipt
+	heldAt  time.Time
+}
+
+// orphanTracker flags or holds the orphan receipts of a stream, see WithOrphanReceipts.
+// It is not thread-safe, since blocks are delivered sequentially. A nil tracker is disabled.
+type orphanTracker struct {
+	seen      *orphanSeen
+	window    time.Duration
+	budget    *byteBudget // Held receipts are counted in the shared budget, nil is unlimited.
+	held      []heldReceipt
+	heldBytes uint64
+}
+
+// newOrphanTracker returns an orphan receipt tracker for the stream or nil if disabled.
+func (p *Provider) newOrphanTracker() *orphanTracker {
+	if p.orphans == nil {
+		return nil
+	}
+
+	return &orphanTracker{
+		seen:   p.orphans,
+		window: p.opts.orphanReceiptWindow,
+		budget: p.budget,
+	}
+}
+
+// Process returns a copy of the block with orphan receipts flagged as unmatched, or held if a window is configured,
+// and previously held receipts that were matched or expired added. The returned commit function must be called
+// once the block was delivered successfully, so held receipts aren't lost if the callback is retried.
+// It is nil-safe, returning the block as is.
+func (t *orphanTracker) Process(block xchain.Block, now time.Time) (xchain.Block, func()) {
+	if t == nil {
+		return block, func() {}
+	}
+
+	var (
+		receipts []xchain.Receipt
+		held     []heldReceipt
+	)
+	for _, h := range t.held {
+		if t.seen.Matched(h.receipt) {
+			receipts = append(receipts, h.receipt)
+		} else if now.Sub(h.heldAt) >= t.window {
+			h.receipt.Unmatched = true
+			receipts = append(receipts, h.receipt)
+		} else {
+			held = append(held, h)
+		}
+	}
+
+	for _, receipt := range block.Receipts {
+		if t.seen.Matched(receipt) {
+			receipts = append(receipts, receipt)
+		} else if t.window > 0 {
+			held = append(held, heldReceipt{receipt: receipt, heldAt: now})
+		} else {
+			receipt.Unmatched = true
+			receipts = append(receipts, receipt)
+		}
+	}
+
+	block.Receipts = receipts
+
+	return block, func() {
+		t.setHeld(held)
+		t.seen.Delivered(block.Msgs)
+	}
+}
+
+// setHeld replaces the held receipts, updating their bytes in the budget.
+func (t *orphanTracker) setHeld(held []heldReceipt) {
+	var heldBytes uint64
+	for _, h := range held {
+		heldBytes += receiptSize(h.receipt)
+	}
+
+	if t.budget != nil {
+		t.budget.sub(t.heldBytes)
+		t.budget.add(heldBytes)
+	}
+
+	t.held = held
+	t.heldBytes = heldBytes
+}
+
+// Close releases the bytes of held receipts from the budget. It is nil-safe.
+func (t *orphanTracker) Close() {
+	if t == nil {
+		return
+	}
+
+	t.setHeld(nil)
+}
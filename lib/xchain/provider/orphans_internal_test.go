@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanReceipts(t *testing.T) {
+	t.Parallel()
+
+	stream := xchain.StreamID{SourceChainID: 1, DestChainID: 2, ShardID: xchain.ShardFinalized0}
+	msg := func(offset uint64) xchain.Msg {
+		return xchain.Msg{MsgID: xchain.MsgID{StreamID: stream, StreamOffset: offset}}
+	}
+	receipt := func(offset uint64) xchain.Receipt {
+		return xchain.Receipt{MsgID: xchain.MsgID{StreamID: stream, StreamOffset: offset}}
+	}
+	offsets := func(receipts []xchain.Receipt) map[uint64]bool {
+		resp := make(map[uint64]bool)
+		for _, r := range receipts {
+			resp[r.StreamOffset] = r.Unmatched
+		}
+
+		return resp
+	}
+
+	const window = time.Minute
+	now := time.Now()
+
+	t.Run("flag", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Provider{orphans: newOrphanSeen(true)}
+		src, dst := p.newOrphanTracker(), p.newOrphanTracker()
+
+		_, commit := src.Process(xchain.Block{Msgs: []xchain.Msg{msg(1), msg(2)}}, now)
+		commit()
+
+		block, commit := dst.Process(xchain.Block{Receipts: []xchain.Receipt{receipt(1), receipt(3)}}, now)
+		commit()
+		require.Equal(t, map[uint64]bool{1: false, 3: true}, offsets(block.Receipts))
+	})
+
+	t.Run("hold", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Provider{orphans: newOrphanSeen(true), opts: options{orphanReceiptWindow: window}}
+		src, dst := p.newOrphanTracker(), p.newOrphanTracker()
+
+		// Orphans are held.
+		block, commit := dst.Process(xchain.Block{Receipts: []xchain.Receipt{receipt(1), receipt(2)}}, now)
+		require.Empty(t, block.Receipts)
+		commit()
+
+		// Uncommitted blocks (e.g. failed callbacks) don't release held receipts.
+		_, commit = src.Process(xchain.Block{Msgs: []xchain.Msg{msg(1)}}, now)
+		block, _ = dst.Process(xchain.Block{}, now)
+		require.Empty(t, block.Receipts)
+
+		// Matched receipts are released once their message was delivered.
+		commit()
+		block, commit = dst.Process(xchain.Block{}, now)
+		require.Equal(t, map[uint64]bool{1: false}, offsets(block.Receipts))
+		block, _ = dst.Process(xchain.Block{}, now)
+		require.Equal(t, map[uint64]bool{1: false}, offsets(block.Receipts)) // Retried callbacks get the same receipts.
+		commit()
+
+		// Unmatched receipts are released once expired.
+		block, commit = dst.Process(xchain.Block{}, now.Add(window))
+		require.Equal(t, map[uint64]bool{2: true}, offsets(block.Receipts))
+		commit()
+
+		block, _ = dst.Process(xchain.Block{}, now.Add(window))
+		require.Empty(t, block.Receipts)
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Provider{orphans: newOrphanSeen(true), opts: options{orphanReceiptWindow: window}, budget: newByteBudget(1)}
+		tracker := p.newOrphanTracker()
+		used := func() uint64 {
+			p.budget.mu.Lock()
+			defer p.budget.mu.Unlock()
+
+			return p.budget.used
+		}
+
+		// Held receipts are counted once committed.
+		_, commit := tracker.Process(xchain.Block{Receipts: []xchain.Receipt{receipt(1), receipt(2)}}, now)
+		require.Zero(t, used())
+		commit()
+		require.Equal(t, 2*receiptSize(receipt(1)), used())
+
+		// Released receipts are subtracted.
+		_, commit = tracker.Process(xchain.Block{}, now.Add(window))
+		commit()
+		require.Zero(t, used())
+
+		// Closing releases remaining held receipts.
+		_, commit = tracker.Process(xchain.Block{Receipts: []xchain.Receipt{receipt(3)}}, now)
+		commit()
+		require.Equal(t, receiptSize(receipt(3)), used())
+		tracker.Close()
+		require.Zero(t, used())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Provider{}
+		tracker := p.newOrphanTracker()
+		require.Nil(t, tracker)
+
+		block, commit := tracker.Process(xchain.Block{Receipts: []xchain.Receipt{receipt(1)}}, now)
+		commit()
+		require.Equal(t, map[uint64]bool{1: false}, offsets(block.Receipts))
+	})
+}
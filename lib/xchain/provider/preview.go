@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AttestPreviewFunc is called with the attestation preview of a streamed block, see WithAttestationPreview.
+type AttestPreviewFunc func(ctx context.Context, preview AttestPreview)
+
+// AttestPreview is the attestation content of a streamed block as the attester would sign it.
+type AttestPreview struct {
+	ConsensusChainID uint64              // Omni consensus chain ID the attestation belongs to.
+	ChainVersion     xchain.ChainVersion // Chain version being attested to.
+	BlockHeader      xchain.BlockHeader  // Header of the attested block.
+	BlockHash        common.Hash         // Canonical hash of the full block, see xchain.Block.Hash.
+	MsgRoot          common.Hash         // Merkle root of the block's messages, zero if none.
+}
+
+// AttestationRoot returns the attestation root the attester would sign for the block at the provided attest offset.
+// The attest offset isn't known to the provider, since it is assigned by the attester.
+func (p AttestPreview) AttestationRoot(attestOffset uint64) (common.Hash, error) {
+	attHeader := xchain.AttestHeader{
+		ConsensusChainID: p.ConsensusChainID,
+		ChainVersion:     p.ChainVersion,
+		AttestOffset:     attestOffset,
+	}
+
+	return xchain.AttestationRoot(attHeader, p.BlockHeader, p.MsgRoot)
+}
+
+// newAttestPreview returns the attestation preview of the block.
+func newAttestPreview(cChainID uint64, chainVer xchain.ChainVersion, block xchain.Block) (AttestPreview, error) {
+	hash, err := block.Hash()
+	if err != nil {
+		return AttestPreview{}, err
+	}
+
+	msgRoot, err := xchain.BlockMsgRoot(block)
+	if err != nil {
+		return AttestPreview{}, err
+	}
+
+	return AttestPreview{
+		ConsensusChainID: cChainID,
+		ChainVersion:     chainVer,
+		BlockHeader:      block.BlockHeader,
+		BlockHash:        hash,
+		MsgRoot:          msgRoot,
+	}, nil
+}
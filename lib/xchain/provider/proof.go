@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// addInclusionProofs populates the receipt-trie inclusion proofs of the messages, see WithInclusionProofs.
+// It fetches all the receipts of the block to construct its receipts trie, which is verified against the header.
+func addInclusionProofs(ctx context.Context, ethCl ethclient.Client, header *types.Header, msgs []xchain.Msg) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	block, err := ethCl.BlockByHash(ctx, header.Hash())
+	if err != nil {
+		return errors.Wrap(err, "block by hash")
+	} else if block == nil {
+		return errors.New("nil block by hash")
+	}
+
+	receipts := make(types.Receipts, 0, len(block.Transactions()))
+	txIndexes := make(map[common.Hash]uint64)
+	for i, tx := range block.Transactions() {
+		receipt, err := ethCl.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return errors.Wrap(err, "transaction receipt", "tx", tx.Hash())
+		} else if receipt == nil {
+			return errors.New("nil transaction receipt", "tx", tx.Hash())
+		}
+		receipts = append(receipts, receipt)
+		txIndexes[tx.Hash()] = uint64(i)
+	}
+
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	values := make([][]byte, len(receipts))
+	for i := range receipts {
+		var buf bytes.Buffer
+		receipts.EncodeIndex(i, &buf)
+		values[i] = buf.Bytes()
+
+		if err := tr.Update(rlp.AppendUint64(nil, uint64(i)), values[i]); err != nil {
+			return errors.Wrap(err, "update receipts trie")
+		}
+	}
+
+	// Ensure the fetched receipts are consistent with the header.
+	if root := tr.Hash(); root != header.ReceiptHash {
+		return errors.New("receipts root mismatch", "height", header.Number, "expect", header.ReceiptHash, "actual", root)
+	}
+
+	headerRLP, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return errors.Wrap(err, "encode header")
+	}
+
+	for i, msg := range msgs {
+		txIndex, ok := txIndexes[msg.TxHash]
+		if !ok {
+			return errors.New("msg tx not in block", "tx", msg.TxHash)
+		}
+
+		nodes := new(proofNodes)
+		if err := tr.Prove(rlp.AppendUint64(nil, txIndex), nodes); err != nil {
+			return errors.Wrap(err, "prove receipt")
+		}
+
+		msgs[i].Proof = &xchain.ReceiptProof{
+			Header:  headerRLP,
+			TxIndex: txIndex,
+			Receipt: values[txIndex],
+			Nodes:   nodes.nodes,
+		}
+	}
+
+	return nil
+}
+
+// proofNodes collects the trie nodes of a proof in order, it implements ethdb.KeyValueWriter.
+type proofNodes struct {
+	nodes [][]byte
+}
+
+func (p *proofNodes) Put(_ []byte, value []byte) error {
+	p.nodes = append(p.nodes, bytes.Clone(value))
+	return nil
+}
+
+func (*proofNodes) Delete([]byte) error {
+	return errors.New("unexpected proof node delete")
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/omni-network/omni/lib/ethclient/mock"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInclusionProofs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Create a block with some transactions, each emitting a single log.
+	const numTxs = 20
+	var (
+		txs      types.Transactions
+		receipts types.Receipts
+	)
+	for i := 0; i < numTxs; i++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(i), GasPrice: big.NewInt(1)})
+		txs = append(txs, tx)
+		receipts = append(receipts, &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(i+1) * 21000,
+			TxHash:            tx.Hash(),
+			Logs: []*types.Log{{
+				Address: common.Address{byte(i)},
+				Topics:  []common.Hash{{byte(i)}},
+				Data:    []byte{byte(i)},
+			}},
+		})
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, &types.Body{Transactions: txs}, receipts, trie.NewStackTrie(nil))
+	header := block.Header()
+
+	ctrl := gomock.NewController(t)
+	ethCl := mock.NewMockClient(ctrl)
+	ethCl.EXPECT().BlockByHash(gomock.Any(), header.Hash()).AnyTimes().Return(block, nil)
+	for _, receipt := range receipts {
+		ethCl.EXPECT().TransactionReceipt(gomock.Any(), receipt.TxHash).AnyTimes().Return(receipt, nil)
+	}
+
+	msgs := []xchain.Msg{{TxHash: txs[0].Hash()}, {TxHash: txs[7].Hash()}, {TxHash: txs[numTxs-1].Hash()}}
+	require.NoError(t, addInclusionProofs(ctx, ethCl, header, msgs))
+
+	for _, msg := range msgs {
+		require.NotNil(t, msg.Proof)
+
+		receipt, err := msg.Proof.Verify(header.Hash())
+		require.NoError(t, err)
+
+		expect := receipts[msg.Proof.TxIndex]
+		require.Equal(t, expect.TxHash, msg.TxHash)
+		require.Equal(t, expect.CumulativeGasUsed, receipt.CumulativeGasUsed)
+		require.Len(t, receipt.Logs, 1)
+		require.Equal(t, expect.Logs[0].Topics, receipt.Logs[0].Topics)
+
+		// Proofs don't verify against other blocks.
+		_, err = msg.Proof.Verify(common.Hash{1})
+		require.ErrorContains(t, err, "proof header hash mismatch")
+
+		// Tampered proofs don't verify.
+		tampered := *msg.Proof
+		tampered.Receipt = append([]byte{}, tampered.Receipt...)
+		tampered.Receipt[len(tampered.Receipt)-1]++
+		_, err = tampered.Verify(header.Hash())
+		require.ErrorContains(t, err, "proof receipt mismatch")
+
+		tampered = *msg.Proof
+		tampered.TxIndex = (tampered.TxIndex + 1) % numTxs
+		_, err = tampered.Verify(header.Hash())
+		require.Error(t, err)
+	}
+
+	// Messages of transactions not in the block are rejected.
+	err := addInclusionProofs(ctx, ethCl, header, []xchain.Msg{{TxHash: common.Hash{1}}})
+	require.ErrorContains(t, err, "msg tx not in block")
+
+	// Receipts inconsistent with the header are rejected.
+	badHeader := types.CopyHeader(header)
+	badHeader.ReceiptHash = common.Hash{1}
+	ethCl.EXPECT().BlockByHash(gomock.Any(), badHeader.Hash()).Return(block, nil)
+	err = addInclusionProofs(ctx, ethCl, badHeader, msgs)
+	require.ErrorContains(t, err, "receipts root mismatch")
+}
@@ -6,7 +6,10 @@ package provider
 
 import (
 	"context"
+	"os"
 	"path"
+	"runtime/debug"
+	"slices"
 	"sync"
 	"time"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/stream"
 	"github.com/omni-network/omni/lib/tracer"
+	"github.com/omni-network/omni/lib/umath"
 	"github.com/omni-network/omni/lib/xchain"
 
 	"go.opentelemetry.io/otel/trace"
@@ -43,6 +47,13 @@ type Provider struct {
 	cChainID    uint64
 	cProvider   cchain.Provider
 	backoffFunc func(context.Context) func()
+	opts        options
+	budget      *byteBudget // Nil if unlimited
+	diskCache   *diskCache  // Nil if disabled
+	reorgLog    *reorgLog   // Nil if disabled
+	heads       *headStore  // Nil if disabled
+	orphans     *orphanSeen // Nil if disabled
+	metaLabels  metaRegistry
 
 	mu sync.Mutex
 	// confHeads caches the latest height by chain version.
@@ -51,57 +62,192 @@ type Provider struct {
 	// Also, since many L2s finalize in batches, the stream
 	// lags behind the chain version head every time a new batch is finalized.
 	confHeads map[xchain.ChainVersion]uint64
+	// injected are the injected blocks, see InjectBlock.
+	injected map[injectKey]xchain.Block
+	// active are the active async streams.
+	active map[activeKey]*activeStream
+	// statuses are the stream statuses by chain version, see StreamStatus.
+	statuses map[xchain.ChainVersion]StreamStatus
 }
 
-// New instantiates the provider instance which will be ready to accept
-// subscriptions for respective destination XBlocks.
-func New(network netconf.Network, rpcClients map[uint64]ethclient.Client, cProvider cchain.Provider) *Provider {
-	backoffFunc := func(ctx context.Context) func() {
-		// Limit backoff to 10s for all EVM chains.
+// newBackoffFunc returns the EVM chain backoff function, with default backoff limited to 10s.
+// The options are applied before the default config, e.g. expbackoff.WithClock in tests.
+func newBackoffFunc(opts ...func(*expbackoff.Config)) func(context.Context) func() {
+	return func(ctx context.Context) func() {
 		const maxDelay = time.Second * 10
 		cfg := expbackoff.DefaultConfig
 		cfg.MaxDelay = maxDelay
 
-		return expbackoff.New(ctx, expbackoff.With(cfg))
+		return expbackoff.New(ctx, append(slices.Clone(opts), expbackoff.With(cfg))...)
 	}
+}
 
+// New instantiates the provider instance which will be ready to accept
+// subscriptions for respective destination XBlocks.
+// It returns an error if any required chain is missing, see WithRequiredChains.
+func New(network netconf.Network, rpcClients map[uint64]ethclient.Client, cProvider cchain.Provider, opts ...Option) (*Provider, error) {
 	cChain, _ := network.OmniConsensusChain()
 
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := verifyRequiredChains(network, rpcClients, o.requiredChains); err != nil {
+		return nil, err
+	}
+
+	// New doesn't have a context, so overrides are logged with the background context.
+	o.chainOpts = applyEnvConfirmations(context.Background(), network, o.chainOpts, os.Environ())
+
 	return &Provider{
 		network:     network,
 		ethClients:  rpcClients,
 		cChainID:    cChain.ID,
 		cProvider:   cProvider,
-		backoffFunc: backoffFunc,
+		backoffFunc: newBackoffFunc(),
+		opts:        o,
+		budget:      newByteBudget(o.maxInFlightBytes),
+		diskCache:   newDiskCache(o.diskCacheDB, o.diskCacheMaxBlocks, o.diskCacheTTL),
+		reorgLog:    newReorgLog(o.reorgLogDB),
+		heads:       newHeadStore(o.headStoreDB),
+		orphans:     newOrphanSeen(o.orphanReceipts),
 		confHeads:   make(map[xchain.ChainVersion]uint64),
+		active:      make(map[activeKey]*activeStream),
+	}, nil
+}
+
+// verifyRequiredChains returns an error listing the required chains missing from the network
+// or, for EVM chains, missing an RPC client.
+func verifyRequiredChains(network netconf.Network, rpcClients map[uint64]ethclient.Client, required []uint64) error {
+	cChain, _ := network.OmniConsensusChain()
+
+	var missing []uint64
+	for _, chainID := range required {
+		if _, ok := network.Chain(chainID); !ok {
+			missing = append(missing, chainID)
+		} else if _, ok := rpcClients[chainID]; !ok && chainID != cChain.ID {
+			missing = append(missing, chainID)
+		}
 	}
+
+	if len(missing) > 0 {
+		return errors.New("missing required chains", "missing", missing)
+	}
+
+	return nil
 }
 
 // StreamAsync starts a goroutine that streams xblocks asynchronously forever.
-// It returns immediately. It only returns an error if the chainID in invalid.
+// It returns immediately. It only returns an error if the chainID in invalid or
+// if the stream is already active, see ErrStreamAlreadyActive and StopStream.
 // This is the async version of StreamBlocks.
 // It retries forever (with backoff) on all fetch and callback errors.
+//
+// If the stream stops unexpectedly due to an error or panic, it is logged and
+// the permanent error handler is called, see WithPermanentErrorHandler.
+// Stalled streams are restarted if a watchdog is enabled, see WithStreamWatchdog.
 func (p *Provider) StreamAsync(
 	ctx context.Context,
 	req xchain.ProviderRequest,
 	callback xchain.ProviderCallback,
 ) error {
-	if _, ok := p.network.Chain(req.ChainID); !ok {
-		return errors.New("unknown chain ID")
+	if _, err := p.configuredChain(req.ChainID); err != nil {
+		return err
+	}
+
+	return p.goStream(ctx, req, func(ctx context.Context) error {
+		if p.opts.watchdogWindow > 0 {
+			return p.streamWatched(ctx, req, callback)
+		}
+
+		return p.stream(ctx, req, callback, true)
+	})
+}
+
+// goStream starts a goroutine running the async stream, logging and reporting
+// unexpected errors and panics to the permanent error handler.
+// It returns ErrStreamAlreadyActive if the stream is already active.
+func (p *Provider) goStream(ctx context.Context, req xchain.ProviderRequest, run func(ctx context.Context) error) error {
+	ctx, stopped, err := p.startActive(ctx, req)
+	if err != nil {
+		return err
 	}
 
 	go func() {
-		err := p.stream(ctx, req, callback, true)
-		if err != nil { // RetryCallback==true so this should only ever return nil on ctx cancel.
+		defer stopped()
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			// Include the stack, since it is lost once recovered.
+			err := errors.New("stream panic", "panic", r, "stack", string(debug.Stack()))
+			log.Error(ctx, "Streaming xprovider blocks panicked [BUG]", err,
+				"chain", p.network.ChainVersionName(req.ChainVersion()),
+			)
+			p.opts.onPermanentError(ctx, req.ChainVersion(), err)
+		}()
+
+		// Async streams retry callbacks, so this should only ever return nil on ctx cancel.
+		if err := run(ctx); err != nil {
 			log.Error(ctx, "Streaming xprovider blocks failed unexpectedly [BUG]", err)
+			p.opts.onPermanentError(ctx, req.ChainVersion(), err)
 		}
 	}()
 
 	return nil
 }
 
+// ConfStream defines a stream of a chain at a specific confirmation level, see StreamAsyncConfLevels.
+type ConfStream struct {
+	ConfLevel xchain.ConfLevel        // Confirmation level to stream
+	Height    uint64                  // Height to start streaming from, e.g. the conf level's cursor.
+	Callback  xchain.ProviderCallback // Callback called for each block at this conf level
+}
+
+// StreamAsyncConfLevels starts parallel async streams for a single chain at different confirmation levels,
+// each delivering to its own callback. This allows a consumer to optimistically process "latest" blocks
+// while authoritatively committing "finalized" blocks. It returns immediately, see StreamAsync.
+//
+// The streams share the provider's RPC clients and in-flight budget, but track independent heights and heads.
+func (p *Provider) StreamAsyncConfLevels(ctx context.Context, chainID uint64, streams ...ConfStream) error {
+	if _, err := p.configuredChain(chainID); err != nil {
+		return err
+	} else if len(streams) == 0 {
+		return errors.New("no conf level streams")
+	}
+
+	dups := make(map[xchain.ConfLevel]bool)
+	for _, s := range streams {
+		if dups[s.ConfLevel] {
+			return errors.New("duplicate conf level stream", "conf_level", s.ConfLevel)
+		} else if s.Callback == nil {
+			return errors.New("nil conf level stream callback", "conf_level", s.ConfLevel)
+		} else if _, ok := headTypeFromConfLevel(s.ConfLevel); !ok && chainID != p.cChainID {
+			return errors.New("unsupported conf level", "conf_level", s.ConfLevel)
+		}
+		dups[s.ConfLevel] = true
+	}
+
+	for _, s := range streams {
+		req := xchain.ProviderRequest{
+			ChainID:   chainID,
+			Height:    s.Height,
+			ConfLevel: s.ConfLevel,
+		}
+		if err := p.StreamAsync(ctx, req, s.Callback); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // StreamBlocks blocks, streaming all xblocks from the chain as they become available (finalized).
-// It retries forever (with backoff) on all fetch errors. It however returns the first callback error.
+// It retries forever (with backoff) on all fetch errors. It however returns the first callback error,
+// unless callback retries are enabled, see WithCallbackRetry.
 // It returns nil when the context is canceled.
 func (p *Provider) StreamBlocks(
 	ctx context.Context,
@@ -111,38 +257,122 @@ func (p *Provider) StreamBlocks(
 	return p.stream(ctx, req, callback, false)
 }
 
+// StreamBlocksRange blocks, streaming all xblocks in the inclusive range [req.Height, toHeight] as they become available.
+// It retries forever (with backoff) on all fetch errors. It however returns the first callback error,
+// unless callback retries are enabled, see WithCallbackRetry.
+// It returns nil once the last block in the range was processed or when the context is canceled.
+//
+// Ranges larger than the configured maximum are refused, see WithMaxBlockRange and WithAllowLargeBlockRange.
+func (p *Provider) StreamBlocksRange(
+	ctx context.Context,
+	req xchain.ProviderRequest,
+	toHeight uint64,
+	callback xchain.ProviderCallback,
+) error {
+	if toHeight < req.Height {
+		return errors.New("invalid block range", "from", req.Height, "to", toHeight)
+	} else if heights := toHeight - req.Height + 1; heights > p.opts.maxBlockRange && !p.opts.allowLargeBlockRange {
+		return errors.New("block range exceeds maximum, stream smaller chunks instead",
+			"from", req.Height, "to", toHeight, "heights", heights, "max", p.opts.maxBlockRange)
+	}
+
+	chain, err := p.configuredChain(req.ChainID)
+	if err != nil {
+		return err
+	} else if toHeight < chain.StartHeight {
+		return nil // Entire range is below the start height, see startHeight.
+	} else if toHeight < chain.DeployHeight && !p.opts.allowBelowDeployHeight {
+		return nil // Entire range is below the deploy height, see startHeight.
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	return p.stream(ctx, req, func(ctx context.Context, block xchain.Block) error {
+		if err := callback(ctx, block); err != nil {
+			return err
+		}
+
+		if block.BlockHeight >= toHeight {
+			cancel(errStreamDone) // Range done, stop the stream.
+		}
+
+		return nil
+	}, false)
+}
+
+// verifyTimestamp returns an error if the block timestamp is more than the skew after now, see WithAllowedClockSkew.
+// A zero skew disables the check.
+func verifyTimestamp(block xchain.Block, now time.Time, skew time.Duration) error {
+	if skew == 0 || !block.Timestamp.After(now.Add(skew)) {
+		return nil
+	}
+
+	return errors.New("block timestamp in the future",
+		"timestamp", block.Timestamp,
+		"ahead", block.Timestamp.Sub(now),
+		"skew", skew,
+	)
+}
+
+// errStreamDone is the cancel cause of streams stopped by their own callback once done, e.g. StreamBlocksRange.
+// Unlike other cancellations, the block of the callback that stopped the stream is committed.
+var errStreamDone = errors.New("stream done")
+
 func (p *Provider) stream(
 	ctx context.Context,
 	req xchain.ProviderRequest,
 	callback xchain.ProviderCallback,
 	retryCallback bool,
 ) error {
-	chain, ok := p.network.Chain(req.ChainID)
-	if !ok {
-		return errors.New("unknown chain ID")
+	chain, err := p.configuredChain(req.ChainID)
+	if err != nil {
+		return err
 	}
 
 	chainVersionName := p.network.ChainVersionName(xchain.ChainVersion{ID: req.ChainID, ConfLevel: req.ConfLevel})
-
-	var workers uint64 // Pick the first threshold that matches (or the last one)
-	for _, threshold := range fetchWorkerThresholds {
-		workers = threshold.Workers
-		if chain.BlockPeriod >= threshold.MinPeriod {
-			break
-		}
+	meta, err := p.streamMeta(ctx)
+	if err != nil {
+		return err
 	}
-	if workers == 0 {
+	if meta.label != "" {
+		streamMetaInfo.WithLabelValues(chainVersionName, meta.label).Set(1)
+	}
+
+	chainOpts := p.chainOptions(req.ChainID, chain.BlockPeriod)
+	if chainOpts.FetchWorkers == 0 {
 		return errors.New("zero workers [BUG]")
 	}
 
-	// Start streaming from chain's deploy height as per config.
-	fromHeight := req.Height
-	if fromHeight < chain.DeployHeight {
-		fromHeight = chain.DeployHeight
+	fromHeight := p.startHeight(ctx, chain, req.Height)
+
+	budget := newStreamBudget(p.budget, fromHeight)
+	defer budget.Close()
+
+	tracker := commitTrackerFromCtx(ctx)
+	progress := progressFromCtx(ctx)
+	active := activeFromCtx(ctx)
+	active.Streaming(fromHeight)
+	reorgs := p.newReorgTracker(req.ChainID)
+	orphans := p.newOrphanTracker()
+	defer orphans.Close()
+	ahead := newLookahead(p.opts.maxLookahead, fromHeight, tracker)
+
+	ctx, halt := context.WithCancel(ctx)
+	defer halt()
+	chainIDs := p.newChainIDCheck(req.ChainID, halt)
+	skipVerify := p.opts.skipVerification[req.ChainID] // Trusted chain, see WithSkipVerification.
+
+	// setHeadLag sets the lag of the delivered height behind the estimated head (if known).
+	setHeadLag := func(delivered uint64) {
+		if head, ok := p.HeadEstimate(ctx, req.ChainVersion()); ok {
+			streamHeadLag.WithLabelValues(chainVersionName, meta.label).Set(float64(umath.SubtractOrZero(head, delivered)))
+		}
 	}
+	setHeadLag(umath.SubtractOrZero(fromHeight, 1)) // Meaningful on startup if heads are persisted, see WithHeadStore.
 
 	deps := stream.Deps[xchain.Block]{
-		FetchWorkers: workers,
+		FetchWorkers: chainOpts.FetchWorkers,
 		FetchBatch: func(ctx context.Context, chainID uint64, height uint64) ([]xchain.Block, error) {
 			fetchReq := xchain.ProviderRequest{
 				ChainID:   chainID,
@@ -150,38 +380,86 @@ func (p *Provider) stream(
 				ConfLevel: req.ConfLevel,
 			}
 
+			// Pause prefetching while the budget is full.
+			if err := budget.Wait(ctx, height); err != nil {
+				return nil, err
+			}
+
+			// Pause fetching while the consumer's commits lag too far behind.
+			if err := waitCommitLag(ctx, tracker, p.opts.maxCommitLag, fromHeight, height); err != nil {
+				return nil, err
+			}
+
+			// Pause fetching while too far ahead of the stream's cursor.
+			if err := ahead.Wait(ctx, height); err != nil {
+				return nil, err
+			}
+
+			// Re-verify the chain ID after fetch errors, since the endpoint may have reconnected to another network.
+			if err := chainIDs.BeforeFetch(ctx); err != nil {
+				return nil, err
+			}
+
 			var lastErr error
 			const retryCount = 5
 			backoff := expbackoff.New(ctx, expbackoff.WithPeriodicConfig(time.Millisecond*100))
-			for i := 0; i < retryCount; i++ {
-				xBlock, exists, err := p.GetBlock(ctx, fetchReq)
+			for attempt := 0; attempt < retryCount; {
+				xBlock, exists, err := p.getBlockWithTimeout(ctx, fetchReq, chainOpts.FetchTimeout)
+				if err == nil && exists {
+					blocksFetched.WithLabelValues(chainVersionName, meta.label).Inc()
+					msgsFetched.WithLabelValues(chainVersionName, meta.label).Add(float64(len(xBlock.Msgs)))
+
+					// Enrichment errors are treated as fetch errors.
+					err = p.opts.enrichBlock(ctx, &xBlock)
+				}
+				if err == nil && exists {
+					err = p.waitFinalityMargin(ctx, req.ChainVersion(), xBlock)
+				}
 				if err != nil {
+					chainIDs.FetchFailed()
+					p.setLastError(req.ChainVersion(), err)
 					lastErr = err
+					attempt++
 					backoff()
+				} else if !exists && chainOpts.PollInterval == 0 {
+					return nil, nil // Stream backs off
 				} else if !exists {
-					return nil, nil
+					// Poll at a fixed interval instead.
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(chainOpts.PollInterval):
+					}
 				} else {
+					budget.Fetched(xBlock)
+					progress.Fetched()
 					return []xchain.Block{xBlock}, nil
 				}
 			}
 
 			return nil, lastErr
 		},
-		Backoff:       p.backoffFunc,
+		Backoff:       chainOpts.Backoff,
 		ElemLabel:     "block",
 		HeightLabel:   "height",
 		RetryCallback: retryCallback,
 		Height: func(block xchain.Block) uint64 {
 			return block.BlockHeight
 		},
-		Verify: func(_ context.Context, block xchain.Block, h uint64) error {
-			if block.ChainID != req.ChainID {
+		Verify: func(ctx context.Context, block xchain.Block, h uint64) error {
+			if err := chainIDs.Mismatch(); err != nil {
+				return err
+			} else if skipVerify {
+				return p.verifyReorg(ctx, reorgs, req, block)
+			} else if block.ChainID != req.ChainID {
 				return errors.New("invalid block source chain id")
 			} else if block.BlockHeight != h {
 				return errors.New("invalid block height")
+			} else if err := verifyTimestamp(block, time.Now(), p.opts.allowedClockSkew); err != nil {
+				return err
 			}
 
-			return nil
+			return p.verifyReorg(ctx, reorgs, req, block)
 		},
 		IncFetchErr: func() {
 			fetchErrTotal.WithLabelValues(chainVersionName).Inc()
@@ -191,6 +469,7 @@ func (p *Provider) stream(
 		},
 		SetStreamHeight: func(h uint64) {
 			streamHeight.WithLabelValues(chainVersionName).Set(float64(h))
+			setHeadLag(h)
 		},
 		SetCallbackLatency: func(d time.Duration) {
 			callbackLatency.WithLabelValues(chainVersionName).Observe(d.Seconds())
@@ -200,12 +479,220 @@ func (p *Provider) stream(
 		},
 	}
 
-	cb := (stream.Callback[xchain.Block])(callback)
+	hbCtx, cancel := context.WithCancel(log.WithCtx(ctx, "chain", chainVersionName))
+	defer cancel()
+	hb := p.startHeartbeat(hbCtx, req.ChainVersion())
+
+	onCallbackErr := func(err error) {
+		deps.IncCallbackErr()
+		p.setLastError(req.ChainVersion(), err)
+	}
+
+	cb := func(ctx context.Context, block xchain.Block) error {
+		delivered, commitOrphans := orphans.Process(block, time.Now())
+		if p.opts.attestPreview != nil {
+			if preview, err := newAttestPreview(p.cChainID, req.ChainVersion(), delivered); err != nil {
+				log.Warn(ctx, "Failed computing attestation preview (will continue)", err, "height", block.BlockHeight)
+			} else {
+				p.opts.attestPreview(ctx, preview)
+			}
+		}
+
+		if retryCallback {
+			// Stream retries callback errors.
+			if err := p.callWithTimeout(ctx, callback, delivered); err != nil {
+				p.setLastError(req.ChainVersion(), err)
+				return err
+			}
+		} else if err := p.callWithRetry(ctx, callback, delivered, onCallbackErr); err != nil {
+			p.setLastError(req.ChainVersion(), err)
+			return err
+		}
+
+		// Callbacks may return nil due to cancellation rather than success (e.g. watchdog restarts),
+		// so don't commit the block, ensuring it is redelivered when the stream is restarted.
+		if ctx.Err() != nil && !errors.Is(context.Cause(ctx), errStreamDone) {
+			return errors.Wrap(ctx.Err(), "callback canceled", "height", block.BlockHeight)
+		}
+
+		commitOrphans()
+		budget.Processed(block)
+		ahead.Delivered(block.BlockHeight)
+		progress.Processed(block.BlockHeight)
+		active.Delivered(block.BlockHeight)
+		hb.Delivered()
+		reorgs.Delivered(block)
+		blocksDelivered.WithLabelValues(chainVersionName, meta.label).Inc()
+		msgsDelivered.WithLabelValues(chainVersionName, meta.label).Add(float64(len(block.Msgs)))
+		if size, err := encodedBlockSize(block); err != nil {
+			log.Warn(ctx, "Failed encoding delivered block size (will continue)", err, "height", block.BlockHeight)
+		} else {
+			blockSizeBytes.WithLabelValues(chainVersionName, meta.label).Observe(float64(size))
+		}
+
+		return nil
+	}
 
 	ctx = log.WithCtx(ctx, "chain", chainVersionName)
+	ctx = log.WithCtx(ctx, meta.attrs...)
 	log.Info(ctx, "Streaming xprovider blocks", "from_height", fromHeight)
 
-	return stream.Stream(ctx, deps, req.ChainID, fromHeight, cb)
+	err = stream.Stream(ctx, deps, req.ChainID, fromHeight, cb)
+	if mismatch := chainIDs.Mismatch(); mismatch != nil {
+		return mismatch // Stream halted due to chain ID mismatch.
+	}
+
+	return err
+}
+
+// getBlockWithTimeout returns GetBlock with the provided timeout applied, zero disables the timeout.
+func (p *Provider) getBlockWithTimeout(ctx context.Context, req xchain.ProviderRequest, timeout time.Duration) (xchain.Block, bool, error) {
+	if timeout == 0 {
+		return p.GetBlock(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return p.GetBlock(ctx, req)
+}
+
+// waitFinalityMargin blocks until the finalized block's safety margin has elapsed since its timestamp,
+// see WithFinalitySafetyMargin.
+func (p *Provider) waitFinalityMargin(ctx context.Context, chainVer xchain.ChainVersion, block xchain.Block) error {
+	if chainVer.ConfLevel != xchain.ConfFinalized {
+		return nil
+	}
+
+	margin := p.opts.finalityMargins[chainVer.ID]
+	wait := time.Until(block.Timestamp.Add(margin))
+	if margin <= 0 || wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// callWithRetry calls the callback with the block, retrying errors with the callback retry backoff
+// until the max attempts are exhausted, see WithCallbackRetry. Callback errors are returned immediately if not enabled.
+func (p *Provider) callWithRetry(ctx context.Context, callback xchain.ProviderCallback, block xchain.Block, onErr func(error)) error {
+	if p.opts.callbackBackoff == nil {
+		return p.callWithTimeout(ctx, callback, block)
+	}
+
+	backoff := p.opts.callbackBackoff(ctx)
+	for attempt := uint64(1); ; attempt++ {
+		err := p.callWithTimeout(ctx, callback, block)
+		if err == nil || ctx.Err() != nil {
+			return err
+		} else if p.opts.callbackMaxAttempts > 0 && attempt >= p.opts.callbackMaxAttempts {
+			return errors.Wrap(err, "callback attempts exhausted", "attempts", attempt)
+		}
+
+		log.Warn(ctx, "Failed processing block (will retry)", err, "height", block.BlockHeight, "attempt", attempt)
+		onErr(err)
+		backoff()
+	}
+}
+
+// callWithTimeout calls the callback with the block, returning an error if it doesn't
+// complete within the callback timeout (if enabled), see WithCallbackTimeout.
+func (p *Provider) callWithTimeout(ctx context.Context, callback xchain.ProviderCallback, block xchain.Block) error {
+	timeout := p.opts.callbackTimeout
+	if timeout == 0 {
+		return callback(ctx, block)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		err      error
+		panicked any
+	}
+
+	resp := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				resp <- result{panicked: r}
+			}
+		}()
+		resp <- result{err: callback(ctx, block)}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-resp:
+		if res.panicked != nil {
+			panic(res.panicked) // Propagate panics to the stream goroutine.
+		}
+
+		return res.err
+	case <-timer.C:
+	}
+
+	// The callback context is canceled, but wait for the callback to return before retrying,
+	// since invocations must never run concurrently.
+	err := errors.New("stream callback timeout", "height", block.BlockHeight, "timeout", timeout)
+	log.Warn(ctx, "Stream callback timeout, waiting for canceled callback to return", err)
+
+	t0 := time.Now()
+	if res := <-resp; res.panicked != nil {
+		panic(res.panicked)
+	}
+	log.Debug(ctx, "Timed out stream callback returned", "height", block.BlockHeight, "after", time.Since(t0))
+
+	return err
+}
+
+// startHeight returns the height to start streaming from.
+// It is clamped to the chain's start height if configured, and to its deploy height
+// unless WithAllowBelowDeployHeight is enabled.
+func (p *Provider) startHeight(ctx context.Context, chain netconf.Chain, height uint64) uint64 {
+	if height < chain.StartHeight {
+		log.Debug(ctx, "Clamping stream to chain start height",
+			"chain", chain.Name,
+			"from_height", height,
+			"start_height", chain.StartHeight,
+		)
+
+		return chain.StartHeight
+	} else if height >= chain.DeployHeight {
+		return height
+	} else if !p.opts.allowBelowDeployHeight {
+		log.Debug(ctx, "Clamping stream to chain deploy height",
+			"chain", chain.Name,
+			"from_height", height,
+			"deploy_height", chain.DeployHeight,
+		)
+
+		return chain.DeployHeight
+	}
+
+	log.Warn(ctx, "Streaming from below chain deploy height", nil,
+		"chain", chain.Name,
+		"from_height", height,
+		"deploy_height", chain.DeployHeight,
+	)
+
+	return height
+}
+
+// ChainConfig returns the network configuration of the chain, e.g. its deploy height and portal address,
+// or false if the chain is not part of the provider's network.
+func (p *Provider) ChainConfig(chainID uint64) (netconf.Chain, bool) {
+	return p.network.Chain(chainID)
 }
 
 // getEVMChain provides the configuration of the given chainID.
@@ -216,13 +703,36 @@ func (p *Provider) getEVMChain(chainID uint64) (netconf.Chain, ethclient.Client,
 
 	chain, ok := p.network.Chain(chainID)
 	if !ok {
-		return netconf.Chain{}, nil, errors.New("unknown chain ID for network")
+		return netconf.Chain{}, nil, errors.Wrap(ErrChainNotConfigured, "unknown chain ID for network", "chain_id", chainID)
 	}
 
 	client, ok := p.ethClients[chainID]
 	if !ok {
-		return netconf.Chain{}, nil, errors.New("no rpc client for chain ID")
+		return netconf.Chain{}, nil, errors.Wrap(ErrChainNotConfigured, "no rpc client for chain ID", "chain_id", chainID)
 	}
 
 	return chain, client, nil
 }
+
+// ErrChainNotConfigured is returned when a chain isn't configured, i.e., it isn't part of the network
+// or it doesn't have an RPC client (or consensus provider). This is a permanent config error,
+// so streams return it immediately instead of retrying.
+var ErrChainNotConfigured = errors.New("chain not configured")
+
+// configuredChain returns the chain if it is configured for streaming, or an error wrapping ErrChainNotConfigured.
+func (p *Provider) configuredChain(chainID uint64) (netconf.Chain, error) {
+	chain, ok := p.network.Chain(chainID)
+	if !ok {
+		return netconf.Chain{}, errors.Wrap(ErrChainNotConfigured, "unknown chain ID", "chain_id", chainID)
+	}
+
+	if chainID == p.cChainID {
+		if p.cProvider == nil {
+			return netconf.Chain{}, errors.Wrap(ErrChainNotConfigured, "no consensus provider", "chain_id", chainID)
+		}
+	} else if _, ok := p.ethClients[chainID]; !ok {
+		return netconf.Chain{}, errors.Wrap(ErrChainNotConfigured, "no rpc client for chain ID", "chain_id", chainID)
+	}
+
+	return chain, nil
+}
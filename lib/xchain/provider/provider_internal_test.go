@@ -2,11 +2,16 @@ package provider
 
 import (
 	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/expbackoff"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/require"
 )
 
 // NewForT returns a new provider for testing. Note that cprovider isn't supported yet.
@@ -15,17 +20,135 @@ func NewForT(
 	network netconf.Network,
 	rpcClients map[uint64]ethclient.Client,
 	backoffFunc func(ctx context.Context) func(),
-	workers int) *Provider {
+	workers int,
+	opts ...Option,
+) *Provider {
 	t.Helper()
 
-	for i := range fetchWorkerThresholds {
-		fetchWorkerThresholds[i].Workers = uint64(workers)
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
 	}
 
+	// Override the built-in fetch workers via the default chain options, since the thresholds are global.
+	o.defaultChainOpts = o.defaultChainOpts.merge(ChainOptions{FetchWorkers: uint64(workers)})
+
 	return &Provider{
 		network:     network,
 		ethClients:  rpcClients,
 		backoffFunc: backoffFunc,
+		opts:        o,
+		budget:      newByteBudget(o.maxInFlightBytes),
+		diskCache:   newDiskCache(o.diskCacheDB, o.diskCacheMaxBlocks, o.diskCacheTTL),
+		reorgLog:    newReorgLog(o.reorgLogDB),
+		heads:       newHeadStore(o.headStoreDB),
 		confHeads:   make(map[xchain.ChainVersion]uint64),
+		active:      make(map[activeKey]*activeStream),
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	// The clock is applied before the default config, so it must be retained.
+	clock := expbackoff.NewVirtualClock(time.Now())
+	backoff := newBackoffFunc(expbackoff.WithClock(clock))(context.Background())
+
+	const retries = 10
+	for range retries {
+		backoff()
 	}
+
+	sleeps := clock.Sleeps()
+	require.Len(t, sleeps, retries)
+	require.Equal(t, time.Second, sleeps[0]) // Base delay isn't jittered.
+	for _, sleep := range sleeps {
+		require.LessOrEqual(t, sleep, time.Second*12) // Max delay (10s) plus 20% jitter.
+	}
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	block := func(ts time.Time) xchain.Block {
+		return xchain.Block{BlockHeader: xchain.BlockHeader{Timestamp: ts}}
+	}
+
+	const skew = time.Minute
+
+	require.NoError(t, verifyTimestamp(block(now.Add(-time.Hour)), now, skew))
+	require.NoError(t, verifyTimestamp(block(now), now, skew))
+	require.NoError(t, verifyTimestamp(block(now.Add(skew)), now, skew)) // Minor skew is allowed
+	require.ErrorContains(t, verifyTimestamp(block(now.Add(skew+time.Second)), now, skew), "block timestamp in the future")
+	require.ErrorContains(t, verifyTimestamp(block(now.Add(time.Hour*24)), now, skew), "block timestamp in the future")
+
+	// Zero skew disables the check.
+	require.NoError(t, verifyTimestamp(block(now.Add(time.Hour*24)), now, 0))
+}
+
+func TestVerifyMsgPayloads(t *testing.T) {
+	t.Parallel()
+
+	msg := func(offset uint64, size int) xchain.Msg {
+		return xchain.Msg{
+			MsgID: xchain.MsgID{StreamID: xchain.StreamID{SourceChainID: 1, DestChainID: 2}, StreamOffset: offset},
+			Data:  make([]byte, size),
+		}
+	}
+	msgs := func() []xchain.Msg {
+		return []xchain.Msg{msg(1, 10), msg(2, 11), msg(3, 0)}
+	}
+
+	newProvider := func(opts ...Option) *Provider {
+		o := defaultOptions()
+		for _, opt := range opts {
+			opt(&o)
+		}
+
+		return &Provider{opts: o}
+	}
+
+	// Disabled by default.
+	require.NoError(t, newProvider().verifyMsgPayloads("test", msgs()))
+
+	// Within limit.
+	require.NoError(t, newProvider(WithMaxMsgPayloadBytes(11)).verifyMsgPayloads("test", msgs()))
+
+	// Oversized msgs fail the fetch, they are never dropped.
+	err := newProvider(WithMaxMsgPayloadBytes(10)).verifyMsgPayloads("test", msgs())
+	require.ErrorContains(t, err, "xmsg payload exceeds maximum")
+}
+
+func TestStreamMetaRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	metaCtx := func(i int) context.Context {
+		ctx, err := WithStreamMeta(ctx, map[string]string{"consumer": strconv.Itoa(i)})
+		require.NoError(t, err)
+
+		return ctx
+	}
+
+	p1, p2 := new(Provider), new(Provider)
+	for i := range maxMetaLabels {
+		_, err := p1.streamMeta(metaCtx(i))
+		require.NoError(t, err)
+	}
+
+	// Existing labels and streams without metadata are always allowed.
+	meta, err := p1.streamMeta(metaCtx(0))
+	require.NoError(t, err)
+	require.Equal(t, "consumer=0", meta.label)
+	_, err = p1.streamMeta(ctx)
+	require.NoError(t, err)
+
+	// New labels exceeding the max are rejected.
+	_, err = p1.streamMeta(metaCtx(maxMetaLabels))
+	require.ErrorContains(t, err, "too many distinct stream metadata sets")
+
+	// Labels are tracked per provider.
+	_, err = p2.streamMeta(metaCtx(maxMetaLabels))
+	require.NoError(t, err)
 }
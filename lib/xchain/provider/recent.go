@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// StreamRecentFirst blocks, first delivering the last count finalized blocks of the chain in descending
+// (newest-first) order, and then streaming newer finalized blocks in normal ascending order as they become
+// available. It is intended for recent-first UIs, e.g. live feeds seeded with history.
+//
+// The history is clamped to the chain's deploy height like streams, see WithAllowBelowDeployHeight.
+// Cursor semantics only apply to the live (ascending) portion; the descending history
+// must not be used to commit cursors.
+//
+// It retries forever (with backoff) on all fetch errors. It however returns the first callback error.
+// It returns nil when the context is canceled.
+func (p *Provider) StreamRecentFirst(ctx context.Context, chainID uint64, count uint64, callback xchain.ProviderCallback) error {
+	chain, ok := p.network.Chain(chainID)
+	if !ok {
+		return errors.New("unknown chain ID")
+	}
+
+	chainVer := xchain.ChainVersion{ID: chainID, ConfLevel: xchain.ConfFinalized}
+	head, err := p.ChainVersionHeight(ctx, chainVer)
+	if ctx.Err() != nil {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "chain version height")
+	}
+
+	lowest := p.startHeight(ctx, chain, 0)
+	if head >= lowest && count > 0 && count-1 < head-lowest { // Avoids overflow
+		lowest = head - count + 1
+	}
+
+	for height := head; count > 0 && height >= lowest; height-- {
+		req := xchain.ProviderRequest{ChainID: chainID, Height: height, ConfLevel: chainVer.ConfLevel}
+		block, err := p.getFinalizedForever(ctx, req)
+		if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := callback(ctx, block); err != nil {
+			return errors.Wrap(err, "callback", "height", height)
+		}
+
+		if height == 0 { // Avoids underflow
+			break
+		}
+	}
+
+	req := xchain.ProviderRequest{ChainID: chainID, Height: head + 1, ConfLevel: chainVer.ConfLevel}
+
+	return p.StreamBlocks(ctx, req, callback)
+}
+
+// getFinalizedForever returns the available block, retrying forever (with backoff) on errors.
+// It only returns an error if the context is canceled.
+func (p *Provider) getFinalizedForever(ctx context.Context, req xchain.ProviderRequest) (xchain.Block, error) {
+	backoff := p.backoffFunc(ctx)
+	for {
+		block, ok, err := p.GetBlock(ctx, req)
+		if ctx.Err() != nil {
+			return xchain.Block{}, errors.Wrap(ctx.Err(), "get block")
+		} else if err != nil {
+			log.Warn(ctx, "Failed fetching recent block (will retry)", err, "height", req.Height)
+			backoff()
+
+			continue
+		} else if !ok {
+			// Heights up to the finalized head should be available, but RPC nodes may lag.
+			log.Warn(ctx, "Recent block not available yet (will retry)", nil, "height", req.Height)
+			backoff()
+
+			continue
+		}
+
+		return block, nil
+	}
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/umath"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrReorgTooDeep is returned by streams that detected a reorg deeper than the maximum reorg depth,
+// see WithMaxReorgDepth.
+var ErrReorgTooDeep = errors.New("reorg exceeds max depth")
+
+// reorgTracker detects reorgs of a stream by tracking the hashes of the last maxDepth+1 delivered blocks.
+// It is not thread-safe, since blocks are delivered sequentially.
+type reorgTracker struct {
+	maxDepth uint64
+	hashes   map[uint64]common.Hash // Delivered block hashes by height
+}
+
+// newReorgTracker returns a reorg tracker for the stream or nil if reorg detection is disabled.
+func (p *Provider) newReorgTracker(chainID uint64) *reorgTracker {
+	if p.opts.maxReorgDepth == 0 || chainID == p.cChainID {
+		return nil
+	}
+
+	return &reorgTracker{
+		maxDepth: p.opts.maxReorgDepth,
+		hashes:   make(map[uint64]common.Hash),
+	}
+}
+
+// Delivered records the delivered block, evicting blocks older than the maximum depth.
+// It is nil-safe, so streams without reorg detection don't track blocks.
+func (t *reorgTracker) Delivered(block xchain.Block) {
+	if t == nil {
+		return
+	}
+
+	t.set(block.BlockHeight, block.BlockHash)
+}
+
+func (t *reorgTracker) set(height uint64, hash common.Hash) {
+	t.hashes[height] = hash
+	if evict, ok := umath.Subtract(height, t.maxDepth+1); ok {
+		delete(t.hashes, evict)
+	}
+}
+
+// verifyReorg returns ErrReorgTooDeep if the block's parent doesn't match the previously delivered block
+// and the resulting reorg is deeper than the maximum depth. Shallower reorgs are logged and tolerated,
+// since the block correctly chains onto the new canonical chain.
+func (p *Provider) verifyReorg(ctx context.Context, t *reorgTracker, req xchain.ProviderRequest, block xchain.Block) error {
+	if t == nil {
+		return nil
+	}
+
+	parentHeight, ok := umath.Subtract(block.BlockHeight, 1)
+	if !ok {
+		return nil
+	}
+
+	parentHash, ok := t.hashes[parentHeight]
+	if !ok || parentHash == block.ParentHash {
+		return nil // First delivered block or no reorg.
+	}
+
+	// Compare all tracked blocks with the canonical chain to determine the reorg depth.
+	from := parentHeight
+	for {
+		prev, ok := umath.Subtract(from, 1)
+		if !ok {
+			break
+		} else if _, ok := t.hashes[prev]; !ok {
+			break
+		}
+		from = prev
+	}
+
+	headers, err := p.getHeadersForever(ctx, req, from, parentHeight)
+	if err != nil {
+		return err
+	}
+
+	var replaced []ReorgHeight
+	for _, header := range headers {
+		if old := t.hashes[header.BlockHeight]; old != header.BlockHash {
+			replaced = append(replaced, ReorgHeight{
+				Height:  header.BlockHeight,
+				OldHash: old,
+				NewHash: header.BlockHash,
+			})
+		}
+		t.set(header.BlockHeight, header.BlockHash) // Follow the new canonical chain.
+	}
+	depth := uint64(len(replaced))
+
+	chainVersionName := p.network.ChainVersionName(req.ChainVersion())
+	reorgTotal.WithLabelValues(chainVersionName).Inc()
+	reorgDepth.WithLabelValues(chainVersionName).Observe(float64(depth))
+
+	err = p.reorgLog.Append(ReorgEvent{
+		ChainID:      req.ChainID,
+		ChainVersion: chainVersionName,
+		Height:       block.BlockHeight,
+		Depth:        depth,
+		Replaced:     replaced,
+		DetectedAt:   time.Now(),
+	})
+	if err != nil {
+		// Don't halt the stream, the log is only for post-mortem analysis.
+		log.Warn(ctx, "Failed appending reorg log", err)
+	}
+
+	if depth > t.maxDepth {
+		return errors.Wrap(ErrReorgTooDeep, "reorg detected",
+			"height", block.BlockHeight,
+			"depth", depth,
+			"max_depth", t.maxDepth,
+		)
+	}
+
+	log.Warn(ctx, "Reorg detected, streaming new canonical chain", nil,
+		"depth", depth,
+		"max_depth", t.maxDepth,
+	)
+
+	return nil
+}
+
+// getHeadersForever returns the headers of the inclusive range, retrying forever (with backoff) on errors.
+// It only returns an error if the context is canceled.
+func (p *Provider) getHeadersForever(ctx context.Context, req xchain.ProviderRequest, from, to uint64) ([]xchain.Header, error) {
+	chain, ok := p.network.Chain(req.ChainID)
+	if !ok {
+		return nil, errors.New("unknown chain ID")
+	}
+
+	backoff := p.chainOptions(req.ChainID, chain.BlockPeriod).Backoff(ctx)
+	for {
+		headers, err := p.GetHeaders(ctx, req.ChainID, from, to)
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(ctx.Err(), "get headers")
+		} else if err != nil {
+			log.Warn(ctx, "Failed fetching reorg headers (will retry)", err)
+			backoff()
+
+			continue
+		}
+
+		return headers, nil
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+// prefixReorg is the reorg log key prefix: <prefix><seq> -> ReorgEvent.
+const prefixReorg byte = 'r'
+
+// ReorgEvent is a reorg detected by a stream, see WithReorgLog.
+type ReorgEvent struct {
+	ChainID      uint64        `json:"chain_id"`
+	ChainVersion string        `json:"chain_version"`
+	Height       uint64        `json:"height"` // Height of the block that triggered detection
+	Depth        uint64        `json:"depth"`
+	Replaced     []ReorgHeight `json:"replaced"` // Delivered blocks no longer canonical
+	DetectedAt   time.Time     `json:"detected_at"`
+}
+
+// ReorgHeight is the previously delivered (old) and new canonical block hash at a reorged height.
+type ReorgHeight struct {
+	Height  uint64      `json:"height"`
+	OldHash common.Hash `json:"old_hash"`
+	NewHash common.Hash `json:"new_hash"`
+}
+
+// reorgLog is a durable append-only log of detected reorgs for post-mortem analysis.
+// A nil log is disabled.
+type reorgLog struct {
+	db dbm.DB
+
+	mu          sync.Mutex
+	initialized bool
+	nextSeq     uint64
+}
+
+// newReorgLog returns a reorg log backed by the provided DB, or nil if the DB is nil (disabled).
+func newReorgLog(db dbm.DB) *reorgLog {
+	if db == nil {
+		return nil
+	}
+
+	return &reorgLog{db: db}
+}
+
+// Append appends the event to the log.
+func (l *reorgLog) Append(event ReorgEvent) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.initialized {
+		// Resume the sequence of existing events.
+		iter, err := l.db.ReverseIterator([]byte{prefixReorg}, []byte{prefixReorg + 1})
+		if err != nil {
+			return errors.Wrap(err, "reorg iterator")
+		}
+		if iter.Valid() {
+			l.nextSeq = binary.BigEndian.Uint64(iter.Key()[1:]) + 1
+		}
+		if err := iter.Close(); err != nil {
+			return errors.Wrap(err, "close iterator")
+		}
+		l.initialized = true
+	}
+
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "marshal reorg event")
+	}
+
+	if err := l.db.SetSync(reorgKey(l.nextSeq), bz); err != nil {
+		return errors.Wrap(err, "set reorg event")
+	}
+	l.nextSeq++
+
+	return nil
+}
+
+// ReadReorgLog returns all reorg events of the log backed by the provided DB in detection order.
+// It allows replaying the reorg history for post-mortem analysis, see WithReorgLog.
+func ReadReorgLog(db dbm.DB) ([]ReorgEvent, error) {
+	iter, err := db.Iterator([]byte{prefixReorg}, []byte{prefixReorg + 1})
+	if err != nil {
+		return nil, errors.Wrap(err, "reorg iterator")
+	}
+	defer iter.Close()
+
+	var resp []ReorgEvent
+	for ; iter.Valid(); iter.Next() {
+		var event ReorgEvent
+		if err := json.Unmarshal(iter.Value(), &event); err != nil {
+			return nil, errors.Wrap(err, "unmarshal reorg event")
+		}
+		resp = append(resp, event)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "iterate reorgs")
+	}
+
+	return resp, nil
+}
+
+func reorgKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{prefixReorg}, seq)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// ReplayHeights returns the heights that may be re-delivered when restarting a stream from the
+// last persisted cursor (req.Height), allowing consumers to pre-load state for idempotent handling.
+//
+// Streams provide at-least-once delivery: blocks delivered after the last persisted cursor are
+// re-delivered on restart. If a consumer persists its cursor at least every commitInterval blocks
+// (e.g. commitInterval=1 if persisted for each block), then at most the commitInterval heights
+// starting at the cursor (inclusive) could have been delivered before a crash. The window is further
+// bounded by the chain version head, since unavailable (not yet finalized) blocks were never delivered.
+//
+// The start height is clamped to the chain's deploy height like the stream itself, see WithAllowBelowDeployHeight.
+func (p *Provider) ReplayHeights(ctx context.Context, req xchain.ProviderRequest, commitInterval uint64) ([]uint64, error) {
+	if commitInterval == 0 {
+		return nil, errors.New("zero commit interval")
+	}
+
+	chain, ok := p.network.Chain(req.ChainID)
+	if !ok {
+		return nil, errors.New("unknown chain ID")
+	}
+
+	head, err := p.ChainVersionHeight(ctx, req.ChainVersion())
+	if err != nil {
+		return nil, errors.Wrap(err, "chain version height")
+	}
+
+	from := p.startHeight(ctx, chain, req.Height)
+	if from > head {
+		return nil, nil // Nothing delivered yet.
+	}
+
+	to := head
+	if commitInterval-1 < head-from { // Avoids overflow
+		to = from + commitInterval - 1
+	}
+
+	resp := make([]uint64, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		resp = append(resp, h)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// callbackRoutes routes blocks of StreamMany to group-specific callbacks, see WithCallbackRoutes.
+type callbackRoutes struct {
+	groups    map[uint64]string                  // Group name by chain ID
+	callbacks map[string]xchain.ProviderCallback // Callback by group name
+}
+
+// route returns the callback of the chain's group or the default callback if the chain isn't routed.
+// It returns an error if the chain's group has no callback or if the resulting callback is nil.
+func (r callbackRoutes) route(chainID uint64, defaultCallback xchain.ProviderCallback) (xchain.ProviderCallback, error) {
+	group, ok := r.groups[chainID]
+	if !ok {
+		if defaultCallback == nil {
+			return nil, errors.New("nil default callback of unrouted chain", "chain_id", chainID)
+		}
+
+		return defaultCallback, nil
+	}
+
+	callback, ok := r.callbacks[group]
+	if !ok || callback == nil {
+		return nil, errors.New("missing group callback", "chain_id", chainID, "group", group)
+	}
+
+	return callback, nil
+}
+
+// StreamMany starts async streams for all the requests, dispatching the blocks of each chain
+// to the callback of the chain's group, see WithCallbackRoutes. Blocks of chains not mapped to
+// a group are delivered to the default callback.
+//
+// It returns immediately. It validates all requests and routes before starting any stream,
+// returning an error if a chain is unknown, duplicated, or has no callback, see StreamAsync.
+func (p *Provider) StreamMany(ctx context.Context, reqs []xchain.ProviderRequest, defaultCallback xchain.ProviderCallback) error {
+	if len(reqs) == 0 {
+		return errors.New("no stream requests")
+	}
+
+	callbacks := make([]xchain.ProviderCallback, 0, len(reqs))
+	dups := make(map[xchain.ChainVersion]bool)
+	for _, req := range reqs {
+		if _, err := p.configuredChain(req.ChainID); err != nil {
+			return err
+		} else if dups[req.ChainVersion()] {
+			return errors.New("duplicate stream request", "chain", p.network.ChainVersionName(req.ChainVersion()))
+		}
+		dups[req.ChainVersion()] = true
+
+		callback, err := p.opts.callbackRoutes.route(req.ChainID, defaultCallback)
+		if err != nil {
+			return err
+		}
+		callbacks = append(callbacks, callback)
+	}
+
+	for i, req := range reqs {
+		if err := p.StreamAsync(ctx, req, callbacks[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
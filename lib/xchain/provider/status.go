@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// StreamStatus is the diagnostic status of the streams of a chain version.
+type StreamStatus struct {
+	ChainVersion xchain.ChainVersion
+	LastError    string    // Most recent (redacted) fetch or callback error, empty if none.
+	LastErrorAt  time.Time // Time of the most recent error, zero if none.
+}
+
+// StreamStatus returns the status of the streams of the chain version.
+// It returns false if no stream of the chain version errored yet.
+func (p *Provider) StreamStatus(chainVer xchain.ChainVersion) (StreamStatus, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status, ok := p.statuses[chainVer]
+
+	return status, ok
+}
+
+// setLastError records the most recent stream error of the chain version, redacting sensitive URL components.
+// Context cancellation errors are ignored since they are expected when streams stop.
+func (p *Provider) setLastError(chainVer xchain.ChainVersion, err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.statuses == nil {
+		p.statuses = make(map[xchain.ChainVersion]StreamStatus)
+	}
+
+	p.statuses[chainVer] = StreamStatus{
+		ChainVersion: chainVer,
+		LastError:    redactURLs(err.Error()),
+		LastErrorAt:  time.Now(),
+	}
+}
+
+// urlRegex matches URLs in error strings, e.g. RPC endpoints.
+var urlRegex = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// minSecretSegment is the minimum length of URL path segments that are redacted
+// since they are likely API keys, e.g. "https://mainnet.infura.io/v3/<key>".
+const minSecretSegment = 20
+
+// redactURLs returns the string with the user info, query values, and likely API key path segments
+// of all URLs redacted, while keeping the scheme and host for diagnosis.
+func redactURLs(s string) string {
+	return urlRegex.ReplaceAllStringFunc(s, func(raw string) string {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "<redacted>"
+		}
+
+		if u.User != nil {
+			u.User = url.User("redacted")
+		}
+
+		if u.RawQuery != "" {
+			query := u.Query()
+			for key := range query {
+				query.Set(key, "redacted")
+			}
+			u.RawQuery = query.Encode()
+		}
+
+		segments := strings.Split(u.Path, "/")
+		for i, segment := range segments {
+			if len(segment) >= minSecretSegment {
+				segments[i] = "redacted"
+			}
+		}
+		u.Path = strings.Join(segments, "/")
+		u.RawPath = ""
+
+		return u.String()
+	})
+}
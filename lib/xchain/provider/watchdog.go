@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+type progressKey struct{}
+
+// streamProgress tracks the progress of a stream for the watchdog, see WithStreamWatchdog.
+// All methods are nil-safe, so streams without a watchdog don't track progress.
+type streamProgress struct {
+	mu        sync.Mutex
+	lastAt    time.Time
+	processed uint64
+	ok        bool
+}
+
+func newStreamProgress() *streamProgress {
+	return &streamProgress{lastAt: time.Now()}
+}
+
+// Fetched records a successfully fetched block.
+func (s *streamProgress) Fetched() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastAt = time.Now()
+}
+
+// Processed records a successfully processed block height.
+func (s *streamProgress) Processed(height uint64) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastAt = time.Now()
+	s.processed = height
+	s.ok = true
+}
+
+// idle returns the duration since the last progress.
+func (s *streamProgress) idle() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return time.Since(s.lastAt)
+}
+
+// next returns the next height to process, or fromHeight if nothing was processed yet.
+func (s *streamProgress) next(fromHeight uint64) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ok {
+		return fromHeight
+	}
+
+	return s.processed + 1
+}
+
+// withStreamProgress returns a copy of the context with the provided stream progress attached.
+func withStreamProgress(ctx context.Context, progress *streamProgress) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+// progressFromCtx returns the stream progress attached to the context or nil.
+func progressFromCtx(ctx context.Context) *streamProgress {
+	progress, _ := ctx.Value(progressKey{}).(*streamProgress)
+	return progress
+}
+
+// streamWatched streams like StreamAsync, but restarts the stream from the next unprocessed height
+// whenever the watchdog detects a stall, see WithStreamWatchdog.
+func (p *Provider) streamWatched(ctx context.Context, req xchain.ProviderRequest, callback xchain.ProviderCallback) error {
+	chainVersionName := p.network.ChainVersionName(req.ChainVersion())
+	meta := metaFromCtx(ctx)
+
+	for {
+		progress := newStreamProgress()
+		streamCtx, cancel := context.WithCancel(ctx)
+
+		var stalled atomic.Bool
+		go func() {
+			if p.watchStream(streamCtx, req, progress) {
+				stalled.Store(true)
+				cancel()
+			}
+		}()
+
+		err := p.stream(withStreamProgress(streamCtx, progress), req, callback, true)
+		cancel()
+		if ctx.Err() != nil || !stalled.Load() {
+			return err
+		}
+
+		req.Height = progress.next(req.Height)
+		watchdogRestarts.WithLabelValues(chainVersionName, meta.label).Inc()
+		log.Warn(ctx, "Restarting stalled xprovider stream", nil,
+			"chain", chainVersionName,
+			"height", req.Height,
+			"window", p.opts.watchdogWindow,
+		)
+	}
+}
+
+// watchStream blocks until the stream is stalled, returning true, or until the context is canceled, returning false.
+// A stream is stalled if it didn't make progress within the watchdog window while the chain head
+// reached the next unprocessed height.
+func (p *Provider) watchStream(ctx context.Context, req xchain.ProviderRequest, progress *streamProgress) bool {
+	window := p.opts.watchdogWindow
+
+	ticker := time.NewTicker(window / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		if progress.idle() < window {
+			continue
+		}
+
+		head, err := p.ChainVersionHeight(ctx, req.ChainVersion())
+		if err != nil {
+			continue // Unknown whether the chain is producing blocks, stream fetch errors are logged already.
+		} else if head < progress.next(req.Height) {
+			continue // Chain is not producing blocks, stream is idle.
+		}
+
+		return true
+	}
+}
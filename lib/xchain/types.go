@@ -155,6 +155,7 @@ type Msg struct {
 	DestGasLimit    uint64         // Gas limit to use for "call" on destination chain
 	TxHash          common.Hash    // Hash of the source chain transaction that emitted the message
 	Fees            *big.Int       // Fees paid for the xcall
+	Proof           *ReceiptProof  // Inclusion proof of the emitting tx receipt, nil if not requested
 }
 
 // Receipt is a cross-chain message receipt, the result of applying the Msg on the destination chain.
@@ -165,6 +166,7 @@ type Receipt struct {
 	Error          []byte         // Error message if the message failed
 	RelayerAddress common.Address // Address of relayer that submitted the message
 	TxHash         common.Hash    // Hash of the relayer submission transaction
+	Unmatched      bool           // True if the provider didn't deliver the message, see provider.WithOrphanReceipts
 }
 
 // BlockHeader uniquely identifies a cross chain block.
@@ -183,6 +185,14 @@ type Block struct {
 	Timestamp  time.Time   // Timestamp of the source chain block
 }
 
+// Header is a lightweight representation of a source chain EVM block header,
+// excluding the cross-chain messages and receipts of the Block.
+type Header struct {
+	BlockHeader
+	ParentHash common.Hash // ParentHash is the hash of the parent block.
+	Timestamp  time.Time   // Timestamp of the source chain block
+}
+
 // ShouldAttest returns true if the xblock should be attested by the omni consensus chain validators.
 // All "non-empty" xblocks should be attested to.
 // Every Nth block based on the chain's attest interval should be attested to.
@@ -71,7 +71,10 @@ func Run(ctx context.Context, cfg Config) error {
 
 	cprov := cprovider.NewABCIProvider(tmClient, network.ID, netconf.ChainVersionNamer(cfg.Network))
 
-	xprov := xprovider.New(network, ethClients, cprov)
+	xprov, err := xprovider.New(network, ethClients, cprov)
+	if err != nil {
+		return errors.Wrap(err, "create xprovider")
+	}
 
 	if err := avs.StartMonitor(ctx, network, ethClients); err != nil {
 		return errors.Wrap(err, "monitor AVS")
@@ -137,7 +140,15 @@ func startIndexer(
 		}
 	}
 
-	return indexer.Start(ctx, network, xprov, db)
+	var opts []indexer.Option
+	if cfg.IndexerCompact {
+		opts = append(opts, indexer.WithCompactEncoding())
+	}
+	if cfg.IndexerMigrate {
+		opts = append(opts, indexer.WithMigration())
+	}
+
+	return indexer.Start(ctx, network, xprov, db, opts...)
 }
 
 // startXMonitor starts the xchain offset/head monitoring.
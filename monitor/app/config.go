@@ -26,6 +26,8 @@ type Config struct {
 	LoadGen        loadgen.Config
 	XFeeMngr       xfeemngr.Config
 	DBDir          string
+	IndexerCompact bool // Stores indexed blocks using compact protobuf encoding instead of JSON.
+	IndexerMigrate bool // Re-encodes existing indexed blocks on startup, see IndexerCompact.
 }
 
 func DefaultConfig() Config {
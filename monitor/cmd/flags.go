@@ -17,6 +17,8 @@ func bindRunFlags(flags *pflag.FlagSet, cfg *monitor.Config) {
 	flags.StringVar(&cfg.MonitoringAddr, "monitoring-addr", cfg.MonitoringAddr, "The address to bind the monitoring server")
 	flags.StringVar(&cfg.HaloURL, "halo-url", cfg.HaloURL, "The URL of the halo node e.g localhost:26657")
 	flags.StringVar(&cfg.DBDir, "db-dir", cfg.DBDir, "The path to the database directory")
+	flags.BoolVar(&cfg.IndexerCompact, "indexer-compact", cfg.IndexerCompact, "Store indexed blocks using compact protobuf encoding instead of JSON; see --indexer-migrate to migrate existing blocks")
+	flags.BoolVar(&cfg.IndexerMigrate, "indexer-migrate", cfg.IndexerMigrate, "Re-encode existing indexed blocks with the configured encoding on startup; only required once after changing --indexer-compact")
 }
 
 func bindLoadGenFlags(flags *pflag.FlagSet, cfg *loadgen.Config) {
@@ -0,0 +1,146 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"cosmossdk.io/core/store"
+	db "github.com/cosmos/cosmos-db"
+)
+
+// BatchSink is an optional BlockSink extension that commits all writes of an indexed block in a single batch.
+type BatchSink interface {
+	BlockSink
+	// Batch calls fn with a context that batches all sink writes using it.
+	// The writes are committed atomically if fn returns nil, otherwise they are discarded.
+	Batch(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+var _ BatchSink = ormSink{}
+
+func (s ormSink) Batch(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.db == nil {
+		return errors.New("batch not supported by sink DB")
+	}
+
+	batch := newBatchStore(s.db)
+	if err := fn(withBatchStore(ctx, batch)); err != nil {
+		return err
+	}
+
+	return batch.Commit()
+}
+
+type batchStoreKey struct{}
+
+// withBatchStore returns a copy of the context with the batch store, see dbStoreService.
+func withBatchStore(ctx context.Context, batch *batchStore) context.Context {
+	return context.WithValue(ctx, batchStoreKey{}, batch)
+}
+
+// batchFromCtx returns the batch store of the context or false.
+func batchFromCtx(ctx context.Context) (*batchStore, bool) {
+	batch, ok := ctx.Value(batchStoreKey{}).(*batchStore)
+	return batch, ok
+}
+
+var _ store.KVStore = (*batchStore)(nil)
+
+// batchStore is a KVStore that buffers writes in memory on top of a DB until committed in a single DB batch.
+// Reads see the buffered writes. Iteration is only supported while no writes are buffered,
+// which suffices for the ORM insert, get and save operations of the index write path.
+type batchStore struct {
+	db     db.DB
+	writes map[string][]byte // Buffered writes by key, nil values are deletes.
+	keys   []string          // Buffered keys in write order.
+}
+
+func newBatchStore(db db.DB) *batchStore {
+	return &batchStore{
+		db:     db,
+		writes: make(map[string][]byte),
+	}
+}
+
+func (b *batchStore) Get(key []byte) ([]byte, error) {
+	if value, ok := b.writes[string(key)]; ok {
+		return value, nil
+	}
+
+	return b.db.Get(key)
+}
+
+func (b *batchStore) Has(key []byte) (bool, error) {
+	if value, ok := b.writes[string(key)]; ok {
+		return value != nil, nil
+	}
+
+	return b.db.Has(key)
+}
+
+func (b *batchStore) Set(key, value []byte) error {
+	if value == nil {
+		return errors.New("nil value")
+	}
+
+	b.write(key, append([]byte{}, value...))
+
+	return nil
+}
+
+func (b *batchStore) Delete(key []byte) error {
+	b.write(key, nil)
+	return nil
+}
+
+func (b *batchStore) write(key, value []byte) {
+	if _, ok := b.writes[string(key)]; !ok {
+		b.keys = append(b.keys, string(key))
+	}
+	b.writes[string(key)] = value
+}
+
+func (b *batchStore) Iterator(start, end []byte) (store.Iterator, error) {
+	if len(b.writes) > 0 {
+		return nil, errors.New("iterating uncommitted batch not supported")
+	}
+
+	return b.db.Iterator(start, end)
+}
+
+func (b *batchStore) ReverseIterator(start, end []byte) (store.Iterator, error) {
+	if len(b.writes) > 0 {
+		return nil, errors.New("iterating uncommitted batch not supported")
+	}
+
+	return b.db.ReverseIterator(start, end)
+}
+
+// Commit writes all buffered writes to the DB in a single atomic batch.
+func (b *batchStore) Commit() error {
+	if len(b.keys) == 0 {
+		return nil
+	}
+
+	batch := b.db.NewBatch()
+	defer batch.Close()
+
+	for _, key := range b.keys {
+		var err error
+		if value := b.writes[key]; value == nil {
+			err = batch.Delete([]byte(key))
+		} else {
+			err = batch.Set([]byte(key), value)
+		}
+		if err != nil {
+			return errors.Wrap(err, "batch write")
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return errors.Wrap(err, "commit batch", "writes", len(b.keys))
+	}
+
+	return nil
+}
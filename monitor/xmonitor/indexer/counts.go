@@ -0,0 +1,119 @@
+package indexer
+
+import (
+	"context"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+)
+
+// CountBlocks returns the number of indexed blocks of the chain.
+// It only iterates the chain's block index keys, so it doesn't decode any blocks.
+func (i *indexer) CountBlocks(ctx context.Context, chainID uint64) (uint64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	iter, err := i.blockTable.List(ctx, BlockChainIdBlockHeightBlockHashIndexKey{}.WithChainId(chainID))
+	if err != nil {
+		return 0, errors.Wrap(err, "list blocks")
+	}
+	defer iter.Close()
+
+	var resp uint64
+	for iter.Next() {
+		resp++
+	}
+
+	return resp, nil
+}
+
+// CountMsgLinks returns the number of msg links.
+// It only iterates the msg_block_id index keys, so it doesn't decode any links.
+func (i *indexer) CountMsgLinks(ctx context.Context) (uint64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.countMsgLinksUnsafe(ctx, MsgLinkMsgBlockIdIndexKey{})
+}
+
+// countMsgLinksUnsafe returns the number of msg links in the provided index.
+// It assumes the lock is held.
+func (i *indexer) countMsgLinksUnsafe(ctx context.Context, key MsgLinkIndexKey) (uint64, error) {
+	iter, err := i.msgLinkTable.List(ctx, key)
+	if err != nil {
+		return 0, errors.Wrap(err, "list msg links")
+	}
+	defer iter.Close()
+
+	var resp uint64
+	for iter.Next() {
+		resp++
+	}
+
+	return resp, nil
+}
+
+// CountCursors returns the number of cursors.
+func (i *indexer) CountCursors(ctx context.Context) (uint64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	iter, err := i.cursorTable.List(ctx, CursorPrimaryKey{})
+	if err != nil {
+		return 0, errors.Wrap(err, "list cursors")
+	}
+	defer iter.Close()
+
+	var resp uint64
+	for iter.Next() {
+		resp++
+	}
+
+	return resp, nil
+}
+
+// instrumentCounts updates the table row count gauges.
+func (i *indexer) instrumentCounts(ctx context.Context, network netconf.Network) error {
+	for _, chain := range network.Chains {
+		count, err := i.CountBlocks(ctx, chain.ID)
+		if err != nil {
+			return err
+		}
+		blocksGauge.WithLabelValues(chain.Name).Set(float64(count))
+	}
+
+	count, err := i.CountMsgLinks(ctx)
+	if err != nil {
+		return err
+	}
+	msgLinksGauge.Set(float64(count))
+
+	count, err = i.CountCursors(ctx)
+	if err != nil {
+		return err
+	}
+	cursorsGauge.Set(float64(count))
+
+	return nil
+}
+
+// instrumentCountsForever updates the table row count gauges every interval, see WithCountInterval.
+func instrumentCountsForever(ctx context.Context, i *indexer, network netconf.Network, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := i.instrumentCounts(ctx, network); ctx.Err() != nil {
+				return
+			} else if err != nil {
+				log.Warn(ctx, "Failed to count indexed rows (will retry)", err)
+			}
+		}
+	}
+}
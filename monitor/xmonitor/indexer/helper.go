@@ -1,23 +1,236 @@
 package indexer
 
 import (
+	"cmp"
 	"encoding/json"
+	"math/big"
 
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/xchain"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func (b *Block) XChainBlock() (xchain.Block, error) {
-	var resp xchain.Block
-	if err := json.Unmarshal(b.GetBlockJson(), &resp); err != nil { //nolint:musttag // TODO: Rather use protobuf
-		return xchain.Block{}, errors.Wrap(err, "unmarshal block")
-	}
+// Block encoding versions, see Block.Version.
+const (
+	versionJSON  uint32 = 0 // Legacy JSON encoding.
+	versionProto uint32 = 1 // Compact XBlock protobuf encoding.
+)
+
+// ErrIncomparableCursors is returned when comparing cursors of different chains or confirmation levels.
+var ErrIncomparableCursors = errors.New("incomparable cursors")
 
-	return resp, nil
+// XChainBlock returns the decoded xchain block.
+func (b *Block) XChainBlock() (xchain.Block, error) {
+	return decodeBlock(b.GetVersion(), b.GetBlockJson())
 }
 
 func (l *MsgLink) Hash() common.Hash {
 	return common.BytesToHash(l.GetIdHash())
 }
+
+// Compare returns -1, 0 or +1 if the cursor's block height is lower, equal to or higher than the other cursor's.
+// It returns ErrIncomparableCursors if the cursors are of different chains or confirmation levels.
+func (c *Cursor) Compare(other *Cursor) (int, error) {
+	if c.GetChainId() != other.GetChainId() || c.GetConfLevel() != other.GetConfLevel() {
+		return 0, errors.Wrap(ErrIncomparableCursors, "compare cursors",
+			"chain_id", c.GetChainId(),
+			"conf_level", xchain.ConfLevel(c.GetConfLevel()),
+			"other_chain_id", other.GetChainId(),
+			"other_conf_level", xchain.ConfLevel(other.GetConfLevel()),
+		)
+	}
+
+	return cmp.Compare(c.GetBlockHeight(), other.GetBlockHeight()), nil
+}
+
+// IsAhead returns true if the cursor's block height is higher than the other cursor's.
+// It returns ErrIncomparableCursors if the cursors are of different chains or confirmation levels.
+func (c *Cursor) IsAhead(other *Cursor) (bool, error) {
+	resp, err := c.Compare(other)
+	if err != nil {
+		return false, err
+	}
+
+	return resp > 0, nil
+}
+
+// encodeBlock returns the block encoded as per the provided version.
+func encodeBlock(version uint32, block xchain.Block) ([]byte, error) {
+	switch version {
+	case versionJSON:
+		bz, err := json.Marshal(block) //nolint:musttag // Legacy encoding
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal block json")
+		}
+
+		return bz, nil
+	case versionProto:
+		bz, err := proto.Marshal(blockToProto(block))
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal block proto")
+		}
+
+		return bz, nil
+	default:
+		return nil, errors.New("unknown block encoding version", "version", version)
+	}
+}
+
+// decodeBlock returns the block decoded as per the provided version.
+func decodeBlock(version uint32, bz []byte) (xchain.Block, error) {
+	switch version {
+	case versionJSON:
+		var resp xchain.Block
+		if err := json.Unmarshal(bz, &resp); err != nil { //nolint:musttag // Legacy encoding
+			return xchain.Block{}, errors.Wrap(err, "unmarshal block json")
+		}
+
+		return resp, nil
+	case versionProto:
+		pb := new(XBlock)
+		if err := proto.Unmarshal(bz, pb); err != nil {
+			return xchain.Block{}, errors.Wrap(err, "unmarshal block proto")
+		}
+
+		return blockFromProto(pb), nil
+	default:
+		return xchain.Block{}, errors.New("unknown block encoding version", "version", version)
+	}
+}
+
+func blockToProto(block xchain.Block) *XBlock {
+	msgs := make([]*XMsg, 0, len(block.Msgs))
+	for _, msg := range block.Msgs {
+		var fees []byte
+		if msg.Fees != nil {
+			fees = msg.Fees.Bytes()
+			if fees == nil {
+				fees = []byte{} // Non-nil empty bytes for zero, so it is distinguishable from nil.
+			}
+		}
+
+		var proof *XReceiptProof
+		if msg.Proof != nil {
+			proof = &XReceiptProof{
+				Header:  msg.Proof.Header,
+				TxIndex: msg.Proof.TxIndex,
+				Receipt: msg.Proof.Receipt,
+				Nodes:   msg.Proof.Nodes,
+			}
+		}
+
+		msgs = append(msgs, &XMsg{
+			SourceChainId:   msg.SourceChainID,
+			DestChainId:     msg.DestChainID,
+			ShardId:         uint64(msg.ShardID),
+			StreamOffset:    msg.StreamOffset,
+			SourceMsgSender: msg.SourceMsgSender.Bytes(),
+			DestAddress:     msg.DestAddress.Bytes(),
+			Data:            msg.Data,
+			DestGasLimit:    msg.DestGasLimit,
+			TxHash:          msg.TxHash.Bytes(),
+			Fees:            fees,
+			Proof:           proof,
+		})
+	}
+
+	receipts := make([]*XReceipt, 0, len(block.Receipts))
+	for _, receipt := range block.Receipts {
+		receipts = append(receipts, &XReceipt{
+			SourceChainId:  receipt.SourceChainID,
+			DestChainId:    receipt.DestChainID,
+			ShardId:        uint64(receipt.ShardID),
+			StreamOffset:   receipt.StreamOffset,
+			GasUsed:        receipt.GasUsed,
+			Success:        receipt.Success,
+			Error:          receipt.Error,
+			RelayerAddress: receipt.RelayerAddress.Bytes(),
+			TxHash:         receipt.TxHash.Bytes(),
+			Unmatched:      receipt.Unmatched,
+		})
+	}
+
+	return &XBlock{
+		ChainId:     block.ChainID,
+		BlockHeight: block.BlockHeight,
+		BlockHash:   block.BlockHash.Bytes(),
+		ParentHash:  block.ParentHash.Bytes(),
+		Timestamp:   timestamppb.New(block.Timestamp),
+		Msgs:        msgs,
+		Receipts:    receipts,
+	}
+}
+
+func blockFromProto(pb *XBlock) xchain.Block {
+	var msgs []xchain.Msg
+	for _, msg := range pb.GetMsgs() {
+		var fees *big.Int
+		if msg.GetFees() != nil { // Fees is optional, so nil if unset.
+			fees = new(big.Int).SetBytes(msg.GetFees())
+		}
+
+		var proof *xchain.ReceiptProof
+		if p := msg.GetProof(); p != nil {
+			proof = &xchain.ReceiptProof{
+				Header:  p.GetHeader(),
+				TxIndex: p.GetTxIndex(),
+				Receipt: p.GetReceipt(),
+				Nodes:   p.GetNodes(),
+			}
+		}
+
+		msgs = append(msgs, xchain.Msg{
+			MsgID: xchain.MsgID{
+				StreamID: xchain.StreamID{
+					SourceChainID: msg.GetSourceChainId(),
+					DestChainID:   msg.GetDestChainId(),
+					ShardID:       xchain.ShardID(msg.GetShardId()),
+				},
+				StreamOffset: msg.GetStreamOffset(),
+			},
+			SourceMsgSender: common.BytesToAddress(msg.GetSourceMsgSender()),
+			DestAddress:     common.BytesToAddress(msg.GetDestAddress()),
+			Data:            msg.GetData(),
+			DestGasLimit:    msg.GetDestGasLimit(),
+			TxHash:          common.BytesToHash(msg.GetTxHash()),
+			Fees:            fees,
+			Proof:           proof,
+		})
+	}
+
+	var receipts []xchain.Receipt
+	for _, receipt := range pb.GetReceipts() {
+		receipts = append(receipts, xchain.Receipt{
+			MsgID: xchain.MsgID{
+				StreamID: xchain.StreamID{
+					SourceChainID: receipt.GetSourceChainId(),
+					DestChainID:   receipt.GetDestChainId(),
+					ShardID:       xchain.ShardID(receipt.GetShardId()),
+				},
+				StreamOffset: receipt.GetStreamOffset(),
+			},
+			GasUsed:        receipt.GetGasUsed(),
+			Success:        receipt.GetSuccess(),
+			Error:          receipt.GetError(),
+			RelayerAddress: common.BytesToAddress(receipt.GetRelayerAddress()),
+			TxHash:         common.BytesToHash(receipt.GetTxHash()),
+			Unmatched:      receipt.GetUnmatched(),
+		})
+	}
+
+	return xchain.Block{
+		BlockHeader: xchain.BlockHeader{
+			ChainID:     pb.GetChainId(),
+			BlockHeight: pb.GetBlockHeight(),
+			BlockHash:   common.BytesToHash(pb.GetBlockHash()),
+		},
+		Msgs:       msgs,
+		Receipts:   receipts,
+		ParentHash: common.BytesToHash(pb.GetParentHash()),
+		Timestamp:  pb.GetTimestamp().AsTime(),
+	}
+}
@@ -0,0 +1,147 @@
+package indexer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MarshalBlockJSON returns the protojson encoding of the block with bytes fields (e.g. block_hash) as 0x-prefixed hex.
+func MarshalBlockJSON(block *Block) ([]byte, error) {
+	return marshalHexJSON(block)
+}
+
+// UnmarshalBlockJSON returns the block decoded from MarshalBlockJSON output.
+func UnmarshalBlockJSON(bz []byte) (*Block, error) {
+	resp := new(Block)
+	if err := unmarshalHexJSON(bz, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// MarshalMsgLinkJSON returns the protojson encoding of the msg link with bytes fields (e.g. id_hash) as 0x-prefixed hex.
+func MarshalMsgLinkJSON(link *MsgLink) ([]byte, error) {
+	return marshalHexJSON(link)
+}
+
+// UnmarshalMsgLinkJSON returns the msg link decoded from MarshalMsgLinkJSON output.
+func UnmarshalMsgLinkJSON(bz []byte) (*MsgLink, error) {
+	resp := new(MsgLink)
+	if err := unmarshalHexJSON(bz, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// marshalHexJSON returns the protojson encoding of the flat message with bytes fields
+// converted from base64 (protojson default) to 0x-prefixed hex.
+func marshalHexJSON(msg proto.Message) ([]byte, error) {
+	bz, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal protojson")
+	}
+
+	fields, err := bytesFields(msg, bz)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, field := range fields.bytes {
+		var b64 string
+		if err := json.Unmarshal(field, &b64); err != nil {
+			return nil, errors.Wrap(err, "unmarshal bytes field", "field", name)
+		}
+
+		b, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode base64", "field", name)
+		}
+
+		if fields.all[name], err = json.Marshal(hexutil.Encode(b)); err != nil {
+			return nil, errors.Wrap(err, "marshal hex")
+		}
+	}
+
+	resp, err := json.Marshal(fields.all)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json")
+	}
+
+	return resp, nil
+}
+
+// unmarshalHexJSON decodes marshalHexJSON output into the provided flat message.
+func unmarshalHexJSON(bz []byte, msg proto.Message) error {
+	fields, err := bytesFields(msg, bz)
+	if err != nil {
+		return err
+	}
+
+	for name, field := range fields.bytes {
+		var hex string
+		if err := json.Unmarshal(field, &hex); err != nil {
+			return errors.Wrap(err, "unmarshal bytes field", "field", name)
+		}
+
+		b, err := hexutil.Decode(hex)
+		if err != nil {
+			return errors.Wrap(err, "decode hex", "field", name)
+		}
+
+		if fields.all[name], err = json.Marshal(base64.StdEncoding.EncodeToString(b)); err != nil {
+			return errors.Wrap(err, "marshal base64")
+		}
+	}
+
+	pbJSON, err := json.Marshal(fields.all)
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if err := protojson.Unmarshal(pbJSON, msg); err != nil {
+		return errors.Wrap(err, "unmarshal protojson")
+	}
+
+	return nil
+}
+
+type jsonFields struct {
+	all   map[string]json.RawMessage // All JSON fields by name
+	bytes map[string]json.RawMessage // Bytes JSON fields by name
+}
+
+// bytesFields returns the JSON fields of the flat message's JSON object, identifying the bytes fields.
+func bytesFields(msg proto.Message, bz []byte) (jsonFields, error) {
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bz, &all); err != nil {
+		return jsonFields{}, errors.Wrap(err, "unmarshal json")
+	}
+
+	bytes := make(map[string]json.RawMessage)
+	descFields := msg.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < descFields.Len(); i++ {
+		fd := descFields.Get(i)
+		if fd.Kind() != protoreflect.BytesKind || fd.IsList() {
+			continue
+		}
+
+		// protojson accepts both JSON and proto field names.
+		for _, name := range []string{fd.JSONName(), string(fd.Name())} {
+			if field, ok := all[name]; ok {
+				bytes[name] = field
+			}
+		}
+	}
+
+	return jsonFields{all: all, bytes: bytes}, nil
+}
@@ -231,6 +231,19 @@ func (this MsgLinkIdHashIndexKey) WithIdHash(id_hash []byte) MsgLinkIdHashIndexK
 	return this
 }
 
+type MsgLinkMsgBlockIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x MsgLinkMsgBlockIdIndexKey) id() uint32            { return 1 }
+func (x MsgLinkMsgBlockIdIndexKey) values() []interface{} { return x.vs }
+func (x MsgLinkMsgBlockIdIndexKey) msgLinkIndexKey()      {}
+
+func (this MsgLinkMsgBlockIdIndexKey) WithMsgBlockId(msg_block_id uint64) MsgLinkMsgBlockIdIndexKey {
+	this.vs = []interface{}{msg_block_id}
+	return this
+}
+
 type msgLinkTable struct {
 	table ormtable.Table
 }
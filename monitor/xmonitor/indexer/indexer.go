@@ -1,8 +1,9 @@
 package indexer
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +20,7 @@ import (
 	ormv1alpha1 "cosmossdk.io/api/cosmos/orm/v1alpha1"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/orm/model/ormdb"
+	"cosmossdk.io/orm/model/ormlist"
 	"cosmossdk.io/orm/types/ormerrors"
 	db "github.com/cosmos/cosmos-db"
 )
@@ -27,6 +29,13 @@ import (
 // This avoids needing to catchup a lot on startup for quite streams.
 const emptyBlockCursorUpdate = 100
 
+// migrateBatchSize is the maximum number of blocks loaded and re-encoded per migration batch, see migrate.
+const migrateBatchSize = 1000
+
+// msgLinksIndexedKey is the raw DB key marking the msg_block_id index backfill as complete, see reindexMsgLinks.
+// It doesn't start with the ORM schema file ID (1), so it never conflicts with ORM keys.
+var msgLinksIndexedKey = []byte("msg_links_indexed")
+
 // unknown is the string used for unknown values.
 const unknown = "unknown"
 
@@ -38,22 +47,42 @@ func Start(
 	network netconf.Network,
 	xprov xchain.Provider,
 	db db.DB,
+	opts ...Option,
 ) error {
-	indexer, err := newIndexer(db, xprov, network.StreamName)
+	indexer, err := newIndexer(db, xprov, network.StreamName, opts...)
 	if err != nil {
 		return errors.Wrap(err, "create indexer")
 	}
 
+	if indexer.migrateOnStart {
+		migrated, err := indexer.migrate(ctx, migrateBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "migrate blocks")
+		}
+		log.Info(ctx, "Migrated indexed blocks encoding", "count", migrated, "version", indexer.version)
+	}
+
+	if reindexed, err := indexer.reindexMsgLinks(ctx, migrateBatchSize); err != nil {
+		return errors.Wrap(err, "reindex msg links")
+	} else if reindexed > 0 {
+		log.Info(ctx, "Reindexed msg links", "count", reindexed)
+	}
+
 	cursors, err := indexer.cursors(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, chain := range network.Chains {
+		height, err := indexer.resumeHeight(ctx, chain.ID, cursors)
+		if err != nil {
+			return err
+		}
+
 		req := xchain.ProviderRequest{
 			ChainID:   chain.ID,
 			ConfLevel: confLevel,
-			Height:    cursors[xchain.ChainVersion{ID: chain.ID, ConfLevel: confLevel}],
+			Height:    height,
 		}
 		if err := xprov.StreamAsync(ctx, req, indexer.index); err != nil {
 			return err
@@ -62,6 +91,10 @@ func Start(
 
 	go deleteForever(ctx, indexer)
 
+	if indexer.countInterval > 0 {
+		go instrumentCountsForever(ctx, indexer, network, indexer.countInterval)
+	}
+
 	return nil
 }
 
@@ -70,7 +103,19 @@ func newIndexer(
 	db db.DB,
 	xprov xchain.Provider,
 	streamNamer func(xchain.StreamID) string,
+	opts ...Option,
 ) (*indexer, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for field := range o.projection {
+		if err := field.Verify(); err != nil {
+			return nil, err
+		}
+	}
+
 	schema := &ormv1alpha1.ModuleSchemaDescriptor{SchemaFile: []*ormv1alpha1.ModuleSchemaDescriptor_FileEntry{
 		{Id: 1, ProtoFileName: File_monitor_xmonitor_indexer_indexer_proto.Path()},
 	}}
@@ -87,27 +132,56 @@ func newIndexer(
 		return nil, errors.Wrap(err, "create store")
 	}
 
-	return &indexer{
-		xprov:        xprov,
-		streamNamer:  streamNamer,
-		blockTable:   dbStore.BlockTable(),
-		msgLinkTable: dbStore.MsgLinkTable(),
-		cursorTable:  dbStore.CursorTable(),
-		sampleFunc:   instrumentSample,
-		xdapps:       nil, // TODO(corver): Populate this once we have well-known xdapps
-	}, nil
+	i := &indexer{
+		xprov:          xprov,
+		db:             db,
+		streamNamer:    streamNamer,
+		blockTable:     dbStore.BlockTable(),
+		msgLinkTable:   dbStore.MsgLinkTable(),
+		cursorTable:    newMonotonicCursorTable(dbStore.CursorTable()),
+		sink:           o.sink,
+		projection:     o.projection,
+		sampleFunc:     instrumentSample,
+		version:        o.version,
+		countInterval:  o.countInterval,
+		batchCommit:    o.batchCommit,
+		migrateOnStart: o.migrate,
+		xdapps:         nil, // TODO(corver): Populate this once we have well-known xdapps
+	}
+
+	if i.sink == nil {
+		i.sink = ormSink{
+			blockTable:   i.blockTable,
+			msgLinkTable: i.msgLinkTable,
+			cursorTable:  i.cursorTable,
+			db:           db,
+		}
+	}
+
+	if _, ok := i.sink.(BatchSink); i.batchCommit && !ok {
+		return nil, errors.New("block sink doesn't support batch commit")
+	}
+
+	return i, nil
 }
 
 // indexer indexes xchain blocks and messages.
 type indexer struct {
-	mu           sync.RWMutex
-	xprov        xchain.Provider
-	blockTable   BlockTable
-	msgLinkTable MsgLinkTable
-	cursorTable  CursorTable
-	streamNamer  func(xchain.StreamID) string
-	xdapps       map[common.Address]string
-	sampleFunc   func(sample)
+	mu             sync.RWMutex
+	xprov          xchain.Provider
+	db             db.DB // Backing DB of the ORM tables
+	blockTable     BlockTable
+	msgLinkTable   MsgLinkTable
+	cursorTable    CursorTable
+	sink           BlockSink  // Write path of indexed blocks, defaults to the ORM tables
+	projection     projection // Optional block fields to store, nil stores all
+	streamNamer    func(xchain.StreamID) string
+	xdapps         map[common.Address]string
+	sampleFunc     func(sample)
+	version        uint32        // Encoding version of newly indexed blocks
+	countInterval  time.Duration // Interval of the table row count gauges, zero disables
+	batchCommit    bool          // Commit each block, its msg links and cursor in a single batch
+	migrateOnStart bool          // Re-encode existing blocks on startup, see WithMigration
 }
 
 // cursors returns the indexed block height for each chain.
@@ -137,6 +211,25 @@ func (i *indexer) cursors(ctx context.Context) (map[xchain.ChainVersion]uint64,
 	return resp, nil
 }
 
+// resumeHeight returns the height to resume indexing the chain from.
+// This is the highest of the persisted cursor and the latest block in the sink,
+// since custom sinks may not share the ORM cursor table.
+func (i *indexer) resumeHeight(ctx context.Context, chainID uint64, cursors map[xchain.ChainVersion]uint64) (uint64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	height := cursors[xchain.ChainVersion{ID: chainID, ConfLevel: confLevel}]
+
+	latest, ok, err := i.sink.LatestBlock(ctx, chainID)
+	if err != nil {
+		return 0, errors.Wrap(err, "latest block", "chain", chainID)
+	} else if ok && latest.GetBlockHeight() > height {
+		height = latest.GetBlockHeight()
+	}
+
+	return height, nil
+}
+
 // delete deletes all blocks (and msg links) that have been fully indexed.
 func (i *indexer) delete(ctx context.Context) ([]xchain.BlockHeader, error) {
 	i.mu.Lock()
@@ -225,25 +318,229 @@ func (i *indexer) delete(ctx context.Context) ([]xchain.BlockHeader, error) {
 	return deleted, nil
 }
 
+// migrate re-encodes all blocks not encoded with the configured version.
+// Blocks are loaded and migrated in batches of at most batchSize, ordered by ID, each committed atomically.
+// This bounds memory usage, and an interrupted migration continues with the remaining blocks when next run.
+// It is only run on startup if enabled, see WithMigration, since it scans the whole block table.
+// It returns the number of blocks migrated.
+func (i *indexer) migrate(ctx context.Context, batchSize uint64) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var total int
+	var fromID uint64
+	for {
+		migrated, nextID, ok, err := i.migrateBatchUnsafe(ctx, fromID, batchSize)
+		if err != nil {
+			return 0, err
+		} else if !ok {
+			return total, nil
+		}
+
+		total += migrated
+		fromID = nextID
+	}
+}
+
+// migrateBatchUnsafe re-encodes the stale blocks of the next batch of at most batchSize blocks from the ID.
+// It returns the number of blocks migrated and the ID of the next batch, or false if no blocks remain.
+// It assumes the lock is held.
+func (i *indexer) migrateBatchUnsafe(ctx context.Context, fromID uint64, batchSize uint64) (int, uint64, bool, error) {
+	from := BlockPrimaryKey{}.WithId(fromID)
+	to := BlockPrimaryKey{}.WithId(math.MaxUint64)
+	iter, err := i.blockTable.ListRange(ctx, from, to, ormlist.DefaultLimit(batchSize))
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, "list blocks")
+	}
+
+	var (
+		stale  []*Block
+		lastID uint64
+		found  bool
+	)
+	for iter.Next() {
+		block, err := iter.Value()
+		if err != nil {
+			iter.Close()
+			return 0, 0, false, errors.Wrap(err, "get block value")
+		}
+
+		lastID, found = block.GetId(), true
+		if block.GetVersion() != i.version {
+			stale = append(stale, block)
+		}
+	}
+	iter.Close()
+
+	if !found {
+		return 0, 0, false, nil
+	}
+
+	// Update after closing the iterator, since some DBs don't support writes while iterating.
+	batch := newBatchStore(i.db)
+	ctx = withBatchStore(ctx, batch)
+	for _, blockDB := range stale {
+		block, err := blockDB.XChainBlock()
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		bz, err := encodeBlock(i.version, block)
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		blockDB.BlockJson = bz
+		blockDB.Version = i.version
+		if err := i.blockTable.Update(ctx, blockDB); err != nil {
+			return 0, 0, false, errors.Wrap(err, "update block")
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return 0, 0, false, err
+	}
+
+	return len(stale), lastID + 1, true, nil
+}
+
+// reindexMsgLinks backfills the msg_block_id index with msg links stored before the index was added.
+// Such links are only present in the primary key, so they are detected by comparing the number of links
+// in the primary key and the index. If these differ, all links are re-inserted in batches of at most batchSize,
+// each committed atomically, since updating a link with unchanged fields doesn't write its index entries.
+// Completion is recorded in the DB, so the backfill (and the counting) only runs once per DB.
+// It returns the number of links re-inserted.
+func (i *indexer) reindexMsgLinks(ctx context.Context, batchSize uint64) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if done, err := i.db.Has(msgLinksIndexedKey); err != nil {
+		return 0, errors.Wrap(err, "get msg links indexed")
+	} else if done {
+		return 0, nil
+	}
+
+	reinserted, err := i.reindexAllUnsafe(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := i.db.SetSync(msgLinksIndexedKey, []byte{1}); err != nil {
+		return 0, errors.Wrap(err, "set msg links indexed")
+	}
+
+	return reinserted, nil
+}
+
+// reindexAllUnsafe re-inserts all msg links if the msg_block_id index is incomplete, see reindexMsgLinks.
+// It assumes the lock is held.
+func (i *indexer) reindexAllUnsafe(ctx context.Context, batchSize uint64) (int, error) {
+	total, err := i.countMsgLinksUnsafe(ctx, MsgLinkPrimaryKey{})
+	if err != nil {
+		return 0, err
+	}
+
+	indexed, err := i.countMsgLinksUnsafe(ctx, MsgLinkMsgBlockIdIndexKey{})
+	if err != nil {
+		return 0, err
+	} else if total == indexed {
+		return 0, nil // Index complete
+	}
+
+	var reinserted int
+	var fromHash []byte
+	for {
+		count, nextHash, ok, err := i.reindexBatchUnsafe(ctx, fromHash, batchSize)
+		if err != nil {
+			return 0, err
+		} else if !ok {
+			return reinserted, nil
+		}
+
+		reinserted += count
+		fromHash = nextHash
+	}
+}
+
+// reindexBatchUnsafe re-inserts the next batch of at most batchSize msg links from the id hash (inclusive, nil for the first).
+// It returns the number of links re-inserted and the id hash of the next batch, or false if no links remain.
+// It assumes the lock is held.
+func (i *indexer) reindexBatchUnsafe(ctx context.Context, fromHash []byte, batchSize uint64) (int, []byte, bool, error) {
+	from := MsgLinkPrimaryKey{}.WithIdHash(fromHash)
+	to := MsgLinkPrimaryKey{}.WithIdHash(bytes.Repeat([]byte{0xff}, common.HashLength))
+	iter, err := i.msgLinkTable.ListRange(ctx, from, to, ormlist.DefaultLimit(batchSize))
+	if err != nil {
+		return 0, nil, false, errors.Wrap(err, "list msg links")
+	}
+
+	var links []*MsgLink
+	for iter.Next() {
+		link, err := iter.Value()
+		if err != nil {
+			iter.Close()
+			return 0, nil, false, errors.Wrap(err, "get msg link value")
+		}
+
+		links = append(links, link)
+	}
+	iter.Close()
+
+	if len(links) == 0 {
+		return 0, nil, false, nil
+	}
+
+	// Re-insert after closing the iterator, since some DBs don't support writes while iterating.
+	batch := newBatchStore(i.db)
+	ctx = withBatchStore(ctx, batch)
+	for _, link := range links {
+		if err := i.msgLinkTable.Delete(ctx, link); err != nil {
+			return 0, nil, false, errors.Wrap(err, "delete msg link")
+		} else if err := i.msgLinkTable.Insert(ctx, link); err != nil {
+			return 0, nil, false, errors.Wrap(err, "insert msg link")
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return 0, nil, false, err
+	}
+
+	// The next batch starts at the lowest id hash greater than the last.
+	nextHash := append(bytes.Clone(links[len(links)-1].GetIdHash()), 0)
+
+	return len(links), nextHash, true, nil
+}
+
 // updateCursor updates the cursor to the provided chain to the provided height.
 func (i *indexer) updateCursor(ctx context.Context, block xchain.Block) error {
-	err := i.cursorTable.Save(ctx, &Cursor{
+	return i.sink.SaveCursor(ctx, &Cursor{
 		ChainId:     block.ChainID,
 		ConfLevel:   uint32(confLevel),
 		BlockHeight: block.BlockHeight,
 	})
-	if err != nil {
-		return errors.Wrap(err, "save cursor")
-	}
-
-	return nil
 }
 
 // index indexes the given block.
+// If batch commit is enabled, the block, its msg links and cursor are committed atomically.
 func (i *indexer) index(ctx context.Context, block xchain.Block) error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
+	if !i.batchCommit {
+		return i.indexUnsafe(ctx, block)
+	}
+
+	sink, ok := i.sink.(BatchSink)
+	if !ok {
+		return errors.New("block sink doesn't support batch commit [BUG]")
+	}
+
+	return sink.Batch(ctx, func(ctx context.Context) error {
+		return i.indexUnsafe(ctx, block)
+	})
+}
+
+// indexUnsafe indexes the given block. It assumes the lock is held.
+func (i *indexer) indexUnsafe(ctx context.Context, block xchain.Block) error {
 	// Skip empty blocks
 	if len(block.Msgs) == 0 && len(block.Receipts) == 0 {
 		// Update cursor for every Nth empty block
@@ -256,46 +553,31 @@ func (i *indexer) index(ctx context.Context, block xchain.Block) error {
 		return nil
 	}
 
-	// Marshal block (we don't store all block fields explicitly)
-	bz, err := json.Marshal(block) //nolint:musttag // TODO: Rather use protobuf
+	// Encode block (we don't store all block fields explicitly)
+	bz, err := encodeBlock(i.version, i.projection.Project(block))
 	if err != nil {
-		return errors.Wrap(err, "marshal block")
+		return err
 	}
 
 	// Insert block
-	id, err := i.blockTable.InsertReturningId(ctx, &Block{
+	id, err := i.sink.PutBlock(ctx, &Block{
 		ChainId:     block.ChainID,
 		BlockHeight: block.BlockHeight,
 		BlockHash:   block.BlockHash.Bytes(),
 		BlockJson:   bz,
+		Version:     i.version,
 	})
-	if errors.Is(err, ormerrors.UniqueKeyViolation) { // Idempotent
-		existing, err := i.blockTable.GetByChainIdBlockHeightBlockHash(ctx, block.ChainID, block.BlockHeight, block.BlockHash.Bytes())
-		if err != nil {
-			return errors.Wrap(err, "get existing block")
-		}
-		id = existing.GetId()
-	} else if err != nil {
-		return errors.Wrap(err, "insert block")
+	if err != nil {
+		return err
 	}
 
 	// Upsert msg links
 	for _, msg := range block.Msgs {
-		link, _, err := i.getLink(ctx, msg.MsgID)
+		hash := msg.MsgID.Hash()
+		link, err := i.sink.PutMsgLink(ctx, &MsgLink{IdHash: hash.Bytes(), MsgBlockId: id})
 		if err != nil {
 			return err
 		}
-		if link.GetMsgBlockId() != 0 && link.GetMsgBlockId() != id {
-			return errors.New("mismatching msg block id [BUG]",
-				"msg_id", msg.MsgID,
-				"got", link.GetMsgBlockId(),
-				"want", id,
-			)
-		}
-		link.MsgBlockId = id
-		if err := i.msgLinkTable.Save(ctx, link); err != nil {
-			return errors.Wrap(err, "save msg link")
-		}
 
 		// Maybe instrument if both msg and receipt are indexed
 		if link.GetMsgBlockId() != 0 && link.GetReceiptBlockId() != 0 {
@@ -307,21 +589,11 @@ func (i *indexer) index(ctx context.Context, block xchain.Block) error {
 
 	// Upsert receipt links
 	for _, receipt := range block.Receipts {
-		link, _, err := i.getLink(ctx, receipt.MsgID)
+		hash := receipt.MsgID.Hash()
+		link, err := i.sink.PutMsgLink(ctx, &MsgLink{IdHash: hash.Bytes(), ReceiptBlockId: id})
 		if err != nil {
 			return err
 		}
-		if link.GetReceiptBlockId() != 0 && link.GetReceiptBlockId() != id {
-			return errors.New("mismatching receipt block id [BUG]",
-				"msg_id", receipt.MsgID,
-				"got", link.GetReceiptBlockId(),
-				"want", id,
-			)
-		}
-		link.ReceiptBlockId = id
-		if err := i.msgLinkTable.Save(ctx, link); err != nil {
-			return errors.Wrap(err, "save msg link")
-		}
 
 		// Maybe instrument
 		if link.GetMsgBlockId() != 0 && link.GetReceiptBlockId() != 0 {
@@ -334,6 +606,38 @@ func (i *indexer) index(ctx context.Context, block xchain.Block) error {
 	return i.updateCursor(ctx, block) // Update cursor since we are done with this block
 }
 
+// LatestMsgLinks returns up to limit msg links ordered by most recent msg block first.
+// Links without an indexed msg (only a receipt) are excluded.
+// It iterates the msg_block_id index in reverse, so it doesn't perform a full table scan.
+func (i *indexer) LatestMsgLinks(ctx context.Context, limit int) ([]*MsgLink, error) {
+	if limit <= 0 {
+		return nil, errors.New("invalid limit", "limit", limit)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	iter, err := i.msgLinkTable.List(ctx, MsgLinkMsgBlockIdIndexKey{}, ormlist.Reverse())
+	if err != nil {
+		return nil, errors.Wrap(err, "list msg links")
+	}
+	defer iter.Close()
+
+	var resp []*MsgLink
+	for len(resp) < limit && iter.Next() {
+		link, err := iter.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "get msg link value")
+		} else if link.GetMsgBlockId() == 0 {
+			break // Reverse order, so only receipt-only links remain.
+		}
+
+		resp = append(resp, link)
+	}
+
+	return resp, nil
+}
+
 // getLink returns the msg link for the given id or a new one.
 func (i *indexer) getLink(ctx context.Context, id xchain.MsgID) (*MsgLink, bool, error) {
 	hash := id.Hash()
@@ -496,10 +800,15 @@ func deleteForever(ctx context.Context, i *indexer) {
 }
 
 // dbStoreService wraps a cosmos-db instance and provides it via OpenKVStore.
+// It provides the batch store instead if the context contains one, see ormSink.Batch.
 type dbStoreService struct {
 	db.DB
 }
 
-func (db dbStoreService) OpenKVStore(context.Context) store.KVStore {
+func (db dbStoreService) OpenKVStore(ctx context.Context) store.KVStore {
+	if batch, ok := batchFromCtx(ctx); ok {
+		return batch
+	}
+
 	return db.DB
 }
@@ -1,19 +1,26 @@
 package indexer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tutil"
 	"github.com/omni-network/omni/lib/umath"
 	"github.com/omni-network/omni/lib/xchain"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"cosmossdk.io/orm/types/ormerrors"
 	dbm "github.com/cosmos/cosmos-db"
 	fuzz "github.com/google/gofuzz"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 //go:generate go test . -count=1000 -race
@@ -21,13 +28,32 @@ import (
 func TestIndexer(t *testing.T) {
 	t.Parallel()
 
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		testIndexer(t)
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		t.Parallel()
+		testIndexer(t, WithCompactEncoding())
+	})
+
+	t.Run("projected", func(t *testing.T) {
+		t.Parallel()
+		testIndexer(t, WithBlockProjection()) // Required fields suffice for indexing.
+	})
+}
+
+func testIndexer(t *testing.T, opts ...Option) {
+	t.Helper()
+
 	f := fuzz.New().NilChance(0).NumElements(0, 10)
 	ctx := context.Background()
 	db := dbm.NewMemDB()
 
 	streamNamer := func(s xchain.StreamID) string { return fmt.Sprint(s) }
 
-	indexer, err := newIndexer(db, mockXProvider{}, streamNamer)
+	indexer, err := newIndexer(db, mockXProvider{}, streamNamer, opts...)
 	require.NoError(t, err)
 	var samples []sample
 	indexer.sampleFunc = func(s sample) {
@@ -131,6 +157,252 @@ func TestIndexer(t *testing.T) {
 	}
 }
 
+func TestEncodeBlock(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(1, 10) // Protobuf decodes empty slices as nil.
+
+	for range 100 {
+		var block xchain.Block
+		f.Fuzz(&block)
+		block.Timestamp = block.Timestamp.UTC() // Protobuf timestamps are decoded as UTC.
+
+		for _, version := range []uint32{versionJSON, versionProto} {
+			bz, err := encodeBlock(version, block)
+			require.NoError(t, err)
+
+			decoded, err := decodeBlock(version, bz)
+			require.NoError(t, err)
+			require.Equal(t, block, decoded)
+		}
+	}
+
+	_, err := encodeBlock(99, xchain.Block{})
+	require.Error(t, err)
+}
+
+func TestEncodeBlockOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(1, 10)
+
+	proof := &xchain.ReceiptProof{
+		Header:  []byte("header"),
+		TxIndex: 7,
+		Receipt: []byte("receipt"),
+		Nodes:   [][]byte{[]byte("node0"), []byte("node1")},
+	}
+
+	tests := []struct {
+		name      string
+		fees      *big.Int
+		proof     *xchain.ReceiptProof
+		unmatched bool
+	}{
+		{name: "nil fees and proof"},
+		{name: "zero fees", fees: big.NewInt(0)},
+		{name: "fees and proof", fees: big.NewInt(1e18), proof: proof},
+		{name: "unmatched receipt", fees: big.NewInt(1), unmatched: true},
+	}
+
+	for _, tt := range tests {
+		var msg xchain.Msg
+		f.Fuzz(&msg)
+		msg.Fees = tt.fees
+		msg.Proof = tt.proof
+
+		var receipt xchain.Receipt
+		f.Fuzz(&receipt)
+		receipt.Unmatched = tt.unmatched
+
+		block := fuzzBlock(f, []xchain.Msg{msg}, []xchain.Receipt{receipt})
+		block.Timestamp = block.Timestamp.UTC() // Protobuf timestamps are decoded as UTC.
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bz, err := encodeBlock(versionProto, block)
+			require.NoError(t, err)
+
+			decoded, err := decodeBlock(versionProto, bz)
+			require.NoError(t, err)
+			require.Equal(t, block, decoded)
+			require.Equal(t, tt.fees == nil, decoded.Msgs[0].Fees == nil)
+			require.Equal(t, tt.proof, decoded.Msgs[0].Proof)
+			require.Equal(t, tt.unmatched, decoded.Receipts[0].Unmatched)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(1, 10)
+	ctx := context.Background()
+	db := dbm.NewMemDB()
+
+	streamNamer := func(s xchain.StreamID) string { return fmt.Sprint(s) }
+
+	// Index some blocks using the legacy JSON encoding.
+	jsonIndexer, err := newIndexer(db, mockXProvider{}, streamNamer)
+	require.NoError(t, err)
+
+	const total = 5
+	var blocks []xchain.Block
+	for range total {
+		var msg xchain.Msg
+		f.Fuzz(&msg)
+		block := fuzzBlock(f, []xchain.Msg{msg}, nil)
+		block.Timestamp = block.Timestamp.UTC()
+		blocks = append(blocks, block)
+		require.NoError(t, jsonIndexer.index(ctx, block))
+	}
+
+	// Migrate them to the compact encoding.
+	compactIndexer, err := newIndexer(db, mockXProvider{}, streamNamer, WithCompactEncoding())
+	require.NoError(t, err)
+
+	// Use a batch size smaller than the total to migrate in multiple batches.
+	const batchSize = 2
+	migrated, err := compactIndexer.migrate(ctx, batchSize)
+	require.NoError(t, err)
+	require.Equal(t, total, migrated)
+
+	// Migrating again is a noop.
+	migrated, err = compactIndexer.migrate(ctx, batchSize)
+	require.NoError(t, err)
+	require.Zero(t, migrated)
+
+	for _, block := range blocks {
+		blockDB, err := compactIndexer.blockTable.GetByChainIdBlockHeightBlockHash(ctx, block.ChainID, block.BlockHeight, block.BlockHash.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, versionProto, blockDB.GetVersion())
+
+		decoded, err := blockDB.XChainBlock()
+		require.NoError(t, err)
+		require.Equal(t, block, decoded)
+	}
+}
+
+func TestLatestMsgLinks(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(0, 10)
+	ctx := context.Background()
+
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, func(s xchain.StreamID) string { return fmt.Sprint(s) })
+	require.NoError(t, err)
+
+	_, err = indexer.LatestMsgLinks(ctx, 0)
+	require.Error(t, err)
+
+	links, err := indexer.LatestMsgLinks(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, links)
+
+	// Index a receipt-only block.
+	var receipt xchain.Receipt
+	f.Fuzz(&receipt)
+	require.NoError(t, indexer.index(ctx, fuzzBlock(f, nil, []xchain.Receipt{receipt})))
+
+	// Index a block per msg.
+	var msgs []xchain.Msg
+	for range 3 {
+		var msg xchain.Msg
+		f.Fuzz(&msg)
+		msgs = append(msgs, msg)
+		require.NoError(t, indexer.index(ctx, fuzzBlock(f, []xchain.Msg{msg}, nil)))
+	}
+
+	links, err = indexer.LatestMsgLinks(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.Equal(t, msgs[2].Hash(), links[0].Hash())
+	require.Equal(t, msgs[1].Hash(), links[1].Hash())
+
+	links, err = indexer.LatestMsgLinks(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, links, len(msgs)) // Receipt-only link excluded.
+}
+
+func TestReindexMsgLinks(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(0, 10)
+	ctx := context.Background()
+	db := dbm.NewMemDB()
+
+	indexer, err := newIndexer(db, mockXProvider{}, func(s xchain.StreamID) string { return fmt.Sprint(s) })
+	require.NoError(t, err)
+
+	const total = 5
+	for range total {
+		var msg xchain.Msg
+		f.Fuzz(&msg)
+		require.NoError(t, indexer.index(ctx, fuzzBlock(f, []xchain.Msg{msg}, nil)))
+	}
+
+	// Nothing to reindex if the index is complete.
+	reindexed, err := indexer.reindexMsgLinks(ctx, 2)
+	require.NoError(t, err)
+	require.Zero(t, reindexed)
+
+	// Delete the msg_block_id index entries and the completion marker to mimic links stored before the index was added.
+	// ORM keys are prefixed by the schema file ID (1), the MsgLink table ID (2) and the index ID (1).
+	deleteKeys(t, db, []byte{1, 2, 1})
+	deleteKeys(t, db, msgLinksIndexedKey)
+	count, err := indexer.CountMsgLinks(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	links, err := indexer.LatestMsgLinks(ctx, total)
+	require.NoError(t, err)
+	require.Empty(t, links)
+
+	// Reindex in multiple batches.
+	reindexed, err = indexer.reindexMsgLinks(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, total, reindexed)
+
+	count, err = indexer.CountMsgLinks(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, total, count)
+	links, err = indexer.LatestMsgLinks(ctx, total)
+	require.NoError(t, err)
+	require.Len(t, links, total)
+
+	// Reindexing again is a noop.
+	reindexed, err = indexer.reindexMsgLinks(ctx, 2)
+	require.NoError(t, err)
+	require.Zero(t, reindexed)
+
+	// Once complete, the index isn't checked again.
+	deleteKeys(t, db, []byte{1, 2, 1})
+	reindexed, err = indexer.reindexMsgLinks(ctx, 2)
+	require.NoError(t, err)
+	require.Zero(t, reindexed)
+}
+
+// deleteKeys deletes all keys with the prefix from the DB.
+func deleteKeys(t *testing.T, db dbm.DB, prefix []byte) {
+	t.Helper()
+
+	iter, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		if bytes.HasPrefix(iter.Key(), prefix) {
+			keys = append(keys, bytes.Clone(iter.Key()))
+		}
+	}
+	require.NoError(t, iter.Close())
+	require.NotEmpty(t, keys)
+
+	for _, key := range keys {
+		require.NoError(t, db.Delete(key))
+	}
+}
+
 func makeSample(blocks []xchain.Block, receipts []xchain.Receipt, msgs []xchain.Msg, idx int) sample {
 	return sample{
 		Stream:        fmt.Sprint(receipts[idx].StreamID),
@@ -207,3 +479,423 @@ func mockConfLevel(txHash common.Hash) xchain.ConfLevel {
 
 	return resp
 }
+
+func TestHexJSON(t *testing.T) {
+	t.Parallel()
+
+	hash := tutil.RandomHash()
+	block := &Block{
+		Id:          1,
+		ChainId:     2,
+		BlockHeight: 3,
+		BlockHash:   hash.Bytes(),
+		BlockJson:   []byte("{}"),
+		Version:     versionProto,
+	}
+
+	bz, err := MarshalBlockJSON(block)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"blockHash":"`+hash.Hex()+`"`)
+	require.Contains(t, string(bz), `"blockJson":"0x7b7d"`)
+
+	decoded, err := UnmarshalBlockJSON(bz)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(block, decoded))
+
+	link := &MsgLink{
+		IdHash:         hash.Bytes(),
+		MsgBlockId:     1,
+		ReceiptBlockId: 2,
+	}
+
+	bz, err = MarshalMsgLinkJSON(link)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"idHash":"`+hash.Hex()+`"`)
+
+	decodedLink, err := UnmarshalMsgLinkJSON(bz)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(link, decodedLink))
+
+	// Empty bytes fields are omitted.
+	bz, err = MarshalMsgLinkJSON(&MsgLink{MsgBlockId: 1})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"msgBlockId":"1"}`, string(bz))
+
+	_, err = UnmarshalMsgLinkJSON([]byte(`{"idHash":"not hex"}`))
+	require.ErrorContains(t, err, "decode hex")
+}
+
+func TestCursorRegression(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, func(s xchain.StreamID) string { return fmt.Sprint(s) })
+	require.NoError(t, err)
+
+	const chainID = 1234 // Unique chain ID to avoid metric conflicts
+	regressions := func() float64 {
+		var m dto.Metric
+		require.NoError(t, cursorRegressions.WithLabelValues("1234", xchain.ConfFinalized.Label()).Write(&m))
+
+		return m.GetCounter().GetValue()
+	}
+
+	save := func(height uint64) {
+		t.Helper()
+		require.NoError(t, indexer.updateCursor(ctx, xchain.Block{BlockHeader: xchain.BlockHeader{ChainID: chainID, BlockHeight: height}}))
+	}
+
+	save(10)
+	save(10)
+	save(20)
+	require.Zero(t, regressions())
+
+	// Regression via indexer
+	save(15)
+	require.InDelta(t, 1, regressions(), 0)
+
+	// Regression via direct table write
+	err = indexer.cursorTable.Update(ctx, &Cursor{ChainId: chainID, ConfLevel: uint32(xchain.ConfFinalized), BlockHeight: 5})
+	require.NoError(t, err)
+	require.InDelta(t, 2, regressions(), 0)
+
+	// Regressions are still written.
+	cursors, err := indexer.cursors(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), cursors[xchain.ChainVersion{ID: chainID, ConfLevel: xchain.ConfFinalized}])
+}
+
+func TestBlockSink(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(0, 10)
+	ctx := context.Background()
+	streamNamer := func(s xchain.StreamID) string { return fmt.Sprint(s) }
+
+	// Use the ORM tables of another DB as custom sink.
+	custom, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, streamNamer)
+	require.NoError(t, err)
+
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, streamNamer, WithBlockSink(custom.sink))
+	require.NoError(t, err)
+	var samples []sample
+	indexer.sampleFunc = func(s sample) {
+		samples = append(samples, s)
+	}
+
+	var msg xchain.Msg
+	f.Fuzz(&msg)
+	receipt := xchain.Receipt{MsgID: msg.MsgID}
+	msgBlock := fuzzBlock(f, []xchain.Msg{msg}, nil)
+	receiptBlock := fuzzBlock(f, nil, []xchain.Receipt{receipt})
+	require.NoError(t, indexer.index(ctx, msgBlock))
+	require.NoError(t, indexer.index(ctx, receiptBlock))
+	require.NoError(t, indexer.index(ctx, msgBlock)) // Idempotent
+
+	// Nothing written to the ORM tables.
+	blocks, err := indexer.blockTable.List(ctx, BlockPrimaryKey{})
+	require.NoError(t, err)
+	require.False(t, blocks.Next())
+	blocks.Close()
+	cursors, err := indexer.cursors(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cursors)
+
+	// Blocks, merged msg link and cursors written to the sink.
+	msgDB, err := custom.blockTable.GetByChainIdBlockHeightBlockHash(ctx, msgBlock.ChainID, msgBlock.BlockHeight, msgBlock.BlockHash.Bytes())
+	require.NoError(t, err)
+	receiptDB, err := custom.blockTable.GetByChainIdBlockHeightBlockHash(ctx, receiptBlock.ChainID, receiptBlock.BlockHeight, receiptBlock.BlockHash.Bytes())
+	require.NoError(t, err)
+
+	link, ok, err := custom.getLink(ctx, msg.MsgID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, msgDB.GetId(), link.GetMsgBlockId())
+	require.Equal(t, receiptDB.GetId(), link.GetReceiptBlockId())
+
+	cursors, err = custom.cursors(ctx)
+	require.NoError(t, err)
+	require.Equal(t, msgBlock.BlockHeight, cursors[xchain.ChainVersion{ID: msgBlock.ChainID, ConfLevel: confLevel}])
+
+	// Msg latency is only instrumented for the ORM tables.
+	require.Empty(t, samples)
+
+	// Resume from the latest sink block if ahead of the cursor.
+	latest, ok, err := custom.sink.LatestBlock(ctx, receiptBlock.ChainID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, receiptDB.GetId(), latest.GetId())
+
+	height, err := indexer.resumeHeight(ctx, receiptBlock.ChainID, nil)
+	require.NoError(t, err)
+	require.Equal(t, receiptBlock.BlockHeight, height)
+
+	height, err = indexer.resumeHeight(ctx, receiptBlock.ChainID, map[xchain.ChainVersion]uint64{
+		{ID: receiptBlock.ChainID, ConfLevel: confLevel}: receiptBlock.BlockHeight + 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, receiptBlock.BlockHeight+1, height)
+
+	_, ok, err = custom.sink.LatestBlock(ctx, 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCursorCompare(t *testing.T) {
+	t.Parallel()
+
+	cursor := func(chainID uint64, confLevel xchain.ConfLevel, height uint64) *Cursor {
+		return &Cursor{ChainId: chainID, ConfLevel: uint32(confLevel), BlockHeight: height}
+	}
+
+	tests := []struct {
+		name    string
+		a, b    *Cursor
+		cmp     int
+		ahead   bool
+		wantErr bool
+	}{
+		{name: "equal", a: cursor(1, xchain.ConfFinalized, 10), b: cursor(1, xchain.ConfFinalized, 10), cmp: 0},
+		{name: "behind", a: cursor(1, xchain.ConfFinalized, 9), b: cursor(1, xchain.ConfFinalized, 10), cmp: -1},
+		{name: "ahead", a: cursor(1, xchain.ConfFinalized, 11), b: cursor(1, xchain.ConfFinalized, 10), cmp: 1, ahead: true},
+		{name: "chain mismatch", a: cursor(1, xchain.ConfFinalized, 11), b: cursor(2, xchain.ConfFinalized, 10), wantErr: true},
+		{name: "conf level mismatch", a: cursor(1, xchain.ConfLatest, 11), b: cursor(1, xchain.ConfFinalized, 10), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmp, err := test.a.Compare(test.b)
+			ahead, aheadErr := test.a.IsAhead(test.b)
+			if test.wantErr {
+				require.ErrorIs(t, err, ErrIncomparableCursors)
+				require.ErrorIs(t, aheadErr, ErrIncomparableCursors)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, aheadErr)
+			require.Equal(t, test.cmp, cmp)
+			require.Equal(t, test.ahead, ahead)
+		})
+	}
+}
+
+func TestBlockProjection(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(1, 10)
+	ctx := context.Background()
+
+	_, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, nil, WithBlockProjection("unknown"))
+	require.ErrorContains(t, err, "unknown block field")
+
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, func(s xchain.StreamID) string { return fmt.Sprint(s) },
+		WithBlockProjection(FieldMsgData, FieldReceiptError))
+	require.NoError(t, err)
+
+	var msg xchain.Msg
+	f.Fuzz(&msg)
+	var receipt xchain.Receipt
+	f.Fuzz(&receipt)
+	block := fuzzBlock(f, []xchain.Msg{msg}, []xchain.Receipt{receipt})
+	require.NoError(t, indexer.index(ctx, block))
+
+	blockDB, err := indexer.blockTable.GetByChainIdBlockHeightBlockHash(ctx, block.ChainID, block.BlockHeight, block.BlockHash.Bytes())
+	require.NoError(t, err)
+	stored, err := blockDB.XChainBlock()
+	require.NoError(t, err)
+
+	// Omitted fields
+	require.Equal(t, common.Hash{}, stored.ParentHash)
+	require.Equal(t, common.Address{}, stored.Msgs[0].DestAddress)
+	require.Equal(t, common.Address{}, stored.Receipts[0].RelayerAddress)
+
+	// Projected fields
+	require.Equal(t, msg.Data, stored.Msgs[0].Data)
+	require.Equal(t, receipt.Error, stored.Receipts[0].Error)
+
+	// Required fields
+	require.Equal(t, block.BlockHeader, stored.BlockHeader)
+	require.Equal(t, msg.MsgID, stored.Msgs[0].MsgID)
+	require.Equal(t, msg.TxHash, stored.Msgs[0].TxHash)
+	require.Equal(t, receipt.MsgID, stored.Receipts[0].MsgID)
+	require.Equal(t, receipt.Success, stored.Receipts[0].Success)
+	require.True(t, block.Timestamp.Equal(stored.Timestamp))
+
+	// The original block isn't modified.
+	require.NotEqual(t, common.Hash{}, block.ParentHash)
+}
+
+func TestCounts(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(0, 10)
+	ctx := context.Background()
+	streamNamer := func(s xchain.StreamID) string { return fmt.Sprint(s) }
+
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, streamNamer)
+	require.NoError(t, err)
+	indexer.sampleFunc = func(sample) {}
+
+	const (
+		chain1 = 4321 // Unique chain IDs to avoid metric conflicts
+		chain2 = 4322
+	)
+
+	var msgs []xchain.Msg
+	for i := 0; i < 3; i++ {
+		var msg xchain.Msg
+		f.Fuzz(&msg)
+		msgs = append(msgs, msg)
+
+		block := fuzzBlock(f, []xchain.Msg{msg}, nil)
+		block.ChainID = chain1
+		require.NoError(t, indexer.index(ctx, block))
+	}
+
+	// A receipt of an existing msg doesn't add a msg link.
+	receiptBlock := fuzzBlock(f, nil, []xchain.Receipt{{MsgID: msgs[0].MsgID}})
+	receiptBlock.ChainID = chain2
+	require.NoError(t, indexer.index(ctx, receiptBlock))
+
+	count, err := indexer.CountBlocks(ctx, chain1)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, count)
+
+	count, err = indexer.CountBlocks(ctx, chain2)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	count, err = indexer.CountMsgLinks(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, count)
+
+	count, err = indexer.CountCursors(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	network := netconf.Network{Chains: []netconf.Chain{{ID: chain1, Name: "count1"}, {ID: chain2, Name: "count2"}}}
+	require.NoError(t, indexer.instrumentCounts(ctx, network))
+
+	var m dto.Metric
+	require.NoError(t, blocksGauge.WithLabelValues("count1").Write(&m))
+	require.InDelta(t, 3, m.GetGauge().GetValue(), 0)
+}
+
+// failingSink wraps a batch sink, failing PutMsgLink once the configured number of links were put.
+type failingSink struct {
+	BatchSink
+	failAfter int // Negative disables failures
+	puts      int
+}
+
+func (s *failingSink) PutMsgLink(ctx context.Context, link *MsgLink) (*MsgLink, error) {
+	if s.failAfter >= 0 && s.puts >= s.failAfter {
+		return nil, errors.New("simulated failure")
+	}
+	s.puts++
+
+	return s.BatchSink.PutMsgLink(ctx, link)
+}
+
+func TestBatchCommit(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(0, 10)
+	ctx := context.Background()
+	streamNamer := func(s xchain.StreamID) string { return fmt.Sprint(s) }
+
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, streamNamer, WithBatchCommit())
+	require.NoError(t, err)
+
+	sink := &failingSink{BatchSink: indexer.sink.(BatchSink), failAfter: 2}
+	indexer.sink = sink
+
+	msgs := make([]xchain.Msg, 3)
+	for i := range msgs {
+		f.Fuzz(&msgs[i])
+	}
+	block := fuzzBlock(f, msgs, nil)
+
+	// Mid-write failure after the block and some msg links were put.
+	require.ErrorContains(t, indexer.index(ctx, block), "simulated failure")
+	require.Equal(t, 2, sink.puts)
+
+	// Nothing committed.
+	_, err = indexer.blockTable.GetByChainIdBlockHeightBlockHash(ctx, block.ChainID, block.BlockHeight, block.BlockHash.Bytes())
+	require.True(t, ormerrors.IsNotFound(err))
+	for _, msg := range msgs {
+		_, ok, err := indexer.getLink(ctx, msg.MsgID)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	cursors, err := indexer.cursors(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cursors)
+
+	// Retry succeeds, committing the block, all its msg links and the cursor.
+	sink.failAfter = -1
+	require.NoError(t, indexer.index(ctx, block))
+
+	blockDB, err := indexer.blockTable.GetByChainIdBlockHeightBlockHash(ctx, block.ChainID, block.BlockHeight, block.BlockHash.Bytes())
+	require.NoError(t, err)
+	for _, msg := range msgs {
+		link, ok, err := indexer.getLink(ctx, msg.MsgID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, blockDB.GetId(), link.GetMsgBlockId())
+	}
+	cursors, err = indexer.cursors(ctx)
+	require.NoError(t, err)
+	require.Equal(t, block.BlockHeight, cursors[xchain.ChainVersion{ID: block.ChainID, ConfLevel: confLevel}])
+
+	// Custom sinks must support batches.
+	custom := struct{ BlockSink }{indexer.sink}
+	_, err = newIndexer(dbm.NewMemDB(), mockXProvider{}, streamNamer, WithBlockSink(custom), WithBatchCommit())
+	require.ErrorContains(t, err, "doesn't support batch commit")
+}
+
+func TestMessageStatus(t *testing.T) {
+	t.Parallel()
+
+	f := fuzz.New().NilChance(0).NumElements(0, 10)
+	ctx := context.Background()
+
+	indexer, err := newIndexer(dbm.NewMemDB(), mockXProvider{}, func(s xchain.StreamID) string { return fmt.Sprint(s) })
+	require.NoError(t, err)
+	indexer.sampleFunc = func(sample) {}
+
+	var msg xchain.Msg
+	f.Fuzz(&msg)
+	msgBlock := fuzzBlock(f, []xchain.Msg{msg}, nil)
+	receiptBlock := fuzzBlock(f, nil, []xchain.Receipt{{MsgID: msg.MsgID}})
+
+	requireStatus := func(expected MsgStatus) {
+		t.Helper()
+		status, err := indexer.MessageStatus(ctx, msg.MsgID.Hash())
+		require.NoError(t, err)
+		require.Equal(t, expected, status)
+	}
+
+	requireStatus(MsgStatus{Status: StatusUnknown})
+
+	require.NoError(t, indexer.index(ctx, msgBlock))
+	requireStatus(MsgStatus{
+		Status:     StatusSubmitted,
+		MsgChainID: msgBlock.ChainID,
+		MsgHeight:  msgBlock.BlockHeight,
+	})
+
+	require.NoError(t, indexer.index(ctx, receiptBlock))
+	requireStatus(MsgStatus{
+		Status:         StatusDelivered,
+		MsgChainID:     msgBlock.ChainID,
+		MsgHeight:      msgBlock.BlockHeight,
+		ReceiptChainID: receiptBlock.ChainID,
+		ReceiptHeight:  receiptBlock.BlockHeight,
+	})
+	require.Equal(t, "delivered", StatusDelivered.String())
+}
@@ -50,12 +50,40 @@ var (
 		Buckets:   prometheus.ExponentialBucketsRange(1, 1e6, 10),
 	}, []string{"stream", "xdapp"})
 
+	cursorRegressions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "monitor",
+		Subsystem: "indexer",
+		Name:      "cursor_regression_total",
+		Help:      "Total number of indexer cursor writes lower than the existing cursor per chain per conf level. Alert if non-zero",
+	}, []string{"chain_id", "conf_level"})
+
 	feesGweiTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "monitor",
 		Subsystem: "indexer",
 		Name:      "fees_gwei_total",
 		Help:      "Total fees collected from xcalls by a portal contract",
 	}, []string{"chain", "token"})
+
+	blocksGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "indexer",
+		Name:      "blocks_total",
+		Help:      "Current number of indexed blocks per chain. Alert if growing unbounded.",
+	}, []string{"chain"})
+
+	msgLinksGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "indexer",
+		Name:      "msglinks_total",
+		Help:      "Current number of msg links. Alert if growing unbounded.",
+	})
+
+	cursorsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "monitor",
+		Subsystem: "indexer",
+		Name:      "cursors_total",
+		Help:      "Current number of cursors.",
+	})
 )
 
 type sample struct {
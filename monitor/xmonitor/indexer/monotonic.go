@@ -0,0 +1,77 @@
+package indexer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+// monotonicCursorTable wraps a CursorTable, instrumenting all cursor writes to detect regressions.
+// A regression is a write of a lower height than the existing (persisted) cursor of the same chain and conf level.
+// Regressions are logged and counted (alert metric), but still written, since this is a safety net
+// for cursor bugs in any code path, not a validation.
+type monotonicCursorTable struct {
+	CursorTable
+}
+
+// newMonotonicCursorTable returns the provided cursor table wrapped with regression detection.
+func newMonotonicCursorTable(table CursorTable) monotonicCursorTable {
+	return monotonicCursorTable{CursorTable: table}
+}
+
+func (t monotonicCursorTable) Insert(ctx context.Context, cursor *Cursor) error {
+	if err := t.checkMonotonic(ctx, cursor); err != nil {
+		return err
+	}
+
+	return t.CursorTable.Insert(ctx, cursor)
+}
+
+func (t monotonicCursorTable) Update(ctx context.Context, cursor *Cursor) error {
+	if err := t.checkMonotonic(ctx, cursor); err != nil {
+		return err
+	}
+
+	return t.CursorTable.Update(ctx, cursor)
+}
+
+func (t monotonicCursorTable) Save(ctx context.Context, cursor *Cursor) error {
+	if err := t.checkMonotonic(ctx, cursor); err != nil {
+		return err
+	}
+
+	return t.CursorTable.Save(ctx, cursor)
+}
+
+// checkMonotonic logs and counts a regression if the cursor height is lower than the existing cursor.
+// It only returns an error if the existing cursor cannot be read.
+func (t monotonicCursorTable) checkMonotonic(ctx context.Context, cursor *Cursor) error {
+	existing, err := t.CursorTable.Get(ctx, cursor.GetChainId(), cursor.GetConfLevel())
+	if ormerrors.IsNotFound(err) {
+		return nil // First write
+	} else if err != nil {
+		return errors.Wrap(err, "get existing cursor")
+	}
+
+	if regressed, err := existing.IsAhead(cursor); err != nil {
+		return err
+	} else if !regressed {
+		return nil
+	}
+
+	confLevel := xchain.ConfLevel(cursor.GetConfLevel())
+	cursorRegressions.WithLabelValues(strconv.FormatUint(cursor.GetChainId(), 10), confLevel.Label()).Inc()
+	log.Error(ctx, "Indexer cursor regression detected [BUG]", nil,
+		"chain_id", cursor.GetChainId(),
+		"conf_level", confLevel,
+		"existing", existing.GetBlockHeight(),
+		"new", cursor.GetBlockHeight(),
+	)
+
+	return nil
+}
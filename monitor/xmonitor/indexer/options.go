@@ -0,0 +1,87 @@
+package indexer
+
+import "time"
+
+// options defines the optional indexer configuration.
+type options struct {
+	// version is the encoding version of newly indexed blocks.
+	version uint32
+	// sink is the storage backend of indexed blocks, nil defaults to the ORM tables.
+	sink BlockSink
+	// projection defines the optional block fields to store, nil stores all fields.
+	projection projection
+	// countInterval is the interval of updating the table row count gauges, zero disables.
+	countInterval time.Duration
+	// batchCommit commits each indexed block, its msg links and cursor in a single batch.
+	batchCommit bool
+	// migrate re-encodes existing blocks not encoded with the version on startup.
+	migrate bool
+}
+
+// Option configures the indexer.
+type Option func(*options)
+
+// WithCompactEncoding returns an option that stores indexed blocks using the
+// compact XBlock protobuf encoding instead of JSON.
+// Existing JSON blocks are only re-encoded if WithMigration is also provided.
+func WithCompactEncoding() Option {
+	return func(o *options) {
+		o.version = versionProto
+	}
+}
+
+// WithBlockSink returns an option that writes indexed blocks, msg links and cursors
+// to the provided sink instead of the built-in ORM tables.
+// Note that pruning, encoding migration and msg latency metrics only apply to the ORM tables.
+func WithBlockSink(sink BlockSink) Option {
+	return func(o *options) {
+		o.sink = sink
+	}
+}
+
+// WithBlockProjection returns an option that only stores the provided optional block fields,
+// omitting the rest from newly indexed blocks. Fields required by the indexer are always stored.
+// This reduces the size of indexed blocks for space-constrained deployments.
+// Note that omitted fields of existing blocks cannot be restored when re-encoding, see WithCompactEncoding.
+func WithBlockProjection(fields ...BlockField) Option {
+	return func(o *options) {
+		o.projection = make(projection)
+		for _, field := range fields {
+			o.projection[field] = true
+		}
+	}
+}
+
+// WithCountInterval returns an option that updates the table row count gauges
+// (blocks per chain, msg links and cursors) every interval. Zero disables the gauges.
+func WithCountInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.countInterval = interval
+	}
+}
+
+// WithBatchCommit returns an option that writes each indexed block, all its msg links and
+// the cursor in a single atomic batch instead of individually. This speeds up indexing blocks
+// with many msgs or receipts and ensures the cursor never advances past a partially written block.
+// The block sink must implement BatchSink, which the built-in ORM tables do.
+func WithBatchCommit() Option {
+	return func(o *options) {
+		o.batchCommit = true
+	}
+}
+
+// WithMigration returns an option that re-encodes all existing blocks not encoded with the
+// configured encoding version on startup, see WithCompactEncoding. This scans the whole block
+// table, so it should only be enabled once after changing the encoding, not on every startup.
+func WithMigration() Option {
+	return func(o *options) {
+		o.migrate = true
+	}
+}
+
+func defaultOptions() options {
+	return options{
+		version:       versionJSON,
+		countInterval: time.Minute * 5,
+	}
+}
@@ -0,0 +1,87 @@
+package indexer
+
+import (
+	"slices"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockField is an optional xchain block field that can be omitted from indexed blocks, see WithBlockProjection.
+// Fields required by the indexer itself (msg IDs, senders, fees, gas, tx hashes, receipt results and timestamps)
+// are always stored.
+type BlockField string
+
+const (
+	FieldParentHash            BlockField = "parent_hash"
+	FieldMsgDestAddress        BlockField = "msg_dest_address"
+	FieldMsgData               BlockField = "msg_data"
+	FieldReceiptError          BlockField = "receipt_error"
+	FieldReceiptRelayerAddress BlockField = "receipt_relayer_address"
+)
+
+// allBlockFields are all optional block fields.
+var allBlockFields = []BlockField{
+	FieldParentHash,
+	FieldMsgDestAddress,
+	FieldMsgData,
+	FieldReceiptError,
+	FieldReceiptRelayerAddress,
+}
+
+// Verify returns an error if the field is unknown.
+func (f BlockField) Verify() error {
+	if !slices.Contains(allBlockFields, f) {
+		return errors.New("unknown block field", "field", f)
+	}
+
+	return nil
+}
+
+// projection defines the optional block fields stored by the indexer.
+// A nil projection stores all fields.
+type projection map[BlockField]bool
+
+// Project returns a copy of the block with all optional fields not included in the projection zeroed.
+func (p projection) Project(block xchain.Block) xchain.Block {
+	if p == nil {
+		return block
+	}
+
+	if !p[FieldParentHash] {
+		block.ParentHash = common.Hash{}
+	}
+
+	msgs := make([]xchain.Msg, 0, len(block.Msgs))
+	for _, msg := range block.Msgs {
+		if !p[FieldMsgDestAddress] {
+			msg.DestAddress = common.Address{}
+		}
+		if !p[FieldMsgData] {
+			msg.Data = nil
+		}
+		msgs = append(msgs, msg)
+	}
+
+	receipts := make([]xchain.Receipt, 0, len(block.Receipts))
+	for _, receipt := range block.Receipts {
+		if !p[FieldReceiptError] {
+			receipt.Error = nil
+		}
+		if !p[FieldReceiptRelayerAddress] {
+			receipt.RelayerAddress = common.Address{}
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if len(block.Msgs) > 0 {
+		block.Msgs = msgs
+	}
+	if len(block.Receipts) > 0 {
+		block.Receipts = receipts
+	}
+
+	return block
+}
@@ -0,0 +1,118 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"cosmossdk.io/orm/model/ormlist"
+	"cosmossdk.io/orm/types/ormerrors"
+	db "github.com/cosmos/cosmos-db"
+)
+
+// BlockSink is the storage backend the indexer writes indexed blocks, msg links and cursors to.
+// It allows feeding indexed data into custom datastores, while keeping the canonical schema semantics.
+type BlockSink interface {
+	// PutBlock stores the block and returns its ID.
+	// It is idempotent, returning the existing ID if the block was already stored.
+	PutBlock(ctx context.Context, block *Block) (uint64, error)
+	// PutMsgLink upserts the msg link, merging it with the existing link of the same IdHash.
+	// Only non-zero block IDs are merged, and it returns an error if an existing non-zero
+	// block ID differs. It returns the resulting merged link.
+	PutMsgLink(ctx context.Context, link *MsgLink) (*MsgLink, error)
+	// SaveCursor upserts the cursor of the chain and conf level.
+	SaveCursor(ctx context.Context, cursor *Cursor) error
+	// LatestBlock returns the highest stored block of the chain, or false if none exists.
+	LatestBlock(ctx context.Context, chainID uint64) (*Block, bool, error)
+}
+
+var _ BlockSink = ormSink{}
+
+// ormSink is the default BlockSink backed by the indexer ORM tables.
+type ormSink struct {
+	blockTable   BlockTable
+	msgLinkTable MsgLinkTable
+	cursorTable  CursorTable
+	db           db.DB // Backing DB of the tables used for batch commits, see Batch.
+}
+
+func (s ormSink) PutBlock(ctx context.Context, block *Block) (uint64, error) {
+	id, err := s.blockTable.InsertReturningId(ctx, block)
+	if errors.Is(err, ormerrors.UniqueKeyViolation) { // Idempotent
+		existing, err := s.blockTable.GetByChainIdBlockHeightBlockHash(ctx, block.GetChainId(), block.GetBlockHeight(), block.GetBlockHash())
+		if err != nil {
+			return 0, errors.Wrap(err, "get existing block")
+		}
+
+		return existing.GetId(), nil
+	} else if err != nil {
+		return 0, errors.Wrap(err, "insert block")
+	}
+
+	return id, nil
+}
+
+func (s ormSink) PutMsgLink(ctx context.Context, link *MsgLink) (*MsgLink, error) {
+	existing, err := s.msgLinkTable.Get(ctx, link.GetIdHash())
+	if ormerrors.IsNotFound(err) {
+		existing = &MsgLink{IdHash: link.GetIdHash()}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get msg link")
+	}
+
+	if id := link.GetMsgBlockId(); id != 0 {
+		if existing.GetMsgBlockId() != 0 && existing.GetMsgBlockId() != id {
+			return nil, errors.New("mismatching msg block id [BUG]",
+				"id_hash", link.Hash(),
+				"got", existing.GetMsgBlockId(),
+				"want", id,
+			)
+		}
+		existing.MsgBlockId = id
+	}
+
+	if id := link.GetReceiptBlockId(); id != 0 {
+		if existing.GetReceiptBlockId() != 0 && existing.GetReceiptBlockId() != id {
+			return nil, errors.New("mismatching receipt block id [BUG]",
+				"id_hash", link.Hash(),
+				"got", existing.GetReceiptBlockId(),
+				"want", id,
+			)
+		}
+		existing.ReceiptBlockId = id
+	}
+
+	if err := s.msgLinkTable.Save(ctx, existing); err != nil {
+		return nil, errors.Wrap(err, "save msg link")
+	}
+
+	return existing, nil
+}
+
+func (s ormSink) SaveCursor(ctx context.Context, cursor *Cursor) error {
+	if err := s.cursorTable.Save(ctx, cursor); err != nil {
+		return errors.Wrap(err, "save cursor")
+	}
+
+	return nil
+}
+
+func (s ormSink) LatestBlock(ctx context.Context, chainID uint64) (*Block, bool, error) {
+	key := BlockChainIdBlockHeightBlockHashIndexKey{}.WithChainId(chainID)
+	iter, err := s.blockTable.List(ctx, key, ormlist.Reverse())
+	if err != nil {
+		return nil, false, errors.Wrap(err, "list blocks")
+	}
+	defer iter.Close()
+
+	if !iter.Next() {
+		return nil, false, nil
+	}
+
+	block, err := iter.Value()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "get block value")
+	}
+
+	return block, true, nil
+}
@@ -0,0 +1,95 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/omni-network/omni/lib/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+// Status is the indexed status of a cross-chain message.
+type Status int
+
+const (
+	// StatusUnknown indicates that neither the message nor its receipt is indexed (or they were deleted).
+	StatusUnknown Status = iota
+	// StatusSubmitted indicates that the message block is indexed, but not its receipt block.
+	StatusSubmitted
+	// StatusDelivered indicates that the receipt block is indexed.
+	StatusDelivered
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusUnknown:
+		return "unknown"
+	case StatusSubmitted:
+		return "submitted"
+	case StatusDelivered:
+		return "delivered"
+	default:
+		return "invalid"
+	}
+}
+
+// MsgStatus is the indexed status of a cross-chain message including the heights of its indexed blocks.
+type MsgStatus struct {
+	Status         Status
+	MsgChainID     uint64 // Source chain ID of the msg block, zero if not indexed
+	MsgHeight      uint64 // Height of the msg block, zero if not indexed
+	ReceiptChainID uint64 // Destination chain ID of the receipt block, zero if not indexed
+	ReceiptHeight  uint64 // Height of the receipt block, zero if not indexed
+}
+
+// MessageStatus returns the indexed status of the message with the provided ID hash, see xchain.MsgID.Hash.
+// It performs a single msg link lookup by ID hash, and a primary key lookup of each linked block.
+// Note that fully indexed messages are eventually deleted, after which their status is unknown.
+func (i *indexer) MessageStatus(ctx context.Context, idHash common.Hash) (MsgStatus, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	link, err := i.msgLinkTable.Get(ctx, idHash.Bytes())
+	if ormerrors.IsNotFound(err) {
+		return MsgStatus{Status: StatusUnknown}, nil
+	} else if err != nil {
+		return MsgStatus{}, errors.Wrap(err, "get msg link")
+	}
+
+	var resp MsgStatus
+	if msgBlock, ok, err := i.getBlock(ctx, link.GetMsgBlockId()); err != nil {
+		return MsgStatus{}, errors.Wrap(err, "get msg block")
+	} else if ok {
+		resp.Status = StatusSubmitted
+		resp.MsgChainID = msgBlock.GetChainId()
+		resp.MsgHeight = msgBlock.GetBlockHeight()
+	}
+
+	if receiptBlock, ok, err := i.getBlock(ctx, link.GetReceiptBlockId()); err != nil {
+		return MsgStatus{}, errors.Wrap(err, "get receipt block")
+	} else if ok {
+		resp.Status = StatusDelivered
+		resp.ReceiptChainID = receiptBlock.GetChainId()
+		resp.ReceiptHeight = receiptBlock.GetBlockHeight()
+	}
+
+	return resp, nil
+}
+
+// getBlock returns the block with the provided ID, or false if the ID is zero or the block doesn't exist.
+func (i *indexer) getBlock(ctx context.Context, id uint64) (*Block, bool, error) {
+	if id == 0 {
+		return nil, false, nil
+	}
+
+	block, err := i.blockTable.Get(ctx, id)
+	if ormerrors.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errors.Wrap(err, "get block")
+	}
+
+	return block, true, nil
+}
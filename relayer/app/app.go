@@ -55,7 +55,10 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 
 	cprov := cprovider.NewABCIProvider(tmClient, network.ID, netconf.ChainVersionNamer(cfg.Network))
-	xprov := xprovider.New(network, rpcClientPerChain, cprov)
+	xprov, err := xprovider.New(network, rpcClientPerChain, cprov)
+	if err != nil {
+		return errors.Wrap(err, "create xprovider")
+	}
 
 	for _, destChain := range network.EVMChains() {
 		// Setup sender provider
@@ -120,7 +120,7 @@ func (s Sender) SendTransaction(ctx context.Context, sub xchain.Submission) erro
 		"msgs", len(sub.Msgs),
 	)
 
-	txData, err := xchain.EncodeXSubmit(xchain.SubmissionToBinding(sub))
+	txData, err := xchain.EncodeSubmission(sub)
 	if err != nil {
 		return err
 	}